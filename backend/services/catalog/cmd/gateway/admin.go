@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+)
+
+// requireAdmin accepts requests carrying "Authorization: Bearer <token>".
+func requireAdmin(token string) gin.HandlerFunc {
+	want := []byte("Bearer " + token)
+	return func(c *gin.Context) {
+		got := []byte(c.GetHeader("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "admin token required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+var overrideKinds = map[string]pb.MergeOverride_Kind{
+	"merge": pb.MergeOverride_KIND_MERGE,
+	"split": pb.MergeOverride_KIND_SPLIT,
+}
+
+// registerAdminRoutes exposes the merge override table. Without a token the
+// routes are not registered at all.
+func registerAdminRoutes(r *gin.Engine, client pb.CatalogClient, token string) {
+	if token == "" {
+		return
+	}
+	admin := r.Group("/v1/admin", requireAdmin(token))
+
+	admin.GET("/merge-overrides", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+		resp, err := client.ListMergeOverrides(ctx, &pb.ListMergeOverridesRequest{})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	admin.POST("/merge-overrides", func(c *gin.Context) {
+		var req struct {
+			Kind     string `json:"kind"`
+			KodikIDA string `json:"kodik_id_a"`
+			KodikIDB string `json:"kodik_id_b"`
+			Note     string `json:"note"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		kind, ok := overrideKinds[req.Kind]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "kind must be merge or split"})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+		resp, err := client.SetMergeOverride(ctx, &pb.MergeOverride{
+			Kind:     kind,
+			KodikIdA: req.KodikIDA,
+			KodikIdB: req.KodikIDB,
+			Note:     req.Note,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	admin.DELETE("/merge-overrides", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+		resp, err := client.DeleteMergeOverride(ctx, &pb.DeleteMergeOverrideRequest{
+			KodikIdA: c.Query("kodik_id_a"),
+			KodikIdB: c.Query("kodik_id_b"),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+)
+
+// queryInt32 reads an optional integer query parameter, 0 when absent.
+func queryInt32(c *gin.Context, name string) (int32, bool) {
+	v := c.Query(name)
+	if v == "" {
+		return 0, true
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": name + " must be an integer"})
+		return 0, false
+	}
+	return int32(n), true
+}
+
+func registerBrowseRoutes(r *gin.Engine, client pb.CatalogClient) {
+	r.GET("/v1/browse/trending", func(c *gin.Context) {
+		page, ok := queryInt32(c, "page")
+		if !ok {
+			return
+		}
+		pageSize, ok := queryInt32(c, "page_size")
+		if !ok {
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+		defer cancel()
+
+		resp, err := client.ListTrending(ctx, &pb.ListTrendingRequest{
+			Window:   c.Query("window"),
+			Page:     page,
+			PageSize: pageSize,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	r.GET("/v1/browse/top-rated", func(c *gin.Context) {
+		page, ok := queryInt32(c, "page")
+		if !ok {
+			return
+		}
+		pageSize, ok := queryInt32(c, "page_size")
+		if !ok {
+			return
+		}
+		minVotes, ok := queryInt32(c, "min_votes")
+		if !ok {
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+		defer cancel()
+
+		resp, err := client.ListTopRated(ctx, &pb.ListTopRatedRequest{
+			Source:   c.Query("source"),
+			MinVotes: minVotes,
+			Page:     page,
+			PageSize: pageSize,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	r.GET("/v1/browse/recently-updated", func(c *gin.Context) {
+		page, ok := queryInt32(c, "page")
+		if !ok {
+			return
+		}
+		pageSize, ok := queryInt32(c, "page_size")
+		if !ok {
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+		defer cancel()
+
+		resp, err := client.ListRecentlyUpdated(ctx, &pb.ListRecentlyUpdatedRequest{
+			Page:     page,
+			PageSize: pageSize,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	r.GET("/v1/genres", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		resp, err := client.ListGenres(ctx, &pb.ListGenresRequest{
+			Locale: c.Query("locale"),
+			Kind:   c.Query("kind"),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	// GET /v1/genres/fantasy/titles?with=adventure&without=ecchi&sort=year
+	r.GET("/v1/genres/:genre/titles", func(c *gin.Context) {
+		page, ok := queryInt32(c, "page")
+		if !ok {
+			return
+		}
+		pageSize, ok := queryInt32(c, "page_size")
+		if !ok {
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+		defer cancel()
+
+		resp, err := client.BrowseByGenre(ctx, &pb.BrowseByGenreRequest{
+			Genres:   append([]string{c.Param("genre")}, c.QueryArray("with")...),
+			Exclude:  c.QueryArray("without"),
+			Sort:     c.Query("sort"),
+			Order:    c.Query("order"),
+			Page:     page,
+			PageSize: pageSize,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+}
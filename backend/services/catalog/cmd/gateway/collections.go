@@ -0,0 +1,331 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var visibilityValues = map[string]librarypb.Visibility{
+	"private":  librarypb.Visibility_VISIBILITY_PRIVATE,
+	"unlisted": librarypb.Visibility_VISIBILITY_UNLISTED,
+	"public":   librarypb.Visibility_VISIBILITY_PUBLIC,
+}
+
+var visibilityNames = func() map[librarypb.Visibility]string {
+	m := make(map[librarypb.Visibility]string, len(visibilityValues))
+	for name, v := range visibilityValues {
+		m[v] = name
+	}
+	return m
+}()
+
+type collectionEntry struct {
+	KodikID   string    `json:"kodik_id"`
+	AniflowID string    `json:"aniflow_id,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	AddedAt   time.Time `json:"added_at"`
+	titleInfo
+}
+
+type collectionView struct {
+	ID          string            `json:"id"`
+	UserID      string            `json:"user_id"`
+	Slug        string            `json:"slug"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Visibility  string            `json:"visibility"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	Items       []collectionEntry `json:"items"`
+}
+
+func newCollectionView(c *librarypb.Collection) collectionView {
+	v := collectionView{
+		ID:          c.Id,
+		UserID:      c.UserId,
+		Slug:        c.Slug,
+		Name:        c.Name,
+		Description: c.Description,
+		Visibility:  visibilityNames[c.Visibility],
+		CreatedAt:   c.CreatedAt.AsTime(),
+		UpdatedAt:   c.UpdatedAt.AsTime(),
+		Items:       make([]collectionEntry, 0, len(c.Items)),
+	}
+	for _, it := range c.Items {
+		v.Items = append(v.Items, collectionEntry{
+			KodikID:   it.KodikId,
+			AniflowID: it.AniflowId,
+			Note:      it.Note,
+			AddedAt:   it.AddedAt.AsTime(),
+		})
+	}
+	return v
+}
+
+// libraryStatus maps a library error to the HTTP status to answer with.
+func libraryStatus(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// respondCollection writes c with catalog data for its titles.
+func respondCollection(ctx context.Context, c *gin.Context, client pb.CatalogClient, col *librarypb.Collection) {
+	v := newCollectionView(col)
+	refs := make([]titleRef, len(v.Items))
+	for i := range v.Items {
+		e := &v.Items[i]
+		refs[i] = titleRef{KodikID: e.KodikID, AniflowID: e.AniflowID, Info: &e.titleInfo}
+	}
+	if err := hydrate(ctx, client, refs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, v)
+}
+
+// respondCollections writes collections without per-title catalog data.
+func respondCollections(c *gin.Context, resp *librarypb.ListCollectionsResponse) {
+	out := make([]collectionView, 0, len(resp.Collections))
+	for _, col := range resp.Collections {
+		out = append(out, newCollectionView(col))
+	}
+	c.JSON(http.StatusOK, gin.H{"collections": out})
+}
+
+func registerCollectionRoutes(r *gin.Engine, client pb.CatalogClient, library librarypb.LibraryClient) {
+	// Owner routes. Like the watchlist, they act as the user in the path.
+	owner := r.Group("/v1/users/:user_id/collections")
+
+	owner.POST("", func(c *gin.Context) {
+		var req struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Visibility  string `json:"visibility"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		vis, ok := visibilityValues[req.Visibility]
+		if req.Visibility != "" && !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "visibility must be private, unlisted or public"})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		col, err := library.CreateCollection(ctx, &librarypb.CreateCollectionRequest{
+			UserId:      c.Param("user_id"),
+			Name:        req.Name,
+			Description: req.Description,
+			Visibility:  vis,
+		})
+		if err != nil {
+			c.JSON(libraryStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, newCollectionView(col))
+	})
+
+	owner.GET("", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		resp, err := library.ListCollections(ctx, &librarypb.ListCollectionsRequest{
+			UserId:   c.Param("user_id"),
+			ViewerId: c.Param("user_id"),
+		})
+		if err != nil {
+			c.JSON(libraryStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		respondCollections(c, resp)
+	})
+
+	owner.GET("/:collection_id", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+		defer cancel()
+
+		col, err := library.GetCollection(ctx, &librarypb.GetCollectionRequest{
+			CollectionId: c.Param("collection_id"),
+			ViewerId:     c.Param("user_id"),
+		})
+		if err != nil {
+			c.JSON(libraryStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		respondCollection(ctx, c, client, col)
+	})
+
+	owner.PATCH("/:collection_id", func(c *gin.Context) {
+		var req struct {
+			Name        *string `json:"name"`
+			Description *string `json:"description"`
+			Visibility  string  `json:"visibility"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		vis, ok := visibilityValues[req.Visibility]
+		if req.Visibility != "" && !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "visibility must be private, unlisted or public"})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		col, err := library.UpdateCollection(ctx, &librarypb.UpdateCollectionRequest{
+			UserId:       c.Param("user_id"),
+			CollectionId: c.Param("collection_id"),
+			Name:         req.Name,
+			Description:  req.Description,
+			Visibility:   vis,
+		})
+		if err != nil {
+			c.JSON(libraryStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, newCollectionView(col))
+	})
+
+	owner.DELETE("/:collection_id", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		_, err := library.DeleteCollection(ctx, &librarypb.DeleteCollectionRequest{
+			UserId:       c.Param("user_id"),
+			CollectionId: c.Param("collection_id"),
+		})
+		if err != nil {
+			c.JSON(libraryStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	owner.POST("/:collection_id/items", func(c *gin.Context) {
+		var req struct {
+			KodikID   string `json:"kodik_id"`
+			AniflowID string `json:"aniflow_id"`
+			Note      string `json:"note"`
+			Position  *int32 `json:"position"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.KodikID == "" && req.AniflowID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "kodik_id or aniflow_id required"})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+		defer cancel()
+
+		// Look the title up first so the collection stores its stable id.
+		anime, err := client.GetAnime(ctx, &pb.GetAnimeRequest{
+			KodikId:   req.KodikID,
+			AniflowId: req.AniflowID,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if req.KodikID == "" {
+			req.KodikID = anime.KodikId
+		}
+		col, err := library.AddCollectionItem(ctx, &librarypb.AddCollectionItemRequest{
+			UserId:       c.Param("user_id"),
+			CollectionId: c.Param("collection_id"),
+			KodikId:      req.KodikID,
+			AniflowId:    anime.AniflowId,
+			Note:         req.Note,
+			Position:     req.Position,
+		})
+		if err != nil {
+			c.JSON(libraryStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		respondCollection(ctx, c, client, col)
+	})
+
+	owner.DELETE("/:collection_id/items/:kodik_id", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+		defer cancel()
+
+		col, err := library.RemoveCollectionItem(ctx, &librarypb.RemoveCollectionItemRequest{
+			UserId:       c.Param("user_id"),
+			CollectionId: c.Param("collection_id"),
+			KodikId:      c.Param("kodik_id"),
+		})
+		if err != nil {
+			c.JSON(libraryStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		respondCollection(ctx, c, client, col)
+	})
+
+	owner.PUT("/:collection_id/order", func(c *gin.Context) {
+		var req struct {
+			KodikIDs []string `json:"kodik_ids"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+		defer cancel()
+
+		col, err := library.ReorderCollection(ctx, &librarypb.ReorderCollectionRequest{
+			UserId:       c.Param("user_id"),
+			CollectionId: c.Param("collection_id"),
+			KodikIds:     req.KodikIDs,
+		})
+		if err != nil {
+			c.JSON(libraryStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		respondCollection(ctx, c, client, col)
+	})
+
+	// Public read access: shared lists by slug and a user's public lists.
+	r.GET("/v1/lists/:slug", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+		defer cancel()
+
+		col, err := library.GetCollection(ctx, &librarypb.GetCollectionRequest{Slug: c.Param("slug")})
+		if err != nil {
+			c.JSON(libraryStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		respondCollection(ctx, c, client, col)
+	})
+
+	r.GET("/v1/profiles/:user_id/collections", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		resp, err := library.ListCollections(ctx, &librarypb.ListCollectionsRequest{UserId: c.Param("user_id")})
+		if err != nil {
+			c.JSON(libraryStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		respondCollections(c, resp)
+	})
+}
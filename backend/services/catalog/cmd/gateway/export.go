@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+	"google.golang.org/grpc"
+)
+
+var exportFormats = map[string]librarypb.ExportFormat{
+	"mal":  librarypb.ExportFormat_EXPORT_FORMAT_MAL_XML,
+	"csv":  librarypb.ExportFormat_EXPORT_FORMAT_CSV,
+	"json": librarypb.ExportFormat_EXPORT_FORMAT_JSON,
+}
+
+// registerExportRoutes fails exports whose reply exceeds maxSize bytes; a
+// JSON export with history is the largest.
+func registerExportRoutes(r *gin.Engine, library librarypb.LibraryClient, maxSize int) {
+	r.GET("/v1/users/:user_id/export", func(c *gin.Context) {
+		format, ok := exportFormats[c.DefaultQuery("format", "json")]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format must be mal, csv or json"})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+		defer cancel()
+
+		resp, err := library.ExportLibrary(ctx, &librarypb.ExportLibraryRequest{
+			UserId: c.Param("user_id"),
+			Format: format,
+		}, grpc.MaxCallRecvMsgSize(maxSize))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": resp.Filename}))
+		c.Data(http.StatusOK, resp.ContentType, resp.Data)
+	})
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const readyTimeout = 2 * time.Second
+
+// healthCheck is one dependency /readyz asks about: service on the gRPC
+// health server behind conn.
+type healthCheck struct {
+	name    string
+	conn    *grpc.ClientConn
+	service string
+}
+
+// registerHealthRoutes serves /healthz, which only says the process is up,
+// and /readyz, which is 503 while draining or while any dependency is not
+// serving. The per-dependency statuses are in the body either way.
+func registerHealthRoutes(r *gin.Engine, draining *atomic.Bool, checks []healthCheck) {
+	r.GET("/healthz", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	r.GET("/readyz", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readyTimeout)
+		defer cancel()
+
+		statuses := make(map[string]string, len(checks))
+		ready := !draining.Load()
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, hc := range checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				status := "SERVING"
+				resp, err := healthpb.NewHealthClient(hc.conn).Check(ctx, &healthpb.HealthCheckRequest{Service: hc.service})
+				switch {
+				case err != nil:
+					status = err.Error()
+				case resp.Status != healthpb.HealthCheckResponse_SERVING:
+					status = resp.Status.String()
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				statuses[hc.name] = status
+				if status != "SERVING" {
+					ready = false
+				}
+			}()
+		}
+		wg.Wait()
+
+		code := http.StatusOK
+		if !ready {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, gin.H{
+			"ready":    ready,
+			"draining": draining.Load(),
+			"checks":   statuses,
+		})
+	})
+}
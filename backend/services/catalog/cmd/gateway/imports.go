@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+	"google.golang.org/grpc"
+)
+
+var importFormats = map[string]librarypb.ImportFormat{
+	"mal":       librarypb.ImportFormat_IMPORT_FORMAT_MAL_XML,
+	"shikimori": librarypb.ImportFormat_IMPORT_FORMAT_SHIKIMORI_JSON,
+}
+
+// readUpload returns the export from a multipart "file" field or, failing
+// that, the raw request body, rejecting anything over maxSize bytes.
+func readUpload(c *gin.Context, maxSize int) ([]byte, error) {
+	var r io.Reader = c.Request.Body
+	if fh, err := c.FormFile("file"); err == nil {
+		f, err := fh.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	data, err := io.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxSize {
+		return nil, fmt.Errorf("export is larger than %d bytes", maxSize)
+	}
+	return data, nil
+}
+
+// registerImportRoutes accepts exports of up to maxSize bytes, which should
+// not exceed the library's own limit.
+func registerImportRoutes(r *gin.Engine, client pb.CatalogClient, library librarypb.LibraryClient, maxSize int) {
+	r.POST("/v1/users/:user_id/imports", func(c *gin.Context) {
+		format, ok := importFormats[c.Query("format")]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format must be mal or shikimori"})
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, int64(maxSize)+1<<20)
+		data, err := readUpload(c, maxSize)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+		defer cancel()
+
+		job, err := library.StartImport(ctx, &librarypb.StartImportRequest{
+			UserId:    c.Param("user_id"),
+			Format:    format,
+			Data:      data,
+			Overwrite: c.Query("overwrite") == "true",
+		}, grpc.MaxCallSendMsgSize(maxSize+1<<10))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusAccepted, job)
+	})
+
+	r.GET("/v1/users/:user_id/imports/:job_id", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		job, err := library.GetImportJob(ctx, &librarypb.GetImportJobRequest{
+			UserId: c.Param("user_id"),
+			JobId:  c.Param("job_id"),
+		})
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, job)
+	})
+
+	// Resolving maps an unmatched entry to a title the user found by hand.
+	r.POST("/v1/users/:user_id/imports/:job_id/resolve", func(c *gin.Context) {
+		var req struct {
+			ExternalID string `json:"external_id"`
+			KodikID    string `json:"kodik_id"`
+			AniflowID  string `json:"aniflow_id"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.ExternalID == "" || (req.KodikID == "" && req.AniflowID == "") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "external_id and kodik_id or aniflow_id required"})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		anime, err := client.GetAnime(ctx, &pb.GetAnimeRequest{
+			KodikId:   req.KodikID,
+			AniflowId: req.AniflowID,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if req.KodikID == "" {
+			req.KodikID = anime.KodikId
+		}
+
+		job, err := library.ResolveImportEntry(ctx, &librarypb.ResolveImportEntryRequest{
+			UserId:     c.Param("user_id"),
+			JobId:      c.Param("job_id"),
+			ExternalId: req.ExternalID,
+			KodikId:    req.KodikID,
+			AniflowId:  anime.AniflowId,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, job)
+	})
+}
@@ -3,31 +3,36 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
-	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	"github.com/greg5320/AniFlow/backend/services/internal/config"
+	"github.com/greg5320/AniFlow/backend/services/internal/logging"
+	"github.com/greg5320/AniFlow/backend/services/internal/metrics"
+	"github.com/greg5320/AniFlow/backend/services/internal/shutdown"
+	"github.com/greg5320/AniFlow/backend/services/internal/tracing"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/connectivity"
 )
 
-func main() {
-	grpcAddr := os.Getenv("CATALOG_GRPC_ADDR")
-	if grpcAddr == "" {
-		grpcAddr = "localhost:50051"
-	}
-
-	cc, err := grpc.NewClient(grpcAddr,
+func dial(logger *slog.Logger, addr string) *grpc.ClientConn {
+	cc, err := grpc.NewClient(addr, append(logging.DialOptions(),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)...)
 	if err != nil {
-		log.Fatalf("failed to create gRPC client: %v", err)
+		logging.Fatal(logger, "failed to create gRPC client", "addr", addr, "error", err)
 	}
-	defer cc.Close()
 
 	cc.Connect()
 
@@ -36,18 +41,43 @@ func main() {
 	for {
 		state := cc.GetState()
 		if state == connectivity.Ready {
-			log.Printf("gRPC connection to %s is READY", grpcAddr)
+			logger.Info("gRPC connection ready", "addr", addr)
 			break
 		}
 		if !cc.WaitForStateChange(ctxWait, state) {
-			log.Printf("timed out waiting for connection state change; current state=%s", cc.GetState())
+			logger.Warn("timed out waiting for gRPC connection", "addr", addr, "state", cc.GetState().String())
 			break
 		}
 	}
+	return cc
+}
+
+func main() {
+	cfg := config.MustLoad(config.GatewayService)
+	logger, err := logging.Setup("gateway", logging.Options{Level: cfg.Log.Level, Format: cfg.Log.Format})
+	if err != nil {
+		log.Fatalf("logging: %v", err)
+	}
+	ctx, stop := shutdown.Context()
+	defer stop()
+
+	shutdownTracing, err := tracing.Init(context.Background(), "gateway")
+	if err != nil {
+		logging.Fatal(logger, "tracing", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	cc := dial(logger, cfg.Gateway.CatalogAddr)
+	defer cc.Close()
+	lc := dial(logger, cfg.Gateway.LibraryAddr)
+	defer lc.Close()
 
 	client := pb.NewCatalogClient(cc)
+	library := librarypb.NewLibraryClient(lc)
 
-	r := gin.Default()
+	r := gin.New()
+	r.Use(gin.Recovery(), metrics.Gin(), otelgin.Middleware("gateway"), logging.Gin(logger))
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	r.POST("/v1/search", func(c *gin.Context) {
 		var req struct {
@@ -82,6 +112,23 @@ func main() {
 		c.JSON(http.StatusOK, grpcResp)
 	})
 
+	r.GET("/v1/titles/:aniflow_id", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		grpcResp, err := client.GetAnime(ctx, &pb.GetAnimeRequest{AniflowId: c.Param("aniflow_id")})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		b, err := json.Marshal(grpcResp)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, "application/json", b)
+	})
+
 	r.GET("/v1/anime/:kodik_id", func(c *gin.Context) {
 		kid := c.Param("kodik_id")
 		if kid == "" {
@@ -104,9 +151,113 @@ func main() {
 		c.Data(http.StatusOK, "application/json", b)
 	})
 
-	httpPort := os.Getenv("GATEWAY_PORT")
-	log.Printf("gateway listening on :%s, proxying to %s", httpPort, grpcAddr)
-	if err := r.Run(":" + httpPort); err != nil {
-		log.Fatalf("gateway failed: %v", err)
+	r.POST("/v1/anime/batch", func(c *gin.Context) {
+		var req struct {
+			KodikIDs   []string `json:"kodik_ids"`
+			AniflowIDs []string `json:"aniflow_ids"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if len(req.KodikIDs)+len(req.AniflowIDs) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "kodik_ids or aniflow_ids required"})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+		defer cancel()
+
+		grpcResp, err := client.BatchGetAnime(ctx, &pb.BatchGetAnimeRequest{
+			KodikIds:   req.KodikIDs,
+			AniflowIds: req.AniflowIDs,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		b, err := json.Marshal(grpcResp)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, "application/json", b)
+	})
+
+	r.GET("/v1/schedule", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+		defer cancel()
+
+		grpcResp, err := client.GetSchedule(ctx, &pb.GetScheduleRequest{
+			UserId:   c.Query("user_id"),
+			Timezone: c.Query("timezone"),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		b, err := json.Marshal(grpcResp)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, "application/json", b)
+	})
+
+	r.GET("/v1/resolve", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		grpcResp, err := client.ResolveAnime(ctx, &pb.ResolveAnimeRequest{
+			KinopoiskId:  c.Query("kinopoisk_id"),
+			ShikimoriId:  c.Query("shikimori_id"),
+			MalId:        c.Query("mal_id"),
+			ImdbId:       c.Query("imdb_id"),
+			WorldartLink: c.Query("worldart_link"),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		b, err := json.Marshal(grpcResp)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, "application/json", b)
+	})
+
+	registerStreamRoutes(r, client)
+	registerRelatedRoutes(r, client)
+	registerBrowseRoutes(r, client)
+	registerNotificationRoutes(r, client)
+	registerWatchlistRoutes(r, client, library)
+	registerCollectionRoutes(r, client, library)
+	registerImportRoutes(r, client, library, cfg.Gateway.MaxImportBytes)
+	registerExportRoutes(r, library, cfg.Gateway.MaxExportBytes)
+	registerRecommendRoutes(r, client, library)
+	registerAdminRoutes(r, client, cfg.Gateway.AdminToken)
+
+	var draining atomic.Bool
+	registerHealthRoutes(r, &draining, []healthCheck{
+		{name: "catalog", conn: cc, service: pb.Catalog_ServiceDesc.ServiceName},
+		{name: "library", conn: lc, service: librarypb.Library_ServiceDesc.ServiceName},
+		{name: "kodik", conn: cc, service: "kodik"},
+	})
+
+	logger.Info("gateway listening", "port", cfg.Gateway.Port, "catalog", cfg.Gateway.CatalogAddr, "library", cfg.Gateway.LibraryAddr)
+	serveCtx, stopServing := context.WithCancel(context.Background())
+	// /readyz fails for the drain delay before the listener closes, so
+	// load balancers stop sending traffic first.
+	go func() {
+		<-ctx.Done()
+		logger.Info("shutting down")
+		draining.Store(true)
+		time.Sleep(time.Duration(cfg.Gateway.DrainDelay))
+		stopServing()
+	}()
+	httpServer := &http.Server{Addr: fmt.Sprintf(":%d", cfg.Gateway.Port), Handler: r}
+	if err := shutdown.HTTP(serveCtx, httpServer, time.Duration(cfg.Gateway.ShutdownTimeout)); err != nil {
+		logging.Fatal(logger, "gateway failed", "error", err)
 	}
+	logger.Info("gateway stopped")
 }
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+)
+
+func registerNotificationRoutes(r *gin.Engine, client pb.CatalogClient) {
+	r.GET("/v1/users/:user_id/notifications", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		resp, err := client.ListNotifications(ctx, &pb.ListNotificationsRequest{
+			UserId:     c.Param("user_id"),
+			UnreadOnly: c.Query("unread_only") == "true",
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	r.POST("/v1/users/:user_id/notifications/read", func(c *gin.Context) {
+		var req struct {
+			IDs []string `json:"ids"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		resp, err := client.MarkNotificationsRead(ctx, &pb.MarkNotificationsReadRequest{
+			UserId: c.Param("user_id"),
+			Ids:    req.IDs,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	// Server-Sent Events: unread notifications first, then live ones as
+	// "notification" events for as long as the client stays connected.
+	r.GET("/v1/users/:user_id/notifications/stream", func(c *gin.Context) {
+		stream, err := client.SubscribeNotifications(c.Request.Context(), &pb.SubscribeNotificationsRequest{
+			UserId: c.Param("user_id"),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Writer.Flush()
+		for {
+			n, err := stream.Recv()
+			if err != nil {
+				if c.Request.Context().Err() == nil {
+					sseError(c, err)
+				}
+				return
+			}
+			b, err := json.Marshal(n)
+			if err != nil {
+				sseError(c, err)
+				return
+			}
+			c.SSEvent("notification", string(b))
+			c.Writer.Flush()
+		}
+	})
+}
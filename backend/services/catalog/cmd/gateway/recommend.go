@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	"github.com/greg5320/AniFlow/backend/services/internal/titles"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+)
+
+type similarEntry struct {
+	KodikID   string    `json:"kodik_id"`
+	AniflowID string    `json:"aniflow_id,omitempty"`
+	Score     float64   `json:"score"`
+	Users     int32     `json:"users"`
+	Anime     *pb.Anime `json:"anime,omitempty"`
+}
+
+func registerRecommendRoutes(r *gin.Engine, client pb.CatalogClient, library librarypb.LibraryClient) {
+	similar := func(c *gin.Context, req *pb.GetAnimeRequest) {
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+		defer cancel()
+
+		anime, err := client.GetAnime(ctx, req)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := library.GetSimilar(ctx, &librarypb.GetSimilarRequest{
+			KodikId:   anime.KodikId,
+			AniflowId: anime.AniflowId,
+			Limit:     int32(min(limit, titles.BatchSize)),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		entries := make([]similarEntry, 0, len(resp.Items))
+		ids := make([]string, 0, len(resp.Items))
+		for _, it := range resp.Items {
+			entries = append(entries, similarEntry{
+				KodikID:   it.KodikId,
+				AniflowID: it.AniflowId,
+				Score:     it.Score,
+				Users:     it.Users,
+			})
+			ids = append(ids, it.KodikId)
+		}
+		if len(ids) > 0 {
+			batch, err := client.BatchGetAnime(ctx, &pb.BatchGetAnimeRequest{KodikIds: ids})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			byKodik := make(map[string]*pb.Anime, len(batch.Results))
+			for _, res := range batch.Results {
+				byKodik[res.KodikId] = res.Anime
+			}
+			for i := range entries {
+				entries[i].Anime = byKodik[entries[i].KodikID]
+			}
+		}
+		c.JSON(http.StatusOK, gin.H{"items": entries, "computed_at": resp.ComputedAt.AsTime()})
+	}
+
+	r.GET("/v1/anime/:kodik_id/similar", func(c *gin.Context) {
+		similar(c, &pb.GetAnimeRequest{KodikId: c.Param("kodik_id")})
+	})
+	r.GET("/v1/titles/:aniflow_id/similar", func(c *gin.Context) {
+		similar(c, &pb.GetAnimeRequest{AniflowId: c.Param("aniflow_id")})
+	})
+
+	r.GET("/v1/users/:user_id/recommendations", func(c *gin.Context) {
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+		defer cancel()
+
+		resp, err := client.Recommend(ctx, &pb.RecommendRequest{
+			UserId: c.Param("user_id"),
+			Limit:  int32(limit),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+}
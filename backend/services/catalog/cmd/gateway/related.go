@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+)
+
+func registerRelatedRoutes(r *gin.Engine, client pb.CatalogClient) {
+	related := func(c *gin.Context, req *pb.GetRelatedRequest) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		resp, err := client.GetRelated(ctx, req)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+
+	r.GET("/v1/titles/:aniflow_id/related", func(c *gin.Context) {
+		related(c, &pb.GetRelatedRequest{AniflowId: c.Param("aniflow_id")})
+	})
+	r.GET("/v1/anime/:kodik_id/related", func(c *gin.Context) {
+		related(c, &pb.GetRelatedRequest{KodikId: c.Param("kodik_id")})
+	})
+}
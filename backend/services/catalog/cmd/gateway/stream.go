@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+)
+
+func sseError(c *gin.Context, err error) {
+	b, _ := json.Marshal(gin.H{"error": err.Error()})
+	c.SSEvent("error", string(b))
+	c.Writer.Flush()
+}
+
+func registerStreamRoutes(r *gin.Engine, client pb.CatalogClient) {
+	// Server-Sent Events: one "item" event per SearchStreamResponse, then
+	// "done", or "error" if the search fails midway.
+	r.GET("/v1/search/stream", func(c *gin.Context) {
+		query := c.Query("query")
+		if query == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "query required"})
+			return
+		}
+		pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+		defer cancel()
+
+		stream, err := client.SearchStream(ctx, &pb.SearchRequest{
+			Query:    query,
+			Page:     1,
+			PageSize: int32(pageSize),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				c.SSEvent("done", "{}")
+				c.Writer.Flush()
+				return
+			}
+			if err != nil {
+				sseError(c, err)
+				return
+			}
+			b, err := json.Marshal(msg)
+			if err != nil {
+				sseError(c, err)
+				return
+			}
+			c.SSEvent("item", string(b))
+			c.Writer.Flush()
+		}
+	})
+}
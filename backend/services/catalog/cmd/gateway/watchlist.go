@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	"github.com/greg5320/AniFlow/backend/services/internal/titles"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+)
+
+// titleInfo is the catalog data shown next to a stored kodik_id.
+type titleInfo struct {
+	Title         string `json:"title,omitempty"`
+	PosterURL     string `json:"poster_url,omitempty"`
+	EpisodesCount int32  `json:"episodes_count,omitempty"`
+	LastEpisode   int32  `json:"last_episode,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// titleRef points hydrate at the info to fill for one stored title.
+type titleRef struct {
+	KodikID   string
+	AniflowID string
+	Info      *titleInfo
+}
+
+type watchlistEntry struct {
+	ID        string    `json:"id"`
+	KodikID   string    `json:"kodik_id"`
+	AniflowID string    `json:"aniflow_id,omitempty"`
+	AddedAt   time.Time `json:"added_at"`
+	Status    string    `json:"status"`
+	Score     int32     `json:"score,omitempty"`
+	Progress  int32     `json:"progress"`
+	Notes     string    `json:"notes,omitempty"`
+	titleInfo
+}
+
+// hydrate fills catalog fields for every ref. A failed lookup is reported
+// on the entry itself so one bad title does not hide the list.
+func hydrate(ctx context.Context, client pb.CatalogClient, refs []titleRef) error {
+	ids := make([]titles.Ref, len(refs))
+	for i, ref := range refs {
+		ids[i] = titles.Ref{KodikID: ref.KodikID, AniflowID: ref.AniflowID}
+	}
+	results, err := titles.Lookup(ctx, client, ids)
+	if err != nil {
+		return err
+	}
+	for i, res := range results {
+		if res == nil {
+			continue
+		}
+		e := refs[i].Info
+		if res.Anime == nil {
+			e.Error = res.Error
+			continue
+		}
+		a := res.Anime
+		e.Title = a.Title
+		e.PosterURL = a.PosterUrl
+		if e.PosterURL == "" {
+			e.PosterURL = a.AnimePosterUrl
+		}
+		e.EpisodesCount = a.EpisodesCount
+		e.LastEpisode = a.LastEpisode
+	}
+	return nil
+}
+
+func registerWatchlistRoutes(r *gin.Engine, client pb.CatalogClient, library librarypb.LibraryClient) {
+	r.POST("/v1/users/:user_id/watchlist", func(c *gin.Context) {
+		var req struct {
+			KodikID   string `json:"kodik_id"`
+			AniflowID string `json:"aniflow_id"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.KodikID == "" && req.AniflowID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "kodik_id or aniflow_id required"})
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		// Look the title up first so the library stores its stable id.
+		anime, err := client.GetAnime(ctx, &pb.GetAnimeRequest{
+			KodikId:   req.KodikID,
+			AniflowId: req.AniflowID,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if req.KodikID == "" {
+			req.KodikID = anime.KodikId
+		}
+
+		resp, err := library.AddToWatchlist(ctx, &librarypb.AddRequest{
+			UserId:    c.Param("user_id"),
+			KodikId:   req.KodikID,
+			AniflowId: anime.AniflowId,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	r.PATCH("/v1/users/:user_id/watchlist/:kodik_id", func(c *gin.Context) {
+		var req struct {
+			Status   string  `json:"status"`
+			Score    *int32  `json:"score"`
+			Progress *int32  `json:"progress"`
+			Notes    *string `json:"notes"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		update := &librarypb.UpdateItemRequest{
+			UserId:   c.Param("user_id"),
+			KodikId:  c.Param("kodik_id"),
+			Score:    req.Score,
+			Progress: req.Progress,
+			Notes:    req.Notes,
+		}
+		if req.Status != "" {
+			st, ok := librarypb.ParseStatus(req.Status)
+			if !ok {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "unknown status " + req.Status})
+				return
+			}
+			update.Status = st
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		defer cancel()
+
+		resp, err := library.UpdateItem(ctx, update)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	r.GET("/v1/users/:user_id/watchlist", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+		defer cancel()
+
+		wl, err := library.GetWatchlist(ctx, &librarypb.GetWatchlistRequest{UserId: c.Param("user_id")})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		entries := make([]watchlistEntry, 0, len(wl.Items))
+		for _, it := range wl.Items {
+			entries = append(entries, watchlistEntry{
+				ID:        it.Id,
+				KodikID:   it.KodikId,
+				AniflowID: it.AniflowId,
+				AddedAt:   it.AddedAt.AsTime(),
+				Status:    it.Status.Name(),
+				Score:     it.Score,
+				Progress:  it.Progress,
+				Notes:     it.Notes,
+			})
+		}
+		refs := make([]titleRef, len(entries))
+		for i := range entries {
+			e := &entries[i]
+			refs[i] = titleRef{KodikID: e.KodikID, AniflowID: e.AniflowID, Info: &e.titleInfo}
+		}
+		if err := hydrate(ctx, client, refs); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": entries})
+	})
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+)
+
+const (
+	// maxBatchSize bounds a single BatchGetAnime call; batchConcurrency
+	// bounds how many of its ids are resolved against Kodik at once.
+	maxBatchSize     = 100
+	batchConcurrency = 8
+)
+
+func (s *server) BatchGetAnime(ctx context.Context, req *pb.BatchGetAnimeRequest) (*pb.BatchGetAnimeResponse, error) {
+	if req == nil || len(req.KodikIds)+len(req.AniflowIds) == 0 {
+		return nil, fmt.Errorf("kodik_ids or aniflow_ids required")
+	}
+
+	// Each job is either a kodik_id or an aniflow_id lookup.
+	var jobs []*pb.BatchGetAnimeResult
+	seen := make(map[string]bool)
+	for _, id := range req.KodikIds {
+		if id == "" || seen["k:"+id] {
+			continue
+		}
+		seen["k:"+id] = true
+		jobs = append(jobs, &pb.BatchGetAnimeResult{KodikId: id})
+	}
+	for _, id := range req.AniflowIds {
+		if id == "" || seen["a:"+id] {
+			continue
+		}
+		seen["a:"+id] = true
+		jobs = append(jobs, &pb.BatchGetAnimeResult{AniflowId: id})
+	}
+	if len(jobs) > maxBatchSize {
+		return nil, fmt.Errorf("too many ids: %d (max %d)", len(jobs), maxBatchSize)
+	}
+
+	sem := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+	for _, res := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(res *pb.BatchGetAnimeResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var a *pb.Anime
+			var err error
+			if res.KodikId != "" {
+				a, err = s.getAnime(ctx, res.KodikId)
+			} else {
+				a, err = s.getAnimeByAniflowID(ctx, res.AniflowId)
+			}
+			if err != nil {
+				res.Error = err.Error()
+			} else {
+				res.Anime = a
+			}
+		}(res)
+	}
+	wg.Wait()
+
+	return &pb.BatchGetAnimeResponse{Results: jobs}, nil
+}
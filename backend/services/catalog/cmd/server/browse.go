@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// maxRecentPages bounds the Kodik pages (100 materials each) walked for
+	// the recently updated feed, which is rewalked from the start once it
+	// is recentTTL old.
+	maxRecentPages = 10
+	recentTTL      = 5 * time.Minute
+)
+
+var trendingWindows = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+}
+
+// ratingSource reads one rating and its vote count from a material.
+type ratingSource struct {
+	rating   func(kodik.Material) float64
+	votes    func(kodik.Material) int
+	minVotes int
+}
+
+var ratingSources = map[string]ratingSource{
+	"kinopoisk": {
+		rating:   func(m kodik.Material) float64 { return m.KinopoiskRating },
+		votes:    func(m kodik.Material) int { return m.KinopoiskVotes },
+		minVotes: 1000,
+	},
+	"shikimori": {
+		rating:   func(m kodik.Material) float64 { return m.ShikimoriRating },
+		votes:    func(m kodik.Material) int { return m.ShikimoriVotes },
+		minVotes: 500,
+	},
+}
+
+// paging applies defaults and limits to 1-based paging parameters.
+func paging(page, pageSize int32) (int32, int32) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return page, min(pageSize, maxPageSize)
+}
+
+// pageRange returns the normalized page and its slice bounds within total
+// items.
+func pageRange(page, pageSize int32, total int) (int32, int, int) {
+	page, pageSize = paging(page, pageSize)
+	start := min(int(page-1)*int(pageSize), total)
+	end := min(start+int(pageSize), total)
+	return page, start, end
+}
+
+// ListTrending ranks titles by AniFlow's own add and progress activity.
+func (s *server) ListTrending(ctx context.Context, req *pb.ListTrendingRequest) (*pb.ListTitlesResponse, error) {
+	name := req.GetWindow()
+	if name == "" {
+		name = "week"
+	}
+	window, ok := trendingWindows[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown window %q, want day, week or month", name)
+	}
+	trending, err := s.library.GetTrending(ctx, &librarypb.GetTrendingRequest{
+		Window: durationpb.New(window),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page, start, end := pageRange(req.GetPage(), req.GetPageSize(), len(trending.Items))
+	resp := &pb.ListTitlesResponse{
+		Page:    page,
+		Total:   int32(len(trending.Items)),
+		HasMore: end < len(trending.Items),
+	}
+	// maxPageSize keeps a page within one batch.
+	batch := &pb.BatchGetAnimeRequest{}
+	for _, it := range trending.Items[start:end] {
+		if it.AniflowId != "" {
+			batch.AniflowIds = append(batch.AniflowIds, it.AniflowId)
+		} else {
+			batch.KodikIds = append(batch.KodikIds, it.KodikId)
+		}
+	}
+	if len(batch.AniflowIds)+len(batch.KodikIds) == 0 {
+		return resp, nil
+	}
+	found, err := s.BatchGetAnime(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*pb.BatchGetAnimeResult, len(found.Results))
+	for _, res := range found.Results {
+		if res.AniflowId != "" {
+			byID["a:"+res.AniflowId] = res
+		} else {
+			byID["k:"+res.KodikId] = res
+		}
+	}
+	for _, it := range trending.Items[start:end] {
+		key := "k:" + it.KodikId
+		if it.AniflowId != "" {
+			key = "a:" + it.AniflowId
+		}
+		res := byID[key]
+		if res.GetAnime() == nil {
+			return nil, fmt.Errorf("resolve trending title %s: %s", key[2:], res.GetError())
+		}
+		resp.Items = append(resp.Items, res.Anime)
+	}
+	return resp, nil
+}
+
+// ListTopRated ranks indexed titles by Kinopoisk or Shikimori rating,
+// leaving out titles with too few votes for the rating to mean much.
+func (s *server) ListTopRated(ctx context.Context, req *pb.ListTopRatedRequest) (*pb.ListTitlesResponse, error) {
+	name := req.GetSource()
+	if name == "" {
+		name = "kinopoisk"
+	}
+	src, ok := ratingSources[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown source %q, want kinopoisk or shikimori", name)
+	}
+	minVotes := src.minVotes
+	if req.GetMinVotes() > 0 {
+		minVotes = int(req.MinVotes)
+	}
+
+	groups := s.indexTitles(func(a *agg) bool {
+		return src.rating(a.Rep) > 0 && src.votes(a.Rep) >= minVotes
+	})
+	sort.SliceStable(groups, func(i, j int) bool {
+		ri, rj := src.rating(groups[i].Rep), src.rating(groups[j].Rep)
+		if ri != rj {
+			return ri > rj
+		}
+		return src.votes(groups[i].Rep) > src.votes(groups[j].Rep)
+	})
+
+	page, start, end := pageRange(req.GetPage(), req.GetPageSize(), len(groups))
+	resp := &pb.ListTitlesResponse{
+		Page:    page,
+		Total:   int32(len(groups)),
+		HasMore: end < len(groups),
+	}
+	for _, a := range groups[start:end] {
+		resp.Items = append(resp.Items, a.toProto())
+	}
+	return resp, nil
+}
+
+// recentWalk is the part of Kodik's recently updated listing walked so far.
+// Requests extend it from its cursor instead of starting over, until it
+// expires. Pages are fetched without holding mu, so a slow walk does not
+// block requests the walk already covers.
+type recentWalk struct {
+	mu        sync.Mutex
+	started   time.Time
+	materials []kodik.Material
+	groups    []*agg
+	// grouped is how many of materials groups was built from.
+	grouped int
+	next    string
+	pages   int
+	done    bool
+}
+
+// expire makes the next request walk from the start, e.g. after a merge
+// override changed how titles group.
+func (w *recentWalk) expire() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.started = time.Time{}
+}
+
+// ListRecentlyUpdated pages through titles by their latest Kodik update,
+// walking Kodik's anime sorted by updated_at only as far as the requested
+// page needs. Total counts the titles walked so far.
+func (s *server) ListRecentlyUpdated(ctx context.Context, req *pb.ListRecentlyUpdatedRequest) (*pb.ListTitlesResponse, error) {
+	page, pageSize := paging(req.GetPage(), req.GetPageSize())
+	// One title more than the page tells whether another page exists.
+	want := int(page)*int(pageSize) + 1
+
+	w := &s.recent
+	for {
+		w.mu.Lock()
+		if time.Since(w.started) > recentTTL {
+			w.started = time.Now()
+			w.materials, w.groups, w.grouped = nil, nil, 0
+			w.next, w.pages, w.done = "", 0, false
+		}
+		// Past maxRecentPages the feed ends, even though Kodik has more.
+		more := !w.done && w.pages < maxRecentPages
+		// A title has at least one material, so there is no point grouping
+		// before the walk has as many materials as the page needs titles.
+		if len(w.materials) > w.grouped && (len(w.materials) >= want || !more) {
+			w.groups = s.groups(w.materials)
+			w.grouped = len(w.materials)
+			sort.SliceStable(w.groups, func(i, j int) bool {
+				return w.groups[i].Rep.UpdatedAt.After(w.groups[j].Rep.UpdatedAt)
+			})
+		}
+		if len(w.groups) >= want || !more {
+			resp := w.page(page, pageSize, more)
+			w.mu.Unlock()
+			return resp, nil
+		}
+		started, next, pages := w.started, w.next, w.pages
+		w.mu.Unlock()
+
+		lr, err := s.client.List(ctx, kodik.ListOptions{
+			Limit:            100,
+			Next:             next,
+			Types:            "anime,anime-serial",
+			Sort:             "updated_at",
+			Order:            "desc",
+			WithMaterialData: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.index.Put(lr.Results...)
+
+		w.mu.Lock()
+		// Another request may have fetched this page first or restarted
+		// the walk; then ours is dropped and the loop reads theirs.
+		if w.started.Equal(started) && w.pages == pages {
+			w.materials = append(w.materials, lr.Results...)
+			w.pages++
+			w.next = kodik.NextCursor(lr)
+			w.done = w.next == ""
+		}
+		w.mu.Unlock()
+	}
+}
+
+// page returns one page of the walk. w.mu must be held.
+func (w *recentWalk) page(page, pageSize int32, more bool) *pb.ListTitlesResponse {
+	_, start, end := pageRange(page, pageSize, len(w.groups))
+	resp := &pb.ListTitlesResponse{
+		Page:    page,
+		Total:   int32(len(w.groups)),
+		HasMore: end < len(w.groups) || more,
+	}
+	for _, a := range w.groups[start:end] {
+		resp.Items = append(resp.Items, a.toProto())
+	}
+	return resp
+}
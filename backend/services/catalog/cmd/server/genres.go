@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/taxonomy"
+)
+
+// genres is replaced at startup when CATALOG_TAXONOMY_FILE extends it.
+var genres = taxonomy.Builtin()
+
+func materialGenres(m kodik.Material) []string {
+	return genres.Normalize(m.Genres, m.AnimeGenres)
+}
+
+// ListGenres lists the taxonomy with how many indexed titles carry each
+// genre. Releases are counted once per Shikimori or Kinopoisk ID, which is
+// close to the number of titles without running the merge engine.
+func (s *server) ListGenres(ctx context.Context, req *pb.ListGenresRequest) (*pb.ListGenresResponse, error) {
+	locale := req.GetLocale()
+	if locale == "" {
+		locale = "en"
+	}
+
+	titles := make(map[string]map[string]bool)
+	s.index.Range(func(m kodik.Material) {
+		key := m.ID
+		switch {
+		case m.ShikimoriID != "":
+			key = "sh:" + m.ShikimoriID
+		case m.KinopoiskID != "":
+			key = "kp:" + m.KinopoiskID
+		}
+		for _, slug := range materialGenres(m) {
+			if titles[slug] == nil {
+				titles[slug] = make(map[string]bool)
+			}
+			titles[slug][key] = true
+		}
+	})
+
+	resp := &pb.ListGenresResponse{}
+	for _, g := range genres.All() {
+		if req.GetKind() != "" && string(g.Kind) != req.Kind {
+			continue
+		}
+		resp.Genres = append(resp.Genres, &pb.Genre{
+			Slug:       g.Slug,
+			Kind:       string(g.Kind),
+			Name:       g.Name(locale),
+			Names:      g.Names,
+			Aliases:    g.Aliases,
+			TitleCount: int32(len(titles[g.Slug])),
+		})
+	}
+	return resp, nil
+}
+
+func resolveGenres(raw []string) ([]string, error) {
+	out := make([]string, 0, len(raw))
+	for _, r := range raw {
+		slug, ok := genres.Resolve(r)
+		if !ok {
+			return nil, fmt.Errorf("unknown genre %q", r)
+		}
+		out = append(out, slug)
+	}
+	return out, nil
+}
+
+// titleSorts order aggregates ascending by each supported sort key.
+var titleSorts = map[string]func(a, b *agg) bool{
+	"rating": func(a, b *agg) bool {
+		return cmpRating(a.Rep) < cmpRating(b.Rep)
+	},
+	"year": func(a, b *agg) bool {
+		return a.Rep.Year < b.Rep.Year
+	},
+	"updated": func(a, b *agg) bool {
+		return a.Rep.UpdatedAt.Before(b.Rep.UpdatedAt)
+	},
+	"title": func(a, b *agg) bool {
+		return strings.ToLower(a.Rep.Title) < strings.ToLower(b.Rep.Title)
+	},
+}
+
+// cmpRating prefers the Kinopoisk rating and falls back to Shikimori.
+func cmpRating(m kodik.Material) float64 {
+	if m.KinopoiskRating > 0 {
+		return m.KinopoiskRating
+	}
+	return m.ShikimoriRating
+}
+
+// BrowseByGenre pages through indexed titles that have every requested
+// genre and none of the excluded ones.
+func (s *server) BrowseByGenre(ctx context.Context, req *pb.BrowseByGenreRequest) (*pb.ListTitlesResponse, error) {
+	include, err := resolveGenres(req.GetGenres())
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := resolveGenres(req.GetExclude())
+	if err != nil {
+		return nil, err
+	}
+	if len(include) == 0 {
+		return nil, fmt.Errorf("at least one genre required")
+	}
+	sortName := req.GetSort()
+	if sortName == "" {
+		sortName = "rating"
+	}
+	less, ok := titleSorts[sortName]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort %q, want rating, year, updated or title", sortName)
+	}
+	desc := true
+	switch req.GetOrder() {
+	case "", "desc":
+	case "asc":
+		desc = false
+	default:
+		return nil, fmt.Errorf("unknown order %q, want asc or desc", req.Order)
+	}
+
+	groups := s.indexTitles(func(a *agg) bool {
+		have := materialGenres(a.Rep)
+		for _, slug := range include {
+			if !slices.Contains(have, slug) {
+				return false
+			}
+		}
+		for _, slug := range exclude {
+			if slices.Contains(have, slug) {
+				return false
+			}
+		}
+		return true
+	})
+	sort.SliceStable(groups, func(i, j int) bool {
+		if desc {
+			return less(groups[j], groups[i])
+		}
+		return less(groups[i], groups[j])
+	})
+
+	page, start, end := pageRange(req.GetPage(), req.GetPageSize(), len(groups))
+	resp := &pb.ListTitlesResponse{
+		Page:    page,
+		Total:   int32(len(groups)),
+		HasMore: end < len(groups),
+	}
+	for _, a := range groups[start:end] {
+		resp.Items = append(resp.Items, a.toProto())
+	}
+	return resp, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// kodikHealthService reports whether the Kodik API is reachable. It is
+	// separate from the catalog's own status since cached and indexed
+	// titles are still served while Kodik is down.
+	kodikHealthService = "kodik"
+	kodikPingTimeout   = 5 * time.Second
+)
+
+func newHealth() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(pb.Catalog_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(kodikHealthService, healthpb.HealthCheckResponse_UNKNOWN)
+	return hs
+}
+
+// watchKodik pings Kodik every interval until ctx is done and reflects the
+// result in the kodik health service.
+func (s *server) watchKodik(ctx context.Context, hs *health.Server, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		pctx, cancel := context.WithTimeout(ctx, kodikPingTimeout)
+		err := s.client.Ping(pctx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		st := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			st = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if st != last {
+			if err != nil {
+				s.logger.Warn("kodik unreachable", "error", err)
+			} else {
+				s.logger.Info("kodik reachable")
+			}
+			last = st
+		}
+		hs.SetServingStatus(kodikHealthService, st)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
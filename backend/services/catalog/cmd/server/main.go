@@ -4,23 +4,57 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
-	"os"
-	"strconv"
-	// "time"
+	"net/url"
+	"slices"
+	"time"
 	"sort"
-	"strings"
+	"sync/atomic"
 
 	structpb "google.golang.org/protobuf/types/known/structpb"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/cache"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/identity"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/index"
 	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik" 
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/merge"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/notify"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/relations"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/schedule"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/taxonomy"
 	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen" 
+	"github.com/greg5320/AniFlow/backend/services/internal/config"
+	"github.com/greg5320/AniFlow/backend/services/internal/logging"
+	"github.com/greg5320/AniFlow/backend/services/internal/metrics"
+	"github.com/greg5320/AniFlow/backend/services/internal/shutdown"
+	"github.com/greg5320/AniFlow/backend/services/internal/tracing"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type server struct {
 	pb.UnimplementedCatalogServer
 	client *kodik.Client
+	cache  *cache.TTL[string, *pb.Anime]
+	index  *index.Index
+
+	library  librarypb.LibraryClient
+	notifier *notify.Notifier
+	history  *schedule.History
+
+	identities *identity.Table
+	overrides  *merge.Overrides
+	relations  *relations.Graph
+
+	// titles is the index grouped by regroup.
+	titles atomic.Pointer[[]*agg]
+	recent recentWalk
+
+	logger *slog.Logger
 }
 
 // func isKodikID(s string) bool {
@@ -29,15 +63,183 @@ type server struct {
 // 	}
 // 	return strings.HasPrefix(s, "movie-") || strings.HasPrefix(s, "serial-")
 // }
-func canonicalKey(m kodik.Material) string {
-	if m.KinopoiskID != "" {
-		return "kp:" + m.KinopoiskID
-	}
-	// if m.ShikimoriID != "" {
-	// 	return "sh:" + m.ShikimoriID
-	// }
-	return fmt.Sprintf("ttl:%s|%d", strings.ToLower(strings.TrimSpace(m.Title)), m.Year)
+type agg struct {
+	Key          string
+	LegacyKeys   []string // keys of its materials before the merge engine
+	Rep          kodik.Material
+	Translations map[int]kodik.Translation
+	IDs          []string
+	AniflowID    string
+}
+
+// groupMaterials merges Kodik materials that describe the same title, as
+// decided by the merge engine, into one aggregate each, ordered by title.
+// The first material of a group becomes its representative.
+func groupMaterials(ms []kodik.Material, overrides *merge.Overrides) []*agg {
+	var out []*agg
+	for _, g := range merge.Partition(ms, overrides) {
+		a := &agg{Key: g.Key, LegacyKeys: g.LegacyKeys(), Rep: g.Members[0], Translations: make(map[int]kodik.Translation)}
+		out = append(out, a)
+		for _, m := range g.Members {
+			a.IDs = append(a.IDs, m.ID)
+			if m.Translation != nil {
+				a.Translations[m.Translation.ID] = *m.Translation
+			}
+			if a.Rep.PosterURL == "" && m.PosterURL != "" {
+				a.Rep.PosterURL = m.PosterURL
+			}
+			if a.Rep.AnimePosterURL == "" && m.AnimePosterURL != "" {
+				a.Rep.AnimePosterURL = m.AnimePosterURL
+			}
+			if a.Rep.Description == "" && m.Description != "" {
+				a.Rep.Description = m.Description
+			}
+			if len(a.Rep.Genres) == 0 && len(m.Genres) > 0 {
+				a.Rep.Genres = m.Genres
+			}
+			if len(a.Rep.AnimeGenres) == 0 && len(m.AnimeGenres) > 0 {
+				a.Rep.AnimeGenres = m.AnimeGenres
+			}
+			if len(a.Rep.Studios) == 0 && len(m.Studios) > 0 {
+				a.Rep.Studios = m.Studios
+			}
+			if m.KinopoiskRating > a.Rep.KinopoiskRating {
+				a.Rep.KinopoiskRating = m.KinopoiskRating
+			}
+			a.Rep.KinopoiskVotes = max(a.Rep.KinopoiskVotes, m.KinopoiskVotes)
+			a.Rep.ShikimoriRating = max(a.Rep.ShikimoriRating, m.ShikimoriRating)
+			a.Rep.ShikimoriVotes = max(a.Rep.ShikimoriVotes, m.ShikimoriVotes)
+			if m.UpdatedAt.After(a.Rep.UpdatedAt) {
+				a.Rep.UpdatedAt = m.UpdatedAt
+			}
+			if m.EpisodesCount > a.Rep.EpisodesCount {
+				a.Rep.EpisodesCount = m.EpisodesCount
+			}
+			if m.LastEpisode > a.Rep.LastEpisode {
+				a.Rep.LastEpisode = m.LastEpisode
+			}
+			if a.Rep.AnimeStatus == "" {
+				a.Rep.AnimeStatus = m.AnimeStatus
+			}
+			if a.Rep.NextEpisodeAt.IsZero() {
+				a.Rep.NextEpisodeAt = m.NextEpisodeAt
+			}
+			if a.Rep.KinopoiskID == "" {
+				a.Rep.KinopoiskID = m.KinopoiskID
+			}
+			if a.Rep.ShikimoriID == "" {
+				a.Rep.ShikimoriID = m.ShikimoriID
+			}
+			if a.Rep.IMDbID == "" {
+				a.Rep.IMDbID = m.IMDbID
+			}
+			if a.Rep.WorldArtLink == "" {
+				a.Rep.WorldArtLink = m.WorldArtLink
+			}
+			if m.EpisodesAired > a.Rep.EpisodesAired {
+				a.Rep.EpisodesAired = m.EpisodesAired
+			}
+			if m.EpisodesTotal > a.Rep.EpisodesTotal {
+				a.Rep.EpisodesTotal = m.EpisodesTotal
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Rep.Title < out[j].Rep.Title
+	})
+	return out
+}
+
+// groups is groupMaterials plus a stable AniFlow ID for every group.
+func (s *server) groups(ms []kodik.Material) []*agg {
+	out := groupMaterials(ms, s.overrides)
+	for _, a := range out {
+		a.AniflowID = s.identities.Assign(a.Key, a.IDs)
+	}
+	return out
+}
+
+// regroup partitions the whole index into titles for indexTitles. It runs
+// after each sync and override change rather than per request, since the
+// merge engine is quadratic in the number of titles.
+func (s *server) regroup() {
+	var all []kodik.Material
+	s.index.Range(func(m kodik.Material) {
+		all = append(all, m)
+	})
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	groups := s.groups(all)
+	s.titles.Store(&groups)
 }
+
+// indexTitles returns the titles of the last regroup for which keep reports
+// true. Materials indexed since then show up after the next one.
+func (s *server) indexTitles(keep func(*agg) bool) []*agg {
+	groups := s.titles.Load()
+	if groups == nil {
+		s.regroup()
+		groups = s.titles.Load()
+	}
+	var out []*agg
+	for _, a := range *groups {
+		if keep(a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func (a *agg) toProto() *pb.Anime {
+	rep := a.Rep
+	item := &pb.Anime{
+		KodikId:       rep.ID,
+		Title:         rep.Title,
+		Description:   rep.Description,
+		PosterUrl:     rep.PosterURL,
+		EpisodesCount: int32(rep.EpisodesCount),
+		LastEpisode:   int32(rep.LastEpisode),
+		Year:          int32(rep.Year),
+		Genres: rep.Genres,
+		Studios: rep.Studios,
+		GenreSlugs: genres.Normalize(rep.Genres, rep.AnimeGenres),
+		UpdatedAt: timestamppb.Now(),
+		KinopoiskId:  rep.KinopoiskID,
+		ShikimoriId:  rep.ShikimoriID,
+		ImdbId:       rep.IMDbID,
+		WorldartLink: rep.WorldArtLink,
+		AniflowId:    a.AniflowID,
+		KinopoiskVotes:  int32(rep.KinopoiskVotes),
+		ShikimoriRating: rep.ShikimoriRating,
+		ShikimoriVotes:  int32(rep.ShikimoriVotes),
+	}
+	if !rep.UpdatedAt.IsZero() {
+		item.KodikUpdatedAt = timestamppb.New(rep.UpdatedAt)
+	}
+	if rep.KinopoiskRating > 0 {
+		item.KinopoiskRating = rep.KinopoiskRating
+	} else {
+		item.KinopoiskRating = 0
+	}
+	if rep.AnimePosterURL != "" {
+		item.AnimePosterUrl = rep.AnimePosterURL
+	}
+	ids := make([]int, 0, len(a.Translations))
+	for id := range a.Translations {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		tr := a.Translations[id]
+		item.Translations = append(item.Translations, &pb.Translation{
+			Id:    int32(tr.ID),
+			Title: tr.Title,
+			Type:  tr.Type,
+		})
+	}
+	return item
+}
+
 func (s *server) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
 	pageSize := int(req.PageSize)
 	if pageSize <= 0 {
@@ -47,197 +249,274 @@ func (s *server) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchR
 	if err != nil {
 		return nil, err
 	}
-	type agg struct {
-		Rep          kodik.Material
-		Translations map[int]kodik.Translation
-	}
-	mmap := make(map[string]*agg)
-
-	for _, m := range lr.Results {
-		key := canonicalKey(m)
-		a, ok := mmap[key]
-		if !ok {
-			a = &agg{Rep: m, Translations: make(map[int]kodik.Translation)}
-			mmap[key] = a
-		}
-		if m.Translation != nil {
-			a.Translations[m.Translation.ID] = *m.Translation
-		}
-		if a.Rep.PosterURL == "" && m.PosterURL != "" {
-			a.Rep.PosterURL = m.PosterURL
-		}
-		if a.Rep.Description == "" && m.Description != "" {
-			a.Rep.Description = m.Description
-		}
-		if len(a.Rep.Genres) == 0 && len(m.Genres) > 0 {
-			a.Rep.Genres = m.Genres
-		}
-		if m.KinopoiskRating > a.Rep.KinopoiskRating {
-			a.Rep.KinopoiskRating = m.KinopoiskRating
-		}
-	}
-
-	keys := make([]string, 0, len(mmap))
-	for k := range mmap {
-		keys = append(keys, k)
-	}
-	sort.Slice(keys, func(i, j int) bool {
-		return mmap[keys[i]].Rep.Title < mmap[keys[j]].Rep.Title
-	})
+	s.index.Put(lr.Results...)
 
 	resp := &pb.SearchResponse{}
-	for _, k := range keys {
-		a := mmap[k]
-		rep := a.Rep
-		item := &pb.Anime{
-			KodikId:       rep.ID,
-			Title:         rep.Title,
-			Description:   rep.Description,
-			PosterUrl:     rep.PosterURL,
-			EpisodesCount: int32(rep.EpisodesCount),
-			Year:          int32(rep.Year),
-			Genres: rep.Genres,
-			UpdatedAt: timestamppb.Now(),
-		}
-		if rep.KinopoiskRating > 0 {
-			item.KinopoiskRating = rep.KinopoiskRating
-		} else {
-			item.KinopoiskRating = 0
-		}
-		if rep.AnimePosterURL != "" {
-			item.AnimePosterUrl = rep.AnimePosterURL
-		}
-		for _, tr := range a.Translations {
-			item.Translations = append(item.Translations, &pb.Translation{
-				Id:    int32(tr.ID),
-				Title: tr.Title,
-				Type:  tr.Type,
-			})
-		}
-		resp.Items = append(resp.Items, item)
+	for _, a := range s.groups(lr.Results) {
+		resp.Items = append(resp.Items, a.toProto())
 	}
 	resp.Total = int32(len(resp.Items))
 	return resp, nil
 }
 
 func (s *server) GetAnime(ctx context.Context, req *pb.GetAnimeRequest) (*pb.Anime, error) {
+	if req != nil && req.KodikId == "" && req.AniflowId != "" {
+		return s.getAnimeByAniflowID(ctx, req.AniflowId)
+	}
 	if req == nil || req.KodikId == "" {
 		return nil, fmt.Errorf("kodik_id required")
 	}
+	return s.getAnime(ctx, req.KodikId)
+}
+
+// getAnimeByAniflowID serves an identity through one of its Kodik
+// materials, newest first, skipping materials Kodik no longer knows.
+func (s *server) getAnimeByAniflowID(ctx context.Context, aniflowID string) (*pb.Anime, error) {
+	rec, ok := s.identities.Get(aniflowID)
+	if !ok {
+		return nil, fmt.Errorf("unknown aniflow_id %s", aniflowID)
+	}
+	var lastErr error
+	for i := len(rec.KodikIDs) - 1; i >= 0; i-- {
+		a, err := s.getAnime(ctx, rec.KodikIDs[i])
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return a, nil
+	}
+	return nil, fmt.Errorf("no material of %s resolves: %v", aniflowID, lastErr)
+}
 
-	mat, err := s.client.FetchByID(ctx, req.KodikId, true)
+// getAnime serves kodikID from the cache, resolving it against Kodik on a miss.
+func (s *server) getAnime(ctx context.Context, kodikID string) (*pb.Anime, error) {
+	if a, ok := s.cache.Get(kodikID); ok {
+		return a, nil
+	}
+	a, _, err := s.fetchAnime(ctx, kodikID)
 	if err != nil {
 		return nil, err
 	}
+	s.cache.Set(kodikID, a)
+	return a, nil
+}
 
-	transMap := make(map[int]kodik.Translation)
-	if mat.Translation != nil {
-		transMap[mat.Translation.ID] = *mat.Translation
-	}
-
-	if mat.KinopoiskID != "" {
-		lr, err := s.client.SearchByKinopoiskID(ctx, mat.KinopoiskID, 200, true)
-		if err == nil {
-			for _, mm := range lr.Results {
-				if canonicalKey(mm) != canonicalKey(*mat) {
-					continue
-				}
-				if mm.Translation != nil {
-					transMap[mm.Translation.ID] = *mm.Translation
-				}
-				if mat.PosterURL == "" && mm.PosterURL != "" {
-					mat.PosterURL = mm.PosterURL
-				}
-				if mat.AnimePosterURL == "" && mm.AnimePosterURL != "" {
-					mat.AnimePosterURL = mm.AnimePosterURL
-				}
-				if len(mat.Genres) == 0 && len(mm.Genres) > 0 {
-					mat.Genres = mm.Genres
-				}
-				if mm.KinopoiskRating > mat.KinopoiskRating {
-					mat.KinopoiskRating = mm.KinopoiskRating
-				}
-			}
-		}
-	} else {
-		lr, err := s.client.Search(ctx, mat.Title, 50, true)
-		if err == nil {
-			for _, mm := range lr.Results {
-				if canonicalKey(mm) != canonicalKey(*mat) {
-					continue
-				}
-				if mm.Translation != nil {
-					transMap[mm.Translation.ID] = *mm.Translation
-				}
-			}
+// fetchAnime resolves kodikID against Kodik, bypassing the cache, and also
+// returns the group of the title it belongs to. Other releases are
+// looked up by the most specific external ID the material has and merged
+// in when the merge engine groups them together.
+func (s *server) fetchAnime(ctx context.Context, kodikID string) (*pb.Anime, *agg, error) {
+	mat, err := s.client.FetchByID(ctx, kodikID, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.index.Put(*mat)
+
+	var lr *kodik.ListResponse
+	switch {
+	case mat.ShikimoriID != "":
+		lr, err = s.client.SearchByExternalID(ctx, kodik.ByShikimoriID, mat.ShikimoriID, 200, true)
+	case mat.KinopoiskID != "":
+		lr, err = s.client.SearchByKinopoiskID(ctx, mat.KinopoiskID, 200, true)
+	default:
+		lr, err = s.client.Search(ctx, mat.Title, 50, true)
+	}
+	all := []kodik.Material{*mat}
+	if err == nil {
+		s.index.Put(lr.Results...)
+		all = append(all, lr.Results...)
+	}
+
+	// mat goes first so it represents its group and kodik_id is kept.
+	var own *agg
+	for _, a := range s.groups(all) {
+		if slices.Contains(a.IDs, kodikID) {
+			own = a
+			break
 		}
 	}
+	if own == nil {
+		return nil, nil, fmt.Errorf("material %s dropped by merge", kodikID)
+	}
 
-	var fullData *structpb.Struct
+	out := own.toProto()
 	if mat.Raw != nil {
 		sv, convErr := structpb.NewStruct(mat.Raw)
 		if convErr != nil {
-			fmt.Printf("[warn] failed to convert raw to structpb: %v\n", convErr)
+			s.logger.WarnContext(ctx, "convert raw material", "kodik_id", kodikID, "error", convErr)
 		} else {
-			fullData = sv
+			out.FullData = sv
 		}
 	}
+	return out, own, nil
+}
+
+type flusher interface {
+	Flush() error
+}
 
-	out := &pb.Anime{
-		KodikId:       req.KodikId,
-		Title:         mat.Title,
-		Description:   mat.Description,
-		PosterUrl:     mat.PosterURL,
-		EpisodesCount: int32(mat.EpisodesCount),
-		Year:          int32(mat.Year),
-		Genres:        mat.Genres,
-		UpdatedAt:     timestamppb.Now(),
-		KinopoiskRating: mat.KinopoiskRating,
-		AnimePosterUrl:  mat.AnimePosterURL,
-		FullData:        fullData,
+// flushLoop persists file-backed stores once a minute.
+func flushLoop(logger *slog.Logger, stores ...flusher) {
+	for range time.Tick(time.Minute) {
+		for _, st := range stores {
+			if err := st.Flush(); err != nil {
+				logger.Error("flush failed", "error", err)
+			}
+		}
 	}
+}
 
-	ids := make([]int, 0, len(transMap))
-	for id := range transMap {
-		ids = append(ids, id)
+func main() {
+	cfg := config.MustLoad(config.CatalogService)
+	logger, err := logging.Setup("catalog", logging.Options{Level: cfg.Log.Level, Format: cfg.Log.Format})
+	if err != nil {
+		log.Fatalf("logging: %v", err)
 	}
-	sort.Ints(ids)
-	for _, id := range ids {
-		tr := transMap[id]
-		out.Translations = append(out.Translations, &pb.Translation{
-			Id:    int32(tr.ID),
-			Title: tr.Title,
-			Type:  tr.Type,
-		})
+	ctx, stop := shutdown.Context()
+	defer stop()
+
+	shutdownTracing, err := tracing.Init(context.Background(), "catalog")
+	if err != nil {
+		logging.Fatal(logger, "tracing", "error", err)
 	}
+	defer shutdownTracing(context.Background())
 
-	return out, nil
-}
+	idx := index.New()
+	if path := cfg.Catalog.IndexFile; path != "" {
+		idx, err = index.Open(path)
+		if err != nil {
+			logging.Fatal(logger, "open index", "error", err)
+		}
+	}
+	identities, err := identity.Open(cfg.Catalog.IdentityFile)
+	if err != nil {
+		logging.Fatal(logger, "open identity table", "error", err)
+	}
+	overrides := merge.NewOverrides()
+	if path := cfg.Catalog.OverridesFile; path != "" {
+		overrides, err = merge.OpenOverrides(path)
+		if err != nil {
+			logging.Fatal(logger, "open merge overrides", "error", err)
+		}
+	}
+	if path := cfg.Catalog.TaxonomyFile; path != "" {
+		genres, err = taxonomy.Load(path)
+		if err != nil {
+			logging.Fatal(logger, "load taxonomy", "error", err)
+		}
+	}
+	rel, err := loadRelations(cfg.Catalog.RelationsDump)
+	if err != nil {
+		logging.Fatal(logger, "load relations dump", "error", err)
+	}
+	logger.Info("relations loaded", "titles", rel.Len())
+	go flushLoop(logger, idx, identities)
 
+	kodikOpts := []kodik.Option{
+		kodik.WithBaseURL(append([]string{cfg.Kodik.BaseURL}, cfg.Kodik.Mirrors...)...),
+		kodik.WithTimeout(time.Duration(cfg.Kodik.Timeout)),
+		kodik.WithUserAgent(cfg.Kodik.UserAgent),
+		kodik.WithLogger(logger),
+	}
+	if cfg.Kodik.Proxy != "" {
+		proxy, err := url.Parse(cfg.Kodik.Proxy)
+		if err != nil {
+			logging.Fatal(logger, "invalid kodik.proxy", "error", err)
+		}
+		kodikOpts = append(kodikOpts, kodik.WithProxy(proxy))
+	}
+	if mode := cfg.Kodik.CassetteMode; mode != "" {
+		logger.Warn("kodik cassette in use", "mode", mode, "dir", cfg.Kodik.CassetteDir)
+		kodikOpts = append(kodikOpts, kodik.WithCassette(cfg.Kodik.CassetteDir, kodik.CassetteMode(mode)))
+	}
+	client := kodik.NewClient(cfg.Kodik.Token, kodikOpts...)
+	srv := &server{
+		client: client,
+		cache:  cache.NewTTL[string, *pb.Anime](time.Duration(cfg.Catalog.CacheTTL), cfg.Catalog.CacheSize),
+		index:  idx,
 
-func main() {
-	token := os.Getenv("KODIK_API_TOKEN")
-	if token == "" {
-		log.Fatal("KODIK_API_TOKEN is not set")
+		identities: identities,
+		overrides:  overrides,
+		relations:  rel,
+
+		logger: logger,
 	}
-	portStr := os.Getenv("CATALOG_PORT")
-	if portStr == "" {
-		log.Fatal("CATALOG_PORT is not set")
+
+	lc, err := grpc.NewClient(cfg.Catalog.LibraryAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(logging.StreamClientInterceptor()),
+	)
+	if err != nil {
+		logging.Fatal(logger, "failed to create library client", "error", err)
 	}
-	port, _ := strconv.Atoi(portStr)
+	defer lc.Close()
+	library := librarypb.NewLibraryClient(lc)
+	srv.library = library
 
-	client := kodik.NewClient(token)
-	srv := &server{client: client}
+	notifyStore := notify.NewStore()
+	if path := cfg.Catalog.NotifyFile; path != "" {
+		notifyStore, err = notify.OpenStore(path)
+		if err != nil {
+			logging.Fatal(logger, "open notification store", "error", err)
+		}
+	}
+	hooks := newWebhooks(logger, cfg.Webhooks)
+	defer hooks.Close()
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	srv.notifier = notify.New(notifyStore, libraryWatchers(library), srv.resolveTitle, notify.WithLogger(logger))
+	srv.notifier.OnChange(publishChange(hooks))
+	go srv.notifier.Run(ctx, time.Duration(cfg.Catalog.NotifyInterval))
+
+	srv.history = schedule.NewHistory()
+	if path := cfg.Catalog.HistoryFile; path != "" {
+		srv.history, err = schedule.OpenHistory(path)
+		if err != nil {
+			logging.Fatal(logger, "open history", "error", err)
+		}
+	}
+	go srv.runSync(ctx, time.Duration(cfg.Catalog.SyncInterval))
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Catalog.Port))
 	if err != nil {
-		log.Fatalf("listen error: %v", err)
+		logging.Fatal(logger, "listen error", "error", err)
 	}
-	grpcServer := grpc.NewServer()
+	// Metrics failing to listen is logged rather than fatal so they never
+	// take the catalog down.
+	metricsDone := make(chan struct{})
+	go func() {
+		defer close(metricsDone)
+		addr := fmt.Sprintf(":%d", cfg.Catalog.MetricsPort)
+		logger.Info("metrics listening", "addr", addr)
+		if err := shutdown.HTTP(ctx, metrics.Server(addr), time.Duration(cfg.Catalog.ShutdownTimeout)); err != nil {
+			logger.Error("metrics server failed", "addr", addr, "error", err)
+		}
+	}()
+
+	opts := append(metrics.ServerOptions(), logging.ServerOptions(logger)...)
+	grpcServer := grpc.NewServer(append(opts,
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+	)...)
 	pb.RegisterCatalogServer(grpcServer, srv)
+	hs := newHealth()
+	healthpb.RegisterHealthServer(grpcServer, hs)
+	go srv.watchKodik(ctx, hs, time.Duration(cfg.Kodik.PingInterval))
 
-	log.Printf("catalog gRPC server listening on :%d", port)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("serve error: %v", err)
+	logger.Info("catalog gRPC server listening", "port", cfg.Catalog.Port)
+	go func() {
+		<-ctx.Done()
+		logger.Info("shutting down")
+		hs.Shutdown()
+	}()
+	if err := shutdown.GRPC(ctx, grpcServer, lis, time.Duration(cfg.Catalog.ShutdownTimeout)); err != nil {
+		logging.Fatal(logger, "serve error", "error", err)
+	}
+	<-metricsDone
+	for _, st := range []flusher{idx, identities, srv.history} {
+		if err := st.Flush(); err != nil {
+			logger.Error("flush failed", "error", err)
+		}
 	}
+	logger.Info("catalog stopped")
 }
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/cache"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/identity"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/index"
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik/kodiktest"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/merge"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+	"google.golang.org/grpc"
+)
+
+func newTestServer(t *testing.T) (*server, *kodiktest.Server) {
+	t.Helper()
+	fake := kodiktest.NewServer()
+	t.Cleanup(fake.Close)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return &server{
+		client:     kodik.NewClient(kodiktest.Token, kodik.WithBaseURL(fake.URL), kodik.WithLogger(logger)),
+		cache:      cache.NewTTL[string, *pb.Anime](time.Minute, 100),
+		index:      index.New(),
+		identities: identity.New(),
+		overrides:  merge.NewOverrides(),
+		logger:     logger,
+	}, fake
+}
+
+func translationIDs(a *pb.Anime) []int32 {
+	var ids []int32
+	for _, tr := range a.Translations {
+		ids = append(ids, tr.Id)
+	}
+	return ids
+}
+
+func TestSearchMergesReleases(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	resp, err := s.Search(context.Background(), &pb.SearchRequest{Query: "frieren"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Items) != 1 {
+		t.Fatalf("got %d titles, want the 3 releases as one", len(resp.Items))
+	}
+	a := resp.Items[0]
+	if got := translationIDs(a); !slices.Equal(got, []int32{610, 1291, 1978}) {
+		t.Errorf("translations = %v", got)
+	}
+	// serial-51802 has 26 episodes and no external IDs; the group keeps
+	// the highest count and the IDs of its other members.
+	if a.EpisodesCount != 28 || a.ShikimoriId != "52991" || a.KinopoiskId != "4616588" {
+		t.Errorf("got %d episodes, shikimori %s, kinopoisk %s", a.EpisodesCount, a.ShikimoriId, a.KinopoiskId)
+	}
+	if a.AniflowId == "" {
+		t.Error("no AniFlow ID assigned")
+	}
+}
+
+func TestSearchKeepsSeasonsApart(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	resp, err := s.Search(context.Background(), &pb.SearchRequest{Query: "shingeki no kyojin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Items) != 2 || resp.Items[0].AniflowId == resp.Items[1].AniflowId {
+		t.Errorf("got %d titles, want both seasons with their own AniFlow IDs", len(resp.Items))
+	}
+}
+
+func TestGetAnimeMergesByShikimoriID(t *testing.T) {
+	s, fake := newTestServer(t)
+
+	a, err := s.GetAnime(context.Background(), &pb.GetAnimeRequest{KodikId: "serial-51745"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The requested material represents its title.
+	if a.KodikId != "serial-51745" {
+		t.Errorf("kodik_id = %s", a.KodikId)
+	}
+	if got := translationIDs(a); !slices.Equal(got, []int32{610, 1978}) {
+		t.Errorf("translations = %v", got)
+	}
+	if a.FullData == nil {
+		t.Error("raw material missing")
+	}
+	if got := fake.Endpoints(); !slices.Equal(got, []string{"/list", "/search"}) {
+		t.Errorf("requests = %v", got)
+	}
+
+	// Served from the cache the second time.
+	if _, err := s.GetAnime(context.Background(), &pb.GetAnimeRequest{KodikId: "serial-51745"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(fake.Requests()); n != 2 {
+		t.Errorf("%d requests after a cached lookup, want 2", n)
+	}
+}
+
+func TestGetAnimeAfterListMiss(t *testing.T) {
+	s, fake := newTestServer(t)
+	fake.HideFromList("movie-21371")
+
+	a, err := s.GetAnime(context.Background(), &pb.GetAnimeRequest{KodikId: "movie-21371"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.KodikId != "movie-21371" || len(a.Translations) != 2 {
+		t.Errorf("got %s with %d translations", a.KodikId, len(a.Translations))
+	}
+	if got := fake.Endpoints(); !slices.Equal(got, []string{"/list", "/search", "/search"}) {
+		t.Errorf("requests = %v, want /list, the /search fallback and the Shikimori lookup", got)
+	}
+}
+
+func TestGetAnimeByAniflowID(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	found, err := s.Search(context.Background(), &pb.SearchRequest{Query: "chainsaw man"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var id string
+	for _, a := range found.Items {
+		if a.ShikimoriId == "44511" {
+			id = a.AniflowId
+		}
+	}
+	if id == "" {
+		t.Fatalf("series not found in %d results", len(found.Items))
+	}
+	a, err := s.GetAnime(context.Background(), &pb.GetAnimeRequest{AniflowId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.AniflowId != id || a.ShikimoriId != "44511" {
+		t.Errorf("got %s (shikimori %s), want %s", a.AniflowId, a.ShikimoriId, id)
+	}
+}
+
+func TestGetAnimeKodikDown(t *testing.T) {
+	s, fake := newTestServer(t)
+	fake.Fail("/list", 500, -1)
+
+	if _, err := s.GetAnime(context.Background(), &pb.GetAnimeRequest{KodikId: "serial-45213"}); err == nil {
+		t.Fatal("GetAnime succeeded with Kodik failing")
+	}
+	if _, ok := s.cache.Get("serial-45213"); ok {
+		t.Error("failure was cached")
+	}
+}
+
+func TestListRecentlyUpdated(t *testing.T) {
+	s, fake := newTestServer(t)
+	ctx := context.Background()
+
+	first, err := s.ListRecentlyUpdated(ctx, &pb.ListRecentlyUpdatedRequest{Page: 1, PageSize: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Items) != 5 || !first.HasMore || first.Total != 8 {
+		t.Fatalf("page 1: %d items of %d, has_more %v", len(first.Items), first.Total, first.HasMore)
+	}
+	if first.Items[0].KodikId != "movie-58210" {
+		t.Errorf("first = %s, want the most recently updated title", first.Items[0].KodikId)
+	}
+	second, err := s.ListRecentlyUpdated(ctx, &pb.ListRecentlyUpdatedRequest{Page: 2, PageSize: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Items) != 3 || second.HasMore {
+		t.Errorf("page 2: %d items, has_more %v", len(second.Items), second.HasMore)
+	}
+	// The second page continues the walk of the first.
+	if got := fake.Endpoints(); !slices.Equal(got, []string{"/list"}) {
+		t.Errorf("requests = %v, want one /list", got)
+	}
+}
+
+// fakeLibrary answers GetTrending with items.
+type fakeLibrary struct {
+	librarypb.LibraryClient
+	items []*librarypb.TrendingItem
+}
+
+func (f *fakeLibrary) GetTrending(ctx context.Context, req *librarypb.GetTrendingRequest, _ ...grpc.CallOption) (*librarypb.GetTrendingResponse, error) {
+	return &librarypb.GetTrendingResponse{Items: f.items}, nil
+}
+
+func TestListTrending(t *testing.T) {
+	s, fake := newTestServer(t)
+	lib := &fakeLibrary{items: []*librarypb.TrendingItem{
+		{KodikId: "serial-51745"},
+		{KodikId: "movie-58210"},
+		{KodikId: "serial-45213"},
+	}}
+	s.library = lib
+	ctx := context.Background()
+
+	resp, err := s.ListTrending(ctx, &pb.ListTrendingRequest{PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Items) != 2 || resp.Total != 3 || !resp.HasMore {
+		t.Fatalf("got %d items of %d, has_more %v", len(resp.Items), resp.Total, resp.HasMore)
+	}
+	if resp.Items[0].KodikId != "serial-51745" || resp.Items[1].KodikId != "movie-58210" {
+		t.Errorf("items = %s, %s, want trending order", resp.Items[0].KodikId, resp.Items[1].KodikId)
+	}
+
+	// A title that no longer resolves fails the page instead of shrinking it.
+	lib.items = append(lib.items, &librarypb.TrendingItem{KodikId: "serial-404"})
+	fake.Fail("/list", 500, -1)
+	if _, err := s.ListTrending(ctx, &pb.ListTrendingRequest{Page: 2, PageSize: 2}); err == nil {
+		t.Error("page with an unresolved title succeeded")
+	}
+}
+
+func TestListRecentlyUpdatedConcurrent(t *testing.T) {
+	s, fake := newTestServer(t)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.ListRecentlyUpdated(cancelled, &pb.ListRecentlyUpdatedRequest{PageSize: 5}); err == nil {
+		t.Fatal("walk succeeded with a cancelled context")
+	}
+
+	var wg sync.WaitGroup
+	totals := make([]int32, 8)
+	for i := range totals {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := s.ListRecentlyUpdated(context.Background(), &pb.ListRecentlyUpdatedRequest{Page: int32(i%2 + 1), PageSize: 5})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			totals[i] = resp.Total
+		}()
+	}
+	wg.Wait()
+	for i, total := range totals {
+		if total != 8 {
+			t.Errorf("request %d saw %d titles, want 8", i, total)
+		}
+	}
+	// Requests racing for the same page may each fetch it, but only one
+	// copy is kept.
+	seen := make(map[string]bool)
+	for _, m := range s.recent.materials {
+		if seen[m.ID] {
+			t.Errorf("%s walked twice in %d requests", m.ID, len(fake.Requests()))
+		}
+		seen[m.ID] = true
+	}
+}
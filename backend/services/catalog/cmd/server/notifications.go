@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/notify"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// resolveTitle is the notifier's view of GetAnime. It always goes to Kodik
+// and refreshes the cache on the way.
+func (s *server) resolveTitle(ctx context.Context, kodikID string) (notify.Title, error) {
+	a, own, err := s.fetchAnime(ctx, kodikID)
+	if err != nil {
+		return notify.Title{}, err
+	}
+	s.cache.Set(kodikID, a)
+
+	t := notify.Title{
+		Key:           own.Key,
+		LegacyKeys:    own.LegacyKeys,
+		KodikID:       kodikID,
+		Title:         a.Title,
+		EpisodesCount: int(a.EpisodesCount),
+	}
+	for _, tr := range a.Translations {
+		t.Translations = append(t.Translations, kodik.Translation{
+			ID:    int(tr.Id),
+			Title: tr.Title,
+			Type:  tr.Type,
+		})
+	}
+	return t, nil
+}
+
+func libraryWatchers(library librarypb.LibraryClient) notify.WatchersFunc {
+	return func(ctx context.Context) (map[string][]string, error) {
+		resp, err := library.ListWatchers(ctx, &librarypb.ListWatchersRequest{})
+		if err != nil {
+			return nil, err
+		}
+		out := make(map[string][]string, len(resp.Watchers))
+		for _, w := range resp.Watchers {
+			out[w.KodikId] = w.UserIds
+		}
+		return out, nil
+	}
+}
+
+var notificationKinds = map[notify.Kind]pb.Notification_Kind{
+	notify.KindNewEpisode:     pb.Notification_KIND_NEW_EPISODE,
+	notify.KindNewTranslation: pb.Notification_KIND_NEW_TRANSLATION,
+}
+
+func notificationToProto(n notify.Notification) *pb.Notification {
+	out := &pb.Notification{
+		Id:        n.ID,
+		UserId:    n.UserID,
+		KodikId:   n.KodikID,
+		Title:     n.Title,
+		Kind:      notificationKinds[n.Kind],
+		Episode:   int32(n.Episode),
+		CreatedAt: timestamppb.New(n.CreatedAt),
+		Read:      n.Read,
+	}
+	if n.Translation != nil {
+		out.Translation = &pb.Translation{
+			Id:    int32(n.Translation.ID),
+			Title: n.Translation.Title,
+			Type:  n.Translation.Type,
+		}
+	}
+	return out
+}
+
+func (s *server) SubscribeNotifications(req *pb.SubscribeNotificationsRequest, stream grpc.ServerStreamingServer[pb.Notification]) error {
+	if req == nil || req.UserId == "" {
+		return fmt.Errorf("user_id required")
+	}
+	// Subscribe before reading the backlog so nothing created in between
+	// is lost; the seen set drops the resulting duplicates.
+	ch, cancel := s.notifier.Subscribe(req.UserId)
+	defer cancel()
+
+	seen := make(map[string]bool)
+	backlog := s.notifier.List(req.UserId, true)
+	for i := len(backlog) - 1; i >= 0; i-- {
+		seen[backlog[i].ID] = true
+		if err := stream.Send(notificationToProto(backlog[i])); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case n := <-ch:
+			if seen[n.ID] {
+				continue
+			}
+			if err := stream.Send(notificationToProto(n)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *server) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	resp := &pb.ListNotificationsResponse{}
+	for _, n := range s.notifier.List(req.UserId, req.UnreadOnly) {
+		resp.Notifications = append(resp.Notifications, notificationToProto(n))
+		if !n.Read {
+			resp.Unread++
+		}
+	}
+	return resp, nil
+}
+
+func (s *server) MarkNotificationsRead(ctx context.Context, req *pb.MarkNotificationsReadRequest) (*pb.MarkNotificationsReadResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	n, err := s.notifier.MarkRead(req.UserId, req.Ids)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.MarkNotificationsReadResponse{Updated: int32(n)}, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/merge"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func overrideToProto(o merge.Override) *pb.MergeOverride {
+	kind := pb.MergeOverride_KIND_MERGE
+	if o.Kind == merge.KindSplit {
+		kind = pb.MergeOverride_KIND_SPLIT
+	}
+	return &pb.MergeOverride{
+		Kind:      kind,
+		KodikIdA:  o.A,
+		KodikIdB:  o.B,
+		Note:      o.Note,
+		CreatedAt: timestamppb.New(o.CreatedAt),
+	}
+}
+
+// SetMergeOverride records a manual merge or split. Cached titles are
+// dropped and the index regrouped so the override applies right away.
+func (s *server) SetMergeOverride(ctx context.Context, req *pb.MergeOverride) (*pb.MergeOverride, error) {
+	var kind merge.Kind
+	switch req.GetKind() {
+	case pb.MergeOverride_KIND_MERGE:
+		kind = merge.KindMerge
+	case pb.MergeOverride_KIND_SPLIT:
+		kind = merge.KindSplit
+	default:
+		return nil, fmt.Errorf("kind required")
+	}
+	o, err := s.overrides.Set(merge.Override{
+		Kind: kind,
+		A:    req.KodikIdA,
+		B:    req.KodikIdB,
+		Note: req.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Clear()
+	s.recent.expire()
+	s.regroup()
+	return overrideToProto(o), nil
+}
+
+func (s *server) ListMergeOverrides(ctx context.Context, req *pb.ListMergeOverridesRequest) (*pb.ListMergeOverridesResponse, error) {
+	resp := &pb.ListMergeOverridesResponse{}
+	for _, o := range s.overrides.List() {
+		resp.Overrides = append(resp.Overrides, overrideToProto(o))
+	}
+	return resp, nil
+}
+
+func (s *server) DeleteMergeOverride(ctx context.Context, req *pb.DeleteMergeOverrideRequest) (*pb.DeleteMergeOverrideResponse, error) {
+	deleted, err := s.overrides.Delete(req.GetKodikIdA(), req.GetKodikIdB())
+	if err != nil {
+		return nil, err
+	}
+	if deleted {
+		s.cache.Clear()
+		s.recent.expire()
+		s.regroup()
+	}
+	return &pb.DeleteMergeOverrideResponse{Deleted: deleted}, nil
+}
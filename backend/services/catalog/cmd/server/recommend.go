@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/recommend"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+)
+
+const (
+	defaultRecommendations = 20
+	maxRecommendations     = 100
+	// candidatePool is how many indexed materials per requested
+	// recommendation are grouped into titles and ranked.
+	candidatePool = 5
+	// coWatchSeeds bounds the library titles asked for "also watched"
+	// neighbours, best liked first.
+	coWatchSeeds   = 20
+	similarPerSeed = 20
+)
+
+func animeFeatures(a *pb.Anime) recommend.Features {
+	return recommend.Features{
+		ID:      a.AniflowId,
+		Title:   a.Title,
+		Genres:  a.Genres,
+		Studios: a.Studios,
+		Year:    int(a.Year),
+		Rating:  a.KinopoiskRating,
+	}
+}
+
+func materialFeatures(m kodik.Material) recommend.Features {
+	return recommend.Features{
+		ID:      m.ID,
+		Title:   m.Title,
+		Genres:  m.Genres,
+		Studios: m.Studios,
+		Year:    m.Year,
+		Rating:  m.KinopoiskRating,
+	}
+}
+
+// Recommend ranks indexed titles the user has not added yet against a
+// profile built from their library.
+func (s *server) Recommend(ctx context.Context, req *pb.RecommendRequest) (*pb.RecommendResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultRecommendations
+	}
+	limit = min(limit, maxRecommendations)
+
+	wl, err := s.library.GetWatchlist(ctx, &librarypb.GetWatchlistRequest{UserId: req.UserId})
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	kodikIDs := make([]string, 0, len(wl.Items))
+	for _, it := range wl.Items {
+		seen[it.KodikId] = true
+		kodikIDs = append(kodikIDs, it.KodikId)
+	}
+	byKodik := make(map[string]*pb.Anime, len(kodikIDs))
+	for start := 0; start < len(kodikIDs); start += maxBatchSize {
+		resp, err := s.BatchGetAnime(ctx, &pb.BatchGetAnimeRequest{
+			KodikIds: kodikIDs[start:min(start+maxBatchSize, len(kodikIDs))],
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range resp.Results {
+			if res.Anime != nil {
+				byKodik[res.KodikId] = res.Anime
+			}
+		}
+	}
+
+	var seeds []recommend.Seed
+	seedKodik := make(map[string]string)
+	for _, it := range wl.Items {
+		a, ok := byKodik[it.KodikId]
+		if !ok {
+			continue
+		}
+		seen[a.AniflowId] = true
+		seedKodik[a.AniflowId] = it.KodikId
+		seeds = append(seeds, recommend.Seed{
+			Features: animeFeatures(a),
+			Status:   it.Status.Name(),
+			Score:    int(it.Score),
+			Progress: int(it.Progress),
+		})
+	}
+	profile := recommend.BuildProfile(seeds)
+	if profile.Empty() {
+		return &pb.RecommendResponse{}, nil
+	}
+
+	// Shortlist materials first so only the promising part of the index
+	// goes through the merge engine.
+	ms := s.index.Select(func(m kodik.Material) bool {
+		return !seen[m.ID] && (len(m.Genres) > 0 || len(m.Studios) > 0)
+	})
+	scores := make(map[string]float64, len(ms))
+	for _, m := range ms {
+		scores[m.ID] = profile.Score(materialFeatures(m))
+	}
+	sort.Slice(ms, func(i, j int) bool { return scores[ms[i].ID] > scores[ms[j].ID] })
+	if len(ms) > limit*candidatePool {
+		ms = ms[:limit*candidatePool]
+	}
+
+	byID := make(map[string]*pb.Anime)
+	var candidates []recommend.Features
+	for _, a := range s.groups(ms) {
+		if seen[a.AniflowID] {
+			continue
+		}
+		anime := a.toProto()
+		byID[anime.AniflowId] = anime
+		candidates = append(candidates, animeFeatures(anime))
+	}
+
+	co, coAnime := s.coWatched(ctx, seeds, seedKodik, seen, limit*2)
+	for id, anime := range coAnime {
+		if _, ok := byID[id]; ok {
+			continue
+		}
+		byID[id] = anime
+		candidates = append(candidates, animeFeatures(anime))
+	}
+
+	resp := &pb.RecommendResponse{}
+	for _, r := range profile.Rank(candidates, co, limit) {
+		resp.Items = append(resp.Items, &pb.Recommendation{
+			Anime:             byID[r.ID],
+			Score:             r.Score,
+			Reasons:           r.Reasons,
+			BecauseAniflowIds: r.Because,
+		})
+	}
+	return resp, nil
+}
+
+// coWatched asks the library which titles users who have the seeds also
+// have, and sums the similarities weighted by how much the user liked each
+// seed. It returns at most limit titles, with their catalog entries, keyed
+// by AniFlow ID. Library errors only cost the collaborative part of the feed.
+func (s *server) coWatched(ctx context.Context, seeds []recommend.Seed, seedKodik map[string]string, seen map[string]bool, limit int) (map[string]recommend.CoWatch, map[string]*pb.Anime) {
+	seeds = slices.Clone(seeds)
+	sort.SliceStable(seeds, func(i, j int) bool { return seeds[i].Weight() > seeds[j].Weight() })
+
+	type acc struct {
+		kodikID string
+		score   float64
+		seed    string
+		best    float64
+	}
+	byKodik := make(map[string]*acc)
+	for i, seed := range seeds {
+		if i >= coWatchSeeds || seed.Weight() <= 0 {
+			break
+		}
+		resp, err := s.library.GetSimilar(ctx, &librarypb.GetSimilarRequest{
+			KodikId:   seedKodik[seed.ID],
+			AniflowId: seed.ID,
+			Limit:     similarPerSeed,
+		})
+		if err != nil {
+			s.logger.WarnContext(ctx, "co-watch lookup failed", "seed", seed.ID, "error", err)
+			continue
+		}
+		for _, it := range resp.Items {
+			if seen[it.KodikId] || seen[it.AniflowId] {
+				continue
+			}
+			a, ok := byKodik[it.KodikId]
+			if !ok {
+				a = &acc{kodikID: it.KodikId}
+				byKodik[it.KodikId] = a
+			}
+			contrib := seed.Weight() * it.Score
+			a.score += contrib
+			if contrib > a.best {
+				a.best, a.seed = contrib, seed.ID
+			}
+		}
+	}
+
+	accs := make([]*acc, 0, len(byKodik))
+	for _, a := range byKodik {
+		accs = append(accs, a)
+	}
+	sort.Slice(accs, func(i, j int) bool { return accs[i].score > accs[j].score })
+
+	out := make(map[string]recommend.CoWatch)
+	animes := make(map[string]*pb.Anime)
+	// Look the best candidates up a batch at a time until limit titles
+	// resolve.
+	chunk := min(limit, maxBatchSize)
+	for start := 0; chunk > 0 && start < len(accs) && len(out) < limit; start += chunk {
+		batch := accs[start:min(start+chunk, len(accs))]
+		ids := make([]string, len(batch))
+		for i, a := range batch {
+			ids[i] = a.kodikID
+		}
+		resp, err := s.BatchGetAnime(ctx, &pb.BatchGetAnimeRequest{KodikIds: ids})
+		if err != nil {
+			s.logger.WarnContext(ctx, "co-watch titles lookup failed", "error", err)
+			break
+		}
+		found := make(map[string]*pb.Anime, len(resp.Results))
+		for _, res := range resp.Results {
+			if res.Anime != nil {
+				found[res.KodikId] = res.Anime
+			}
+		}
+		for _, a := range batch {
+			if len(out) >= limit {
+				break
+			}
+			anime, ok := found[a.kodikID]
+			if !ok || seen[anime.AniflowId] {
+				continue
+			}
+			cw := out[anime.AniflowId]
+			if a.score > cw.Score {
+				cw.Seed = a.seed
+			}
+			cw.Score += a.score
+			out[anime.AniflowId] = cw
+			animes[anime.AniflowId] = anime
+		}
+	}
+	return out, animes
+}
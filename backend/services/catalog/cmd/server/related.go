@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/relations"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxFranchiseSize bounds GetRelated for sprawling franchises.
+const maxFranchiseSize = 200
+
+func (s *server) GetRelated(ctx context.Context, req *pb.GetRelatedRequest) (*pb.GetRelatedResponse, error) {
+	anime, err := s.GetAnime(ctx, &pb.GetAnimeRequest{
+		KodikId:   req.GetKodikId(),
+		AniflowId: req.GetAniflowId(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if anime.ShikimoriId == "" {
+		return nil, fmt.Errorf("title %s has no shikimori id to look relations up by", anime.AniflowId)
+	}
+
+	f, ok := s.relations.Franchise(anime.ShikimoriId, maxFranchiseSize)
+	if !ok {
+		return &pb.GetRelatedResponse{Titles: []*pb.RelatedTitle{{
+			ShikimoriId: anime.ShikimoriId,
+			Name:        anime.Title,
+			Anime:       anime,
+		}}}, nil
+	}
+
+	// Attach catalog data for every title some indexed release belongs to.
+	wanted := make(map[string]bool, len(f.Titles))
+	for _, t := range f.Titles {
+		wanted[t.ID] = true
+	}
+	known := map[string]*pb.Anime{anime.ShikimoriId: anime}
+	ms := s.index.Select(func(m kodik.Material) bool {
+		return wanted[m.ShikimoriID] && m.ShikimoriID != anime.ShikimoriId
+	})
+	for _, a := range s.groups(ms) {
+		if _, ok := known[a.Rep.ShikimoriID]; !ok {
+			known[a.Rep.ShikimoriID] = a.toProto()
+		}
+	}
+
+	resp := &pb.GetRelatedResponse{}
+	for _, t := range f.Titles {
+		rt := &pb.RelatedTitle{
+			ShikimoriId: t.ID,
+			Name:        t.Name,
+			Kind:        t.Format,
+			Relation:    string(f.Direct[t.ID]),
+			Anime:       known[t.ID],
+		}
+		if !t.AiredOn.IsZero() {
+			rt.AiredOn = timestamppb.New(t.AiredOn)
+		}
+		if rt.Name == "" && rt.Anime != nil {
+			rt.Name = rt.Anime.Title
+		}
+		resp.Titles = append(resp.Titles, rt)
+	}
+	for _, e := range f.Edges {
+		resp.Edges = append(resp.Edges, &pb.RelationEdge{
+			FromShikimoriId: e.From,
+			ToShikimoriId:   e.To,
+			Relation:        string(e.Kind),
+		})
+	}
+	return resp, nil
+}
+
+// loadRelations imports the dump at path, or returns an empty graph.
+func loadRelations(path string) (*relations.Graph, error) {
+	if path == "" {
+		return relations.New(), nil
+	}
+	return relations.Open(path)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+)
+
+func (s *server) ResolveAnime(ctx context.Context, req *pb.ResolveAnimeRequest) (*pb.ResolveAnimeResponse, error) {
+	var param, value string
+	switch {
+	case req.GetKinopoiskId() != "":
+		param, value = kodik.ByKinopoiskID, req.KinopoiskId
+	case req.GetShikimoriId() != "":
+		param, value = kodik.ByShikimoriID, req.ShikimoriId
+	case req.GetMalId() != "":
+		param, value = kodik.ByShikimoriID, req.MalId
+	case req.GetImdbId() != "":
+		param, value = kodik.ByIMDbID, req.ImdbId
+	case req.GetWorldartLink() != "":
+		param, value = kodik.ByWorldArtLink, req.WorldartLink
+	default:
+		return nil, fmt.Errorf("one of kinopoisk_id, shikimori_id, mal_id, imdb_id or worldart_link required")
+	}
+
+	lr, err := s.client.SearchByExternalID(ctx, param, value, 100, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(lr.Results) == 0 {
+		return nil, fmt.Errorf("no title with %s %s", param, value)
+	}
+	s.index.Put(lr.Results...)
+
+	// An external id can still match releases that group apart, e.g. one
+	// missing the id we key on; the biggest group is the title itself.
+	var best *agg
+	for _, a := range s.groups(lr.Results) {
+		if best == nil || len(a.IDs) > len(best.IDs) {
+			best = a
+		}
+	}
+	return &pb.ResolveAnimeResponse{
+		Anime:    best.toProto(),
+		KodikIds: best.IDs,
+	}, nil
+}
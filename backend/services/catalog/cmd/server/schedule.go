@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/schedule"
+	librarypb "github.com/greg5320/AniFlow/backend/services/library"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxSyncPages bounds one sync of ongoing titles (100 materials per page).
+const maxSyncPages = 30
+
+// syncOngoing pulls every ongoing anime serial from Kodik into the index
+// and records episode progress in the schedule history.
+func (s *server) syncOngoing(ctx context.Context) error {
+	var all []kodik.Material
+	next := ""
+	for page := 0; page < maxSyncPages; page++ {
+		lr, err := s.client.List(ctx, kodik.ListOptions{
+			Limit:            100,
+			Next:             next,
+			Types:            "anime-serial",
+			AnimeStatus:      "ongoing",
+			WithMaterialData: true,
+		})
+		if err != nil {
+			return err
+		}
+		all = append(all, lr.Results...)
+		if next = kodik.NextCursor(lr); next == "" {
+			break
+		}
+	}
+	s.index.Put(all...)
+
+	now := time.Now()
+	for _, a := range s.groups(all) {
+		s.history.Migrate(a.Key, a.LegacyKeys)
+		s.history.Record(a.Key, max(a.Rep.LastEpisode, a.Rep.EpisodesAired), now)
+	}
+	s.regroup()
+	return s.history.Flush()
+}
+
+func (s *server) runSync(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if err := s.syncOngoing(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "ongoing sync failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// watchlistTitles returns the AniFlow IDs of everything on the user's
+// watchlist, resolving titles that have not been assigned one yet.
+func (s *server) watchlistTitles(ctx context.Context, userID string) (map[string]bool, error) {
+	wl, err := s.library.GetWatchlist(ctx, &librarypb.GetWatchlistRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool, len(wl.Items))
+	for _, it := range wl.Items {
+		id, ok := s.identities.ForKodik(it.KodikId)
+		if !ok {
+			a, err := s.getAnime(ctx, it.KodikId)
+			if err != nil {
+				continue
+			}
+			id = a.AniflowId
+		}
+		ids[id] = true
+	}
+	return ids, nil
+}
+
+func (s *server) GetSchedule(ctx context.Context, req *pb.GetScheduleRequest) (*pb.GetScheduleResponse, error) {
+	loc := time.UTC
+	if req.GetTimezone() != "" {
+		l, err := time.LoadLocation(req.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %v", req.Timezone, err)
+		}
+		loc = l
+	}
+
+	var only map[string]bool
+	if req.GetUserId() != "" {
+		ids, err := s.watchlistTitles(ctx, req.UserId)
+		if err != nil {
+			return nil, err
+		}
+		only = ids
+	}
+
+	ongoing := s.indexTitles(func(a *agg) bool {
+		return a.Rep.AnimeStatus == "ongoing" && (only == nil || only[a.AniflowID])
+	})
+	now := time.Now()
+	byKey := make(map[string]*agg)
+	var items []schedule.Item
+	for _, a := range ongoing {
+		it, ok := schedule.Expect(schedule.Title{
+			Key:           a.Key,
+			LastEpisode:   max(a.Rep.LastEpisode, a.Rep.EpisodesAired),
+			EpisodesTotal: a.Rep.EpisodesTotal,
+			NextEpisodeAt: a.Rep.NextEpisodeAt,
+		}, s.history, now)
+		if !ok {
+			continue
+		}
+		byKey[a.Key] = a
+		items = append(items, it)
+	}
+
+	resp := &pb.GetScheduleResponse{}
+	for _, d := range schedule.Week(items, now, loc) {
+		day := &pb.ScheduleDay{
+			Date:    timestamppb.New(d.Date),
+			Weekday: d.Date.Weekday().String(),
+		}
+		for _, it := range d.Items {
+			day.Items = append(day.Items, &pb.ScheduleItem{
+				Anime:       byKey[it.Key].toProto(),
+				LastEpisode: int32(it.LastEpisode),
+				NextEpisode: int32(it.NextEpisode),
+				ExpectedAt:  timestamppb.New(it.ExpectedAt),
+				Estimated:   it.Estimated,
+			})
+		}
+		resp.Days = append(resp.Days, day)
+	}
+	return resp, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	"google.golang.org/grpc"
+)
+
+func (s *server) SearchStream(req *pb.SearchRequest, stream grpc.ServerStreamingServer[pb.SearchStreamResponse]) error {
+	if req == nil || strings.TrimSpace(req.Query) == "" {
+		return fmt.Errorf("query required")
+	}
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	ctx := stream.Context()
+
+	// sent remembers how many translations each emitted title had, so an
+	// upstream hit is only re-sent when Kodik knows more than the index did.
+	sent := make(map[string]int)
+
+	local := s.index.Search(req.Query, pageSize*10)
+	for i, a := range s.groups(local) {
+		if i >= pageSize {
+			break
+		}
+		if err := stream.Send(&pb.SearchStreamResponse{
+			Anime:  a.toProto(),
+			Source: pb.SearchStreamResponse_SOURCE_LOCAL,
+		}); err != nil {
+			return err
+		}
+		sent[a.Key] = len(a.Translations)
+	}
+
+	lr, err := s.client.Search(ctx, req.Query, pageSize, true)
+	if err != nil {
+		return err
+	}
+	s.index.Put(lr.Results...)
+
+	upstream := make(map[string]bool, len(lr.Results))
+	for _, m := range lr.Results {
+		upstream[m.ID] = true
+	}
+	// Local materials go first so titles already sent keep their kodik_id.
+	for _, a := range s.groups(append(local, lr.Results...)) {
+		if !slices.ContainsFunc(a.IDs, func(id string) bool { return upstream[id] }) {
+			continue
+		}
+		if n, ok := sent[a.Key]; ok && n == len(a.Translations) {
+			continue
+		}
+		if err := stream.Send(&pb.SearchStreamResponse{
+			Anime:  a.toProto(),
+			Source: pb.SearchStreamResponse_SOURCE_UPSTREAM,
+		}); err != nil {
+			return err
+		}
+		sent[a.Key] = len(a.Translations)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"log/slog"
+
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/notify"
+	"github.com/greg5320/AniFlow/backend/services/internal/config"
+	"github.com/greg5320/AniFlow/backend/services/internal/logging"
+	"github.com/greg5320/AniFlow/backend/services/internal/webhook"
+)
+
+const (
+	eventEpisodeAdded     = "catalog.episode_added"
+	eventTranslationAdded = "catalog.translation_added"
+)
+
+type titleChangeEvent struct {
+	Key         string             `json:"key"`
+	KodikID     string             `json:"kodik_id"`
+	Title       string             `json:"title"`
+	Episode     int                `json:"episode,omitempty"`
+	Translation *kodik.Translation `json:"translation,omitempty"`
+}
+
+// newWebhooks returns nil, which publishes nothing, unless cfg.File
+// registers endpoints.
+func newWebhooks(logger *slog.Logger, cfg config.Webhooks) *webhook.Dispatcher {
+	if cfg.File == "" {
+		return nil
+	}
+	endpoints, err := webhook.LoadEndpoints(cfg.File)
+	if err != nil {
+		logging.Fatal(logger, "load webhooks", "error", err)
+	}
+	return webhook.NewDispatcher(endpoints, webhook.Options{
+		DeadLetterPath: cfg.DeadLetterFile,
+		Logger:         logger,
+	})
+}
+
+func publishChange(hooks *webhook.Dispatcher) func(notify.Change) {
+	return func(ch notify.Change) {
+		ev := titleChangeEvent{
+			Key:     ch.Title.Key,
+			KodikID: ch.Title.KodikID,
+			Title:   ch.Title.Title,
+		}
+		switch ch.Kind {
+		case notify.KindNewEpisode:
+			ev.Episode = ch.Episode
+			hooks.Publish(eventEpisodeAdded, ev)
+		case notify.KindNewTranslation:
+			tr := ch.Translation
+			ev.Translation = &tr
+			hooks.Publish(eventTranslationAdded, ev)
+		}
+	}
+}
@@ -23,6 +23,155 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SearchStreamResponse_Source int32
+
+const (
+	SearchStreamResponse_SOURCE_UNSPECIFIED SearchStreamResponse_Source = 0
+	// answered from the catalog's local index
+	SearchStreamResponse_SOURCE_LOCAL SearchStreamResponse_Source = 1
+	// answered by Kodik; replaces any earlier item with the same kodik_id
+	SearchStreamResponse_SOURCE_UPSTREAM SearchStreamResponse_Source = 2
+)
+
+// Enum value maps for SearchStreamResponse_Source.
+var (
+	SearchStreamResponse_Source_name = map[int32]string{
+		0: "SOURCE_UNSPECIFIED",
+		1: "SOURCE_LOCAL",
+		2: "SOURCE_UPSTREAM",
+	}
+	SearchStreamResponse_Source_value = map[string]int32{
+		"SOURCE_UNSPECIFIED": 0,
+		"SOURCE_LOCAL":       1,
+		"SOURCE_UPSTREAM":    2,
+	}
+)
+
+func (x SearchStreamResponse_Source) Enum() *SearchStreamResponse_Source {
+	p := new(SearchStreamResponse_Source)
+	*p = x
+	return p
+}
+
+func (x SearchStreamResponse_Source) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchStreamResponse_Source) Descriptor() protoreflect.EnumDescriptor {
+	return file_catalog_proto_enumTypes[0].Descriptor()
+}
+
+func (SearchStreamResponse_Source) Type() protoreflect.EnumType {
+	return &file_catalog_proto_enumTypes[0]
+}
+
+func (x SearchStreamResponse_Source) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchStreamResponse_Source.Descriptor instead.
+func (SearchStreamResponse_Source) EnumDescriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{8, 0}
+}
+
+type Notification_Kind int32
+
+const (
+	Notification_KIND_UNSPECIFIED     Notification_Kind = 0
+	Notification_KIND_NEW_EPISODE     Notification_Kind = 1
+	Notification_KIND_NEW_TRANSLATION Notification_Kind = 2
+)
+
+// Enum value maps for Notification_Kind.
+var (
+	Notification_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_NEW_EPISODE",
+		2: "KIND_NEW_TRANSLATION",
+	}
+	Notification_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED":     0,
+		"KIND_NEW_EPISODE":     1,
+		"KIND_NEW_TRANSLATION": 2,
+	}
+)
+
+func (x Notification_Kind) Enum() *Notification_Kind {
+	p := new(Notification_Kind)
+	*p = x
+	return p
+}
+
+func (x Notification_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Notification_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_catalog_proto_enumTypes[1].Descriptor()
+}
+
+func (Notification_Kind) Type() protoreflect.EnumType {
+	return &file_catalog_proto_enumTypes[1]
+}
+
+func (x Notification_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Notification_Kind.Descriptor instead.
+func (Notification_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{9, 0}
+}
+
+type MergeOverride_Kind int32
+
+const (
+	MergeOverride_KIND_UNSPECIFIED MergeOverride_Kind = 0
+	MergeOverride_KIND_MERGE       MergeOverride_Kind = 1
+	MergeOverride_KIND_SPLIT       MergeOverride_Kind = 2
+)
+
+// Enum value maps for MergeOverride_Kind.
+var (
+	MergeOverride_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_MERGE",
+		2: "KIND_SPLIT",
+	}
+	MergeOverride_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"KIND_MERGE":       1,
+		"KIND_SPLIT":       2,
+	}
+)
+
+func (x MergeOverride_Kind) Enum() *MergeOverride_Kind {
+	p := new(MergeOverride_Kind)
+	*p = x
+	return p
+}
+
+func (x MergeOverride_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MergeOverride_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_catalog_proto_enumTypes[2].Descriptor()
+}
+
+func (MergeOverride_Kind) Type() protoreflect.EnumType {
+	return &file_catalog_proto_enumTypes[2]
+}
+
+func (x MergeOverride_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MergeOverride_Kind.Descriptor instead.
+func (MergeOverride_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{21, 0}
+}
+
 type Translation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -97,8 +246,24 @@ type Anime struct {
 	KinopoiskRating float64                `protobuf:"fixed64,10,opt,name=kinopoisk_rating,json=kinopoiskRating,proto3" json:"kinopoisk_rating,omitempty"`
 	AnimePosterUrl  string                 `protobuf:"bytes,11,opt,name=anime_poster_url,json=animePosterUrl,proto3" json:"anime_poster_url,omitempty"`
 	FullData        *structpb.Struct       `protobuf:"bytes,12,opt,name=full_data,json=fullData,proto3" json:"full_data,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	LastEpisode     int32                  `protobuf:"varint,13,opt,name=last_episode,json=lastEpisode,proto3" json:"last_episode,omitempty"`
+	KinopoiskId     string                 `protobuf:"bytes,14,opt,name=kinopoisk_id,json=kinopoiskId,proto3" json:"kinopoisk_id,omitempty"`
+	// equal to the MyAnimeList id
+	ShikimoriId  string `protobuf:"bytes,15,opt,name=shikimori_id,json=shikimoriId,proto3" json:"shikimori_id,omitempty"`
+	ImdbId       string `protobuf:"bytes,16,opt,name=imdb_id,json=imdbId,proto3" json:"imdb_id,omitempty"`
+	WorldartLink string `protobuf:"bytes,17,opt,name=worldart_link,json=worldartLink,proto3" json:"worldart_link,omitempty"`
+	// stable AniFlow id of the canonical title, survives Kodik re-issues
+	AniflowId       string   `protobuf:"bytes,18,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
+	Studios         []string `protobuf:"bytes,19,rep,name=studios,proto3" json:"studios,omitempty"`
+	KinopoiskVotes  int32    `protobuf:"varint,20,opt,name=kinopoisk_votes,json=kinopoiskVotes,proto3" json:"kinopoisk_votes,omitempty"`
+	ShikimoriRating float64  `protobuf:"fixed64,21,opt,name=shikimori_rating,json=shikimoriRating,proto3" json:"shikimori_rating,omitempty"`
+	ShikimoriVotes  int32    `protobuf:"varint,22,opt,name=shikimori_votes,json=shikimoriVotes,proto3" json:"shikimori_votes,omitempty"`
+	// when Kodik last changed any release of the title
+	KodikUpdatedAt *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=kodik_updated_at,json=kodikUpdatedAt,proto3" json:"kodik_updated_at,omitempty"`
+	// genres and tags normalized to taxonomy slugs, see ListGenres
+	GenreSlugs    []string `protobuf:"bytes,24,rep,name=genre_slugs,json=genreSlugs,proto3" json:"genre_slugs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Anime) Reset() {
@@ -215,9 +380,95 @@ func (x *Anime) GetFullData() *structpb.Struct {
 	return nil
 }
 
+func (x *Anime) GetLastEpisode() int32 {
+	if x != nil {
+		return x.LastEpisode
+	}
+	return 0
+}
+
+func (x *Anime) GetKinopoiskId() string {
+	if x != nil {
+		return x.KinopoiskId
+	}
+	return ""
+}
+
+func (x *Anime) GetShikimoriId() string {
+	if x != nil {
+		return x.ShikimoriId
+	}
+	return ""
+}
+
+func (x *Anime) GetImdbId() string {
+	if x != nil {
+		return x.ImdbId
+	}
+	return ""
+}
+
+func (x *Anime) GetWorldartLink() string {
+	if x != nil {
+		return x.WorldartLink
+	}
+	return ""
+}
+
+func (x *Anime) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
+func (x *Anime) GetStudios() []string {
+	if x != nil {
+		return x.Studios
+	}
+	return nil
+}
+
+func (x *Anime) GetKinopoiskVotes() int32 {
+	if x != nil {
+		return x.KinopoiskVotes
+	}
+	return 0
+}
+
+func (x *Anime) GetShikimoriRating() float64 {
+	if x != nil {
+		return x.ShikimoriRating
+	}
+	return 0
+}
+
+func (x *Anime) GetShikimoriVotes() int32 {
+	if x != nil {
+		return x.ShikimoriVotes
+	}
+	return 0
+}
+
+func (x *Anime) GetKodikUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.KodikUpdatedAt
+	}
+	return nil
+}
+
+func (x *Anime) GetGenreSlugs() []string {
+	if x != nil {
+		return x.GenreSlugs
+	}
+	return nil
+}
+
 type GetAnimeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	KodikId       string                 `protobuf:"bytes,1,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	KodikId string                 `protobuf:"bytes,1,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	// used when kodik_id is empty
+	AniflowId     string `protobuf:"bytes,2,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -259,6 +510,13 @@ func (x *GetAnimeRequest) GetKodikId() string {
 	return ""
 }
 
+func (x *GetAnimeRequest) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
 type SearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
@@ -371,43 +629,2385 @@ func (x *SearchResponse) GetTotal() int32 {
 	return 0
 }
 
-var File_catalog_proto protoreflect.FileDescriptor
+type BatchGetAnimeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KodikIds      []string               `protobuf:"bytes,1,rep,name=kodik_ids,json=kodikIds,proto3" json:"kodik_ids,omitempty"`
+	AniflowIds    []string               `protobuf:"bytes,2,rep,name=aniflow_ids,json=aniflowIds,proto3" json:"aniflow_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_catalog_proto_rawDesc = "" +
-	"\n" +
-	"\rcatalog.proto\x12\x12aniflow.catalog.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"G\n" +
-	"\vTranslation\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\"\xd7\x03\n" +
-	"\x05Anime\x12\x19\n" +
-	"\bkodik_id\x18\x01 \x01(\tR\akodikId\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
-	"\x06genres\x18\x04 \x03(\tR\x06genres\x12\x1d\n" +
-	"\n" +
-	"poster_url\x18\x05 \x01(\tR\tposterUrl\x12%\n" +
-	"\x0eepisodes_count\x18\x06 \x01(\x05R\repisodesCount\x129\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12C\n" +
-	"\ftranslations\x18\b \x03(\v2\x1f.aniflow.catalog.v1.TranslationR\ftranslations\x12\x12\n" +
-	"\x04year\x18\t \x01(\x05R\x04year\x12)\n" +
-	"\x10kinopoisk_rating\x18\n" +
-	" \x01(\x01R\x0fkinopoiskRating\x12(\n" +
-	"\x10anime_poster_url\x18\v \x01(\tR\x0eanimePosterUrl\x124\n" +
-	"\tfull_data\x18\f \x01(\v2\x17.google.protobuf.StructR\bfullData\",\n" +
-	"\x0fGetAnimeRequest\x12\x19\n" +
-	"\bkodik_id\x18\x01 \x01(\tR\akodikId\"V\n" +
-	"\rSearchRequest\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
-	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"W\n" +
-	"\x0eSearchResponse\x12/\n" +
-	"\x05items\x18\x01 \x03(\v2\x19.aniflow.catalog.v1.AnimeR\x05items\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total2\xa6\x01\n" +
-	"\aCatalog\x12J\n" +
-	"\bGetAnime\x12#.aniflow.catalog.v1.GetAnimeRequest\x1a\x19.aniflow.catalog.v1.Anime\x12O\n" +
-	"\x06Search\x12!.aniflow.catalog.v1.SearchRequest\x1a\".aniflow.catalog.v1.SearchResponseB<Z:github.com/greg5320/aniflow/services/catalog/gen;catalogpbb\x06proto3"
+func (x *BatchGetAnimeRequest) Reset() {
+	*x = BatchGetAnimeRequest{}
+	mi := &file_catalog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetAnimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetAnimeRequest) ProtoMessage() {}
+
+func (x *BatchGetAnimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetAnimeRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetAnimeRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchGetAnimeRequest) GetKodikIds() []string {
+	if x != nil {
+		return x.KodikIds
+	}
+	return nil
+}
+
+func (x *BatchGetAnimeRequest) GetAniflowIds() []string {
+	if x != nil {
+		return x.AniflowIds
+	}
+	return nil
+}
+
+// BatchGetAnimeResult echoes whichever of kodik_id or aniflow_id was asked.
+type BatchGetAnimeResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	KodikId string                 `protobuf:"bytes,1,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	Anime   *Anime                 `protobuf:"bytes,2,opt,name=anime,proto3" json:"anime,omitempty"`
+	// set instead of anime when this id could not be resolved
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	AniflowId     string `protobuf:"bytes,4,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetAnimeResult) Reset() {
+	*x = BatchGetAnimeResult{}
+	mi := &file_catalog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetAnimeResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetAnimeResult) ProtoMessage() {}
+
+func (x *BatchGetAnimeResult) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetAnimeResult.ProtoReflect.Descriptor instead.
+func (*BatchGetAnimeResult) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchGetAnimeResult) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+func (x *BatchGetAnimeResult) GetAnime() *Anime {
+	if x != nil {
+		return x.Anime
+	}
+	return nil
+}
+
+func (x *BatchGetAnimeResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchGetAnimeResult) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
+type BatchGetAnimeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchGetAnimeResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetAnimeResponse) Reset() {
+	*x = BatchGetAnimeResponse{}
+	mi := &file_catalog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetAnimeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetAnimeResponse) ProtoMessage() {}
+
+func (x *BatchGetAnimeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetAnimeResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetAnimeResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchGetAnimeResponse) GetResults() []*BatchGetAnimeResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SearchStreamResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Anime         *Anime                      `protobuf:"bytes,1,opt,name=anime,proto3" json:"anime,omitempty"`
+	Source        SearchStreamResponse_Source `protobuf:"varint,2,opt,name=source,proto3,enum=aniflow.catalog.v1.SearchStreamResponse_Source" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchStreamResponse) Reset() {
+	*x = SearchStreamResponse{}
+	mi := &file_catalog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStreamResponse) ProtoMessage() {}
+
+func (x *SearchStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStreamResponse.ProtoReflect.Descriptor instead.
+func (*SearchStreamResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchStreamResponse) GetAnime() *Anime {
+	if x != nil {
+		return x.Anime
+	}
+	return nil
+}
+
+func (x *SearchStreamResponse) GetSource() SearchStreamResponse_Source {
+	if x != nil {
+		return x.Source
+	}
+	return SearchStreamResponse_SOURCE_UNSPECIFIED
+}
+
+type Notification struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// the id from the user's watchlist, not necessarily the one that changed
+	KodikId       string                 `protobuf:"bytes,3,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Kind          Notification_Kind      `protobuf:"varint,5,opt,name=kind,proto3,enum=aniflow.catalog.v1.Notification_Kind" json:"kind,omitempty"`
+	Episode       int32                  `protobuf:"varint,6,opt,name=episode,proto3" json:"episode,omitempty"`
+	Translation   *Translation           `protobuf:"bytes,7,opt,name=translation,proto3" json:"translation,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Read          bool                   `protobuf:"varint,9,opt,name=read,proto3" json:"read,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_catalog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Notification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Notification) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Notification) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+func (x *Notification) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Notification) GetKind() Notification_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return Notification_KIND_UNSPECIFIED
+}
+
+func (x *Notification) GetEpisode() int32 {
+	if x != nil {
+		return x.Episode
+	}
+	return 0
+}
+
+func (x *Notification) GetTranslation() *Translation {
+	if x != nil {
+		return x.Translation
+	}
+	return nil
+}
+
+func (x *Notification) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Notification) GetRead() bool {
+	if x != nil {
+		return x.Read
+	}
+	return false
+}
+
+type SubscribeNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeNotificationsRequest) Reset() {
+	*x = SubscribeNotificationsRequest{}
+	mi := &file_catalog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeNotificationsRequest) ProtoMessage() {}
+
+func (x *SubscribeNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubscribeNotificationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UnreadOnly    bool                   `protobuf:"varint,2,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_catalog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListNotificationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetUnreadOnly() bool {
+	if x != nil {
+		return x.UnreadOnly
+	}
+	return false
+}
+
+type ListNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	Unread        int32                  `protobuf:"varint,2,opt,name=unread,proto3" json:"unread,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_catalog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetUnread() int32 {
+	if x != nil {
+		return x.Unread
+	}
+	return 0
+}
+
+type MarkNotificationsReadRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// empty marks every notification of the user as read
+	Ids           []string `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNotificationsReadRequest) Reset() {
+	*x = MarkNotificationsReadRequest{}
+	mi := &file_catalog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationsReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationsReadRequest) ProtoMessage() {}
+
+func (x *MarkNotificationsReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationsReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkNotificationsReadRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MarkNotificationsReadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MarkNotificationsReadRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type MarkNotificationsReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       int32                  `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNotificationsReadResponse) Reset() {
+	*x = MarkNotificationsReadResponse{}
+	mi := &file_catalog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationsReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationsReadResponse) ProtoMessage() {}
+
+func (x *MarkNotificationsReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationsReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkNotificationsReadResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MarkNotificationsReadResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+type GetScheduleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// when set, only titles on this user's watchlist are included
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// IANA zone the days are cut in, UTC by default
+	Timezone      string `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduleRequest) Reset() {
+	*x = GetScheduleRequest{}
+	mi := &file_catalog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduleRequest) ProtoMessage() {}
+
+func (x *GetScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduleRequest.ProtoReflect.Descriptor instead.
+func (*GetScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetScheduleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetScheduleRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type ScheduleItem struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Anime       *Anime                 `protobuf:"bytes,1,opt,name=anime,proto3" json:"anime,omitempty"`
+	LastEpisode int32                  `protobuf:"varint,2,opt,name=last_episode,json=lastEpisode,proto3" json:"last_episode,omitempty"`
+	NextEpisode int32                  `protobuf:"varint,3,opt,name=next_episode,json=nextEpisode,proto3" json:"next_episode,omitempty"`
+	ExpectedAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expected_at,json=expectedAt,proto3" json:"expected_at,omitempty"`
+	// true when expected_at is estimated from sync history rather than
+	// announced by Kodik
+	Estimated     bool `protobuf:"varint,5,opt,name=estimated,proto3" json:"estimated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleItem) Reset() {
+	*x = ScheduleItem{}
+	mi := &file_catalog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleItem) ProtoMessage() {}
+
+func (x *ScheduleItem) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleItem.ProtoReflect.Descriptor instead.
+func (*ScheduleItem) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ScheduleItem) GetAnime() *Anime {
+	if x != nil {
+		return x.Anime
+	}
+	return nil
+}
+
+func (x *ScheduleItem) GetLastEpisode() int32 {
+	if x != nil {
+		return x.LastEpisode
+	}
+	return 0
+}
+
+func (x *ScheduleItem) GetNextEpisode() int32 {
+	if x != nil {
+		return x.NextEpisode
+	}
+	return 0
+}
+
+func (x *ScheduleItem) GetExpectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpectedAt
+	}
+	return nil
+}
+
+func (x *ScheduleItem) GetEstimated() bool {
+	if x != nil {
+		return x.Estimated
+	}
+	return false
+}
+
+type ScheduleDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Weekday       string                 `protobuf:"bytes,2,opt,name=weekday,proto3" json:"weekday,omitempty"`
+	Items         []*ScheduleItem        `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleDay) Reset() {
+	*x = ScheduleDay{}
+	mi := &file_catalog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleDay) ProtoMessage() {}
+
+func (x *ScheduleDay) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleDay.ProtoReflect.Descriptor instead.
+func (*ScheduleDay) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ScheduleDay) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *ScheduleDay) GetWeekday() string {
+	if x != nil {
+		return x.Weekday
+	}
+	return ""
+}
+
+func (x *ScheduleDay) GetItems() []*ScheduleItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetScheduleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// seven days starting today
+	Days          []*ScheduleDay `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduleResponse) Reset() {
+	*x = GetScheduleResponse{}
+	mi := &file_catalog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduleResponse) ProtoMessage() {}
+
+func (x *GetScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduleResponse.ProtoReflect.Descriptor instead.
+func (*GetScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetScheduleResponse) GetDays() []*ScheduleDay {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+// ResolveAnimeRequest looks a title up by an external id. The first
+// non-empty field is used.
+type ResolveAnimeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KinopoiskId   string                 `protobuf:"bytes,1,opt,name=kinopoisk_id,json=kinopoiskId,proto3" json:"kinopoisk_id,omitempty"`
+	ShikimoriId   string                 `protobuf:"bytes,2,opt,name=shikimori_id,json=shikimoriId,proto3" json:"shikimori_id,omitempty"`
+	MalId         string                 `protobuf:"bytes,3,opt,name=mal_id,json=malId,proto3" json:"mal_id,omitempty"`
+	ImdbId        string                 `protobuf:"bytes,4,opt,name=imdb_id,json=imdbId,proto3" json:"imdb_id,omitempty"`
+	WorldartLink  string                 `protobuf:"bytes,5,opt,name=worldart_link,json=worldartLink,proto3" json:"worldart_link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveAnimeRequest) Reset() {
+	*x = ResolveAnimeRequest{}
+	mi := &file_catalog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveAnimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveAnimeRequest) ProtoMessage() {}
+
+func (x *ResolveAnimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveAnimeRequest.ProtoReflect.Descriptor instead.
+func (*ResolveAnimeRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ResolveAnimeRequest) GetKinopoiskId() string {
+	if x != nil {
+		return x.KinopoiskId
+	}
+	return ""
+}
+
+func (x *ResolveAnimeRequest) GetShikimoriId() string {
+	if x != nil {
+		return x.ShikimoriId
+	}
+	return ""
+}
+
+func (x *ResolveAnimeRequest) GetMalId() string {
+	if x != nil {
+		return x.MalId
+	}
+	return ""
+}
+
+func (x *ResolveAnimeRequest) GetImdbId() string {
+	if x != nil {
+		return x.ImdbId
+	}
+	return ""
+}
+
+func (x *ResolveAnimeRequest) GetWorldartLink() string {
+	if x != nil {
+		return x.WorldartLink
+	}
+	return ""
+}
+
+type ResolveAnimeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Anime *Anime                 `protobuf:"bytes,1,opt,name=anime,proto3" json:"anime,omitempty"`
+	// every Kodik material merged into anime
+	KodikIds      []string `protobuf:"bytes,2,rep,name=kodik_ids,json=kodikIds,proto3" json:"kodik_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveAnimeResponse) Reset() {
+	*x = ResolveAnimeResponse{}
+	mi := &file_catalog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveAnimeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveAnimeResponse) ProtoMessage() {}
+
+func (x *ResolveAnimeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveAnimeResponse.ProtoReflect.Descriptor instead.
+func (*ResolveAnimeResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ResolveAnimeResponse) GetAnime() *Anime {
+	if x != nil {
+		return x.Anime
+	}
+	return nil
+}
+
+func (x *ResolveAnimeResponse) GetKodikIds() []string {
+	if x != nil {
+		return x.KodikIds
+	}
+	return nil
+}
+
+// MergeOverride is a manual decision that two Kodik materials are, or are
+// not, the same title.
+type MergeOverride struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          MergeOverride_Kind     `protobuf:"varint,1,opt,name=kind,proto3,enum=aniflow.catalog.v1.MergeOverride_Kind" json:"kind,omitempty"`
+	KodikIdA      string                 `protobuf:"bytes,2,opt,name=kodik_id_a,json=kodikIdA,proto3" json:"kodik_id_a,omitempty"`
+	KodikIdB      string                 `protobuf:"bytes,3,opt,name=kodik_id_b,json=kodikIdB,proto3" json:"kodik_id_b,omitempty"`
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeOverride) Reset() {
+	*x = MergeOverride{}
+	mi := &file_catalog_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeOverride) ProtoMessage() {}
+
+func (x *MergeOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeOverride.ProtoReflect.Descriptor instead.
+func (*MergeOverride) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MergeOverride) GetKind() MergeOverride_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return MergeOverride_KIND_UNSPECIFIED
+}
+
+func (x *MergeOverride) GetKodikIdA() string {
+	if x != nil {
+		return x.KodikIdA
+	}
+	return ""
+}
+
+func (x *MergeOverride) GetKodikIdB() string {
+	if x != nil {
+		return x.KodikIdB
+	}
+	return ""
+}
+
+func (x *MergeOverride) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *MergeOverride) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListMergeOverridesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMergeOverridesRequest) Reset() {
+	*x = ListMergeOverridesRequest{}
+	mi := &file_catalog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMergeOverridesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMergeOverridesRequest) ProtoMessage() {}
+
+func (x *ListMergeOverridesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMergeOverridesRequest.ProtoReflect.Descriptor instead.
+func (*ListMergeOverridesRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{22}
+}
+
+type ListMergeOverridesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Overrides     []*MergeOverride       `protobuf:"bytes,1,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMergeOverridesResponse) Reset() {
+	*x = ListMergeOverridesResponse{}
+	mi := &file_catalog_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMergeOverridesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMergeOverridesResponse) ProtoMessage() {}
+
+func (x *ListMergeOverridesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMergeOverridesResponse.ProtoReflect.Descriptor instead.
+func (*ListMergeOverridesResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListMergeOverridesResponse) GetOverrides() []*MergeOverride {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+type DeleteMergeOverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KodikIdA      string                 `protobuf:"bytes,1,opt,name=kodik_id_a,json=kodikIdA,proto3" json:"kodik_id_a,omitempty"`
+	KodikIdB      string                 `protobuf:"bytes,2,opt,name=kodik_id_b,json=kodikIdB,proto3" json:"kodik_id_b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMergeOverrideRequest) Reset() {
+	*x = DeleteMergeOverrideRequest{}
+	mi := &file_catalog_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMergeOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMergeOverrideRequest) ProtoMessage() {}
+
+func (x *DeleteMergeOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMergeOverrideRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMergeOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteMergeOverrideRequest) GetKodikIdA() string {
+	if x != nil {
+		return x.KodikIdA
+	}
+	return ""
+}
+
+func (x *DeleteMergeOverrideRequest) GetKodikIdB() string {
+	if x != nil {
+		return x.KodikIdB
+	}
+	return ""
+}
+
+type DeleteMergeOverrideResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMergeOverrideResponse) Reset() {
+	*x = DeleteMergeOverrideResponse{}
+	mi := &file_catalog_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMergeOverrideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMergeOverrideResponse) ProtoMessage() {}
+
+func (x *DeleteMergeOverrideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMergeOverrideResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMergeOverrideResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteMergeOverrideResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// GetRelatedRequest names a title by kodik_id or aniflow_id.
+type GetRelatedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KodikId       string                 `protobuf:"bytes,1,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	AniflowId     string                 `protobuf:"bytes,2,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedRequest) Reset() {
+	*x = GetRelatedRequest{}
+	mi := &file_catalog_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedRequest) ProtoMessage() {}
+
+func (x *GetRelatedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetRelatedRequest) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+func (x *GetRelatedRequest) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
+type RelatedTitle struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ShikimoriId string                 `protobuf:"bytes,1,opt,name=shikimori_id,json=shikimoriId,proto3" json:"shikimori_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// tv, movie, ova, ...
+	Kind    string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	AiredOn *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=aired_on,json=airedOn,proto3" json:"aired_on,omitempty"`
+	// relation to the requested title when linked directly, e.g. "sequel"
+	Relation string `protobuf:"bytes,5,opt,name=relation,proto3" json:"relation,omitempty"`
+	// set when the catalog has seen a Kodik release of the title
+	Anime         *Anime `protobuf:"bytes,6,opt,name=anime,proto3" json:"anime,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelatedTitle) Reset() {
+	*x = RelatedTitle{}
+	mi := &file_catalog_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedTitle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedTitle) ProtoMessage() {}
+
+func (x *RelatedTitle) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedTitle.ProtoReflect.Descriptor instead.
+func (*RelatedTitle) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RelatedTitle) GetShikimoriId() string {
+	if x != nil {
+		return x.ShikimoriId
+	}
+	return ""
+}
+
+func (x *RelatedTitle) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RelatedTitle) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RelatedTitle) GetAiredOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AiredOn
+	}
+	return nil
+}
+
+func (x *RelatedTitle) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *RelatedTitle) GetAnime() *Anime {
+	if x != nil {
+		return x.Anime
+	}
+	return nil
+}
+
+type RelationEdge struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FromShikimoriId string                 `protobuf:"bytes,1,opt,name=from_shikimori_id,json=fromShikimoriId,proto3" json:"from_shikimori_id,omitempty"`
+	ToShikimoriId   string                 `protobuf:"bytes,2,opt,name=to_shikimori_id,json=toShikimoriId,proto3" json:"to_shikimori_id,omitempty"`
+	Relation        string                 `protobuf:"bytes,3,opt,name=relation,proto3" json:"relation,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RelationEdge) Reset() {
+	*x = RelationEdge{}
+	mi := &file_catalog_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationEdge) ProtoMessage() {}
+
+func (x *RelationEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationEdge.ProtoReflect.Descriptor instead.
+func (*RelationEdge) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RelationEdge) GetFromShikimoriId() string {
+	if x != nil {
+		return x.FromShikimoriId
+	}
+	return ""
+}
+
+func (x *RelationEdge) GetToShikimoriId() string {
+	if x != nil {
+		return x.ToShikimoriId
+	}
+	return ""
+}
+
+func (x *RelationEdge) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+type GetRelatedResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the whole franchise, requested title included, in watch order
+	Titles        []*RelatedTitle `protobuf:"bytes,1,rep,name=titles,proto3" json:"titles,omitempty"`
+	Edges         []*RelationEdge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedResponse) Reset() {
+	*x = GetRelatedResponse{}
+	mi := &file_catalog_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedResponse) ProtoMessage() {}
+
+func (x *GetRelatedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedResponse.ProtoReflect.Descriptor instead.
+func (*GetRelatedResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetRelatedResponse) GetTitles() []*RelatedTitle {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+func (x *GetRelatedResponse) GetEdges() []*RelationEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+type RecommendRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// defaults to 20
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendRequest) Reset() {
+	*x = RecommendRequest{}
+	mi := &file_catalog_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendRequest) ProtoMessage() {}
+
+func (x *RecommendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendRequest.ProtoReflect.Descriptor instead.
+func (*RecommendRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RecommendRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecommendRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Recommendation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Anime *Anime                 `protobuf:"bytes,1,opt,name=anime,proto3" json:"anime,omitempty"`
+	Score float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// human-readable, e.g. "because you rated Frieren 9/10"
+	Reasons []string `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	// library titles the recommendation is based on
+	BecauseAniflowIds []string `protobuf:"bytes,4,rep,name=because_aniflow_ids,json=becauseAniflowIds,proto3" json:"because_aniflow_ids,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Recommendation) Reset() {
+	*x = Recommendation{}
+	mi := &file_catalog_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recommendation) ProtoMessage() {}
+
+func (x *Recommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recommendation.ProtoReflect.Descriptor instead.
+func (*Recommendation) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Recommendation) GetAnime() *Anime {
+	if x != nil {
+		return x.Anime
+	}
+	return nil
+}
+
+func (x *Recommendation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Recommendation) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *Recommendation) GetBecauseAniflowIds() []string {
+	if x != nil {
+		return x.BecauseAniflowIds
+	}
+	return nil
+}
+
+type RecommendResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Recommendation      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendResponse) Reset() {
+	*x = RecommendResponse{}
+	mi := &file_catalog_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendResponse) ProtoMessage() {}
+
+func (x *RecommendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendResponse.ProtoReflect.Descriptor instead.
+func (*RecommendResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RecommendResponse) GetItems() []*Recommendation {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ListTrendingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// day, week (default) or month
+	Window        string `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	Page          int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrendingRequest) Reset() {
+	*x = ListTrendingRequest{}
+	mi := &file_catalog_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrendingRequest) ProtoMessage() {}
+
+func (x *ListTrendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrendingRequest.ProtoReflect.Descriptor instead.
+func (*ListTrendingRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListTrendingRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *ListTrendingRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListTrendingRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListTopRatedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// kinopoisk (default) or shikimori
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// titles with fewer votes are left out; defaults per source
+	MinVotes      int32 `protobuf:"varint,2,opt,name=min_votes,json=minVotes,proto3" json:"min_votes,omitempty"`
+	Page          int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopRatedRequest) Reset() {
+	*x = ListTopRatedRequest{}
+	mi := &file_catalog_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopRatedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopRatedRequest) ProtoMessage() {}
+
+func (x *ListTopRatedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopRatedRequest.ProtoReflect.Descriptor instead.
+func (*ListTopRatedRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListTopRatedRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ListTopRatedRequest) GetMinVotes() int32 {
+	if x != nil {
+		return x.MinVotes
+	}
+	return 0
+}
+
+func (x *ListTopRatedRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListTopRatedRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListRecentlyUpdatedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentlyUpdatedRequest) Reset() {
+	*x = ListRecentlyUpdatedRequest{}
+	mi := &file_catalog_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentlyUpdatedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentlyUpdatedRequest) ProtoMessage() {}
+
+func (x *ListRecentlyUpdatedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentlyUpdatedRequest.ProtoReflect.Descriptor instead.
+func (*ListRecentlyUpdatedRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListRecentlyUpdatedRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListRecentlyUpdatedRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListTitlesResponse is one page of a browse feed.
+type ListTitlesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Items []*Anime               `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Page  int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// titles in the feed, across pages
+	Total         int32 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	HasMore       bool  `protobuf:"varint,4,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTitlesResponse) Reset() {
+	*x = ListTitlesResponse{}
+	mi := &file_catalog_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTitlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTitlesResponse) ProtoMessage() {}
+
+func (x *ListTitlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTitlesResponse.ProtoReflect.Descriptor instead.
+func (*ListTitlesResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListTitlesResponse) GetItems() []*Anime {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListTitlesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListTitlesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListTitlesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type Genre struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Slug  string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	// genre, demographic, theme or tag
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// name in the requested locale
+	Name    string            `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Names   map[string]string `protobuf:"bytes,4,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Aliases []string          `protobuf:"bytes,5,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// indexed titles with this genre, approximate
+	TitleCount    int32 `protobuf:"varint,6,opt,name=title_count,json=titleCount,proto3" json:"title_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Genre) Reset() {
+	*x = Genre{}
+	mi := &file_catalog_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Genre) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Genre) ProtoMessage() {}
+
+func (x *Genre) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Genre.ProtoReflect.Descriptor instead.
+func (*Genre) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Genre) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Genre) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Genre) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Genre) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *Genre) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Genre) GetTitleCount() int32 {
+	if x != nil {
+		return x.TitleCount
+	}
+	return 0
+}
+
+type ListGenresRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ru or en (default)
+	Locale string `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	// only genres of this kind when set
+	Kind          string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGenresRequest) Reset() {
+	*x = ListGenresRequest{}
+	mi := &file_catalog_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGenresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGenresRequest) ProtoMessage() {}
+
+func (x *ListGenresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGenresRequest.ProtoReflect.Descriptor instead.
+func (*ListGenresRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListGenresRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ListGenresRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type ListGenresResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Genres        []*Genre               `protobuf:"bytes,1,rep,name=genres,proto3" json:"genres,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGenresResponse) Reset() {
+	*x = ListGenresResponse{}
+	mi := &file_catalog_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGenresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGenresResponse) ProtoMessage() {}
+
+func (x *ListGenresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGenresResponse.ProtoReflect.Descriptor instead.
+func (*ListGenresResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListGenresResponse) GetGenres() []*Genre {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+type BrowseByGenreRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// slugs or aliases; titles must have all of them
+	Genres []string `protobuf:"bytes,1,rep,name=genres,proto3" json:"genres,omitempty"`
+	// titles with any of these are left out
+	Exclude []string `protobuf:"bytes,2,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	// rating (default), year, updated or title
+	Sort string `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`
+	// desc (default) or asc
+	Order         string `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`
+	Page          int32  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrowseByGenreRequest) Reset() {
+	*x = BrowseByGenreRequest{}
+	mi := &file_catalog_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrowseByGenreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrowseByGenreRequest) ProtoMessage() {}
+
+func (x *BrowseByGenreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrowseByGenreRequest.ProtoReflect.Descriptor instead.
+func (*BrowseByGenreRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *BrowseByGenreRequest) GetGenres() []string {
+	if x != nil {
+		return x.Genres
+	}
+	return nil
+}
+
+func (x *BrowseByGenreRequest) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+func (x *BrowseByGenreRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *BrowseByGenreRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *BrowseByGenreRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *BrowseByGenreRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+var File_catalog_proto protoreflect.FileDescriptor
+
+const file_catalog_proto_rawDesc = "" +
+	"\n" +
+	"\rcatalog.proto\x12\x12aniflow.catalog.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"G\n" +
+	"\vTranslation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\"\x9b\a\n" +
+	"\x05Anime\x12\x19\n" +
+	"\bkodik_id\x18\x01 \x01(\tR\akodikId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06genres\x18\x04 \x03(\tR\x06genres\x12\x1d\n" +
+	"\n" +
+	"poster_url\x18\x05 \x01(\tR\tposterUrl\x12%\n" +
+	"\x0eepisodes_count\x18\x06 \x01(\x05R\repisodesCount\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12C\n" +
+	"\ftranslations\x18\b \x03(\v2\x1f.aniflow.catalog.v1.TranslationR\ftranslations\x12\x12\n" +
+	"\x04year\x18\t \x01(\x05R\x04year\x12)\n" +
+	"\x10kinopoisk_rating\x18\n" +
+	" \x01(\x01R\x0fkinopoiskRating\x12(\n" +
+	"\x10anime_poster_url\x18\v \x01(\tR\x0eanimePosterUrl\x124\n" +
+	"\tfull_data\x18\f \x01(\v2\x17.google.protobuf.StructR\bfullData\x12!\n" +
+	"\flast_episode\x18\r \x01(\x05R\vlastEpisode\x12!\n" +
+	"\fkinopoisk_id\x18\x0e \x01(\tR\vkinopoiskId\x12!\n" +
+	"\fshikimori_id\x18\x0f \x01(\tR\vshikimoriId\x12\x17\n" +
+	"\aimdb_id\x18\x10 \x01(\tR\x06imdbId\x12#\n" +
+	"\rworldart_link\x18\x11 \x01(\tR\fworldartLink\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x12 \x01(\tR\taniflowId\x12\x18\n" +
+	"\astudios\x18\x13 \x03(\tR\astudios\x12'\n" +
+	"\x0fkinopoisk_votes\x18\x14 \x01(\x05R\x0ekinopoiskVotes\x12)\n" +
+	"\x10shikimori_rating\x18\x15 \x01(\x01R\x0fshikimoriRating\x12'\n" +
+	"\x0fshikimori_votes\x18\x16 \x01(\x05R\x0eshikimoriVotes\x12D\n" +
+	"\x10kodik_updated_at\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampR\x0ekodikUpdatedAt\x12\x1f\n" +
+	"\vgenre_slugs\x18\x18 \x03(\tR\n" +
+	"genreSlugs\"K\n" +
+	"\x0fGetAnimeRequest\x12\x19\n" +
+	"\bkodik_id\x18\x01 \x01(\tR\akodikId\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x02 \x01(\tR\taniflowId\"V\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"W\n" +
+	"\x0eSearchResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.aniflow.catalog.v1.AnimeR\x05items\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"T\n" +
+	"\x14BatchGetAnimeRequest\x12\x1b\n" +
+	"\tkodik_ids\x18\x01 \x03(\tR\bkodikIds\x12\x1f\n" +
+	"\vaniflow_ids\x18\x02 \x03(\tR\n" +
+	"aniflowIds\"\x96\x01\n" +
+	"\x13BatchGetAnimeResult\x12\x19\n" +
+	"\bkodik_id\x18\x01 \x01(\tR\akodikId\x12/\n" +
+	"\x05anime\x18\x02 \x01(\v2\x19.aniflow.catalog.v1.AnimeR\x05anime\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x04 \x01(\tR\taniflowId\"Z\n" +
+	"\x15BatchGetAnimeResponse\x12A\n" +
+	"\aresults\x18\x01 \x03(\v2'.aniflow.catalog.v1.BatchGetAnimeResultR\aresults\"\xd9\x01\n" +
+	"\x14SearchStreamResponse\x12/\n" +
+	"\x05anime\x18\x01 \x01(\v2\x19.aniflow.catalog.v1.AnimeR\x05anime\x12G\n" +
+	"\x06source\x18\x02 \x01(\x0e2/.aniflow.catalog.v1.SearchStreamResponse.SourceR\x06source\"G\n" +
+	"\x06Source\x12\x16\n" +
+	"\x12SOURCE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fSOURCE_LOCAL\x10\x01\x12\x13\n" +
+	"\x0fSOURCE_UPSTREAM\x10\x02\"\x9d\x03\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bkodik_id\x18\x03 \x01(\tR\akodikId\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x129\n" +
+	"\x04kind\x18\x05 \x01(\x0e2%.aniflow.catalog.v1.Notification.KindR\x04kind\x12\x18\n" +
+	"\aepisode\x18\x06 \x01(\x05R\aepisode\x12A\n" +
+	"\vtranslation\x18\a \x01(\v2\x1f.aniflow.catalog.v1.TranslationR\vtranslation\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x12\n" +
+	"\x04read\x18\t \x01(\bR\x04read\"L\n" +
+	"\x04Kind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10KIND_NEW_EPISODE\x10\x01\x12\x18\n" +
+	"\x14KIND_NEW_TRANSLATION\x10\x02\"8\n" +
+	"\x1dSubscribeNotificationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"T\n" +
+	"\x18ListNotificationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vunread_only\x18\x02 \x01(\bR\n" +
+	"unreadOnly\"{\n" +
+	"\x19ListNotificationsResponse\x12F\n" +
+	"\rnotifications\x18\x01 \x03(\v2 .aniflow.catalog.v1.NotificationR\rnotifications\x12\x16\n" +
+	"\x06unread\x18\x02 \x01(\x05R\x06unread\"I\n" +
+	"\x1cMarkNotificationsReadRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids\"9\n" +
+	"\x1dMarkNotificationsReadResponse\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x05R\aupdated\"I\n" +
+	"\x12GetScheduleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\btimezone\x18\x02 \x01(\tR\btimezone\"\xe0\x01\n" +
+	"\fScheduleItem\x12/\n" +
+	"\x05anime\x18\x01 \x01(\v2\x19.aniflow.catalog.v1.AnimeR\x05anime\x12!\n" +
+	"\flast_episode\x18\x02 \x01(\x05R\vlastEpisode\x12!\n" +
+	"\fnext_episode\x18\x03 \x01(\x05R\vnextEpisode\x12;\n" +
+	"\vexpected_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expectedAt\x12\x1c\n" +
+	"\testimated\x18\x05 \x01(\bR\testimated\"\x8f\x01\n" +
+	"\vScheduleDay\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x18\n" +
+	"\aweekday\x18\x02 \x01(\tR\aweekday\x126\n" +
+	"\x05items\x18\x03 \x03(\v2 .aniflow.catalog.v1.ScheduleItemR\x05items\"J\n" +
+	"\x13GetScheduleResponse\x123\n" +
+	"\x04days\x18\x01 \x03(\v2\x1f.aniflow.catalog.v1.ScheduleDayR\x04days\"\xb0\x01\n" +
+	"\x13ResolveAnimeRequest\x12!\n" +
+	"\fkinopoisk_id\x18\x01 \x01(\tR\vkinopoiskId\x12!\n" +
+	"\fshikimori_id\x18\x02 \x01(\tR\vshikimoriId\x12\x15\n" +
+	"\x06mal_id\x18\x03 \x01(\tR\x05malId\x12\x17\n" +
+	"\aimdb_id\x18\x04 \x01(\tR\x06imdbId\x12#\n" +
+	"\rworldart_link\x18\x05 \x01(\tR\fworldartLink\"d\n" +
+	"\x14ResolveAnimeResponse\x12/\n" +
+	"\x05anime\x18\x01 \x01(\v2\x19.aniflow.catalog.v1.AnimeR\x05anime\x12\x1b\n" +
+	"\tkodik_ids\x18\x02 \x03(\tR\bkodikIds\"\x94\x02\n" +
+	"\rMergeOverride\x12:\n" +
+	"\x04kind\x18\x01 \x01(\x0e2&.aniflow.catalog.v1.MergeOverride.KindR\x04kind\x12\x1c\n" +
+	"\n" +
+	"kodik_id_a\x18\x02 \x01(\tR\bkodikIdA\x12\x1c\n" +
+	"\n" +
+	"kodik_id_b\x18\x03 \x01(\tR\bkodikIdB\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"<\n" +
+	"\x04Kind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"KIND_MERGE\x10\x01\x12\x0e\n" +
+	"\n" +
+	"KIND_SPLIT\x10\x02\"\x1b\n" +
+	"\x19ListMergeOverridesRequest\"]\n" +
+	"\x1aListMergeOverridesResponse\x12?\n" +
+	"\toverrides\x18\x01 \x03(\v2!.aniflow.catalog.v1.MergeOverrideR\toverrides\"X\n" +
+	"\x1aDeleteMergeOverrideRequest\x12\x1c\n" +
+	"\n" +
+	"kodik_id_a\x18\x01 \x01(\tR\bkodikIdA\x12\x1c\n" +
+	"\n" +
+	"kodik_id_b\x18\x02 \x01(\tR\bkodikIdB\"7\n" +
+	"\x1bDeleteMergeOverrideResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"M\n" +
+	"\x11GetRelatedRequest\x12\x19\n" +
+	"\bkodik_id\x18\x01 \x01(\tR\akodikId\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x02 \x01(\tR\taniflowId\"\xdd\x01\n" +
+	"\fRelatedTitle\x12!\n" +
+	"\fshikimori_id\x18\x01 \x01(\tR\vshikimoriId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x125\n" +
+	"\baired_on\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aairedOn\x12\x1a\n" +
+	"\brelation\x18\x05 \x01(\tR\brelation\x12/\n" +
+	"\x05anime\x18\x06 \x01(\v2\x19.aniflow.catalog.v1.AnimeR\x05anime\"~\n" +
+	"\fRelationEdge\x12*\n" +
+	"\x11from_shikimori_id\x18\x01 \x01(\tR\x0ffromShikimoriId\x12&\n" +
+	"\x0fto_shikimori_id\x18\x02 \x01(\tR\rtoShikimoriId\x12\x1a\n" +
+	"\brelation\x18\x03 \x01(\tR\brelation\"\x86\x01\n" +
+	"\x12GetRelatedResponse\x128\n" +
+	"\x06titles\x18\x01 \x03(\v2 .aniflow.catalog.v1.RelatedTitleR\x06titles\x126\n" +
+	"\x05edges\x18\x02 \x03(\v2 .aniflow.catalog.v1.RelationEdgeR\x05edges\"A\n" +
+	"\x10RecommendRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xa1\x01\n" +
+	"\x0eRecommendation\x12/\n" +
+	"\x05anime\x18\x01 \x01(\v2\x19.aniflow.catalog.v1.AnimeR\x05anime\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x18\n" +
+	"\areasons\x18\x03 \x03(\tR\areasons\x12.\n" +
+	"\x13because_aniflow_ids\x18\x04 \x03(\tR\x11becauseAniflowIds\"M\n" +
+	"\x11RecommendResponse\x128\n" +
+	"\x05items\x18\x01 \x03(\v2\".aniflow.catalog.v1.RecommendationR\x05items\"^\n" +
+	"\x13ListTrendingRequest\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\tR\x06window\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"{\n" +
+	"\x13ListTopRatedRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x1b\n" +
+	"\tmin_votes\x18\x02 \x01(\x05R\bminVotes\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"M\n" +
+	"\x1aListRecentlyUpdatedRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x8a\x01\n" +
+	"\x12ListTitlesResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.aniflow.catalog.v1.AnimeR\x05items\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x19\n" +
+	"\bhas_more\x18\x04 \x01(\bR\ahasMore\"\xf4\x01\n" +
+	"\x05Genre\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12:\n" +
+	"\x05names\x18\x04 \x03(\v2$.aniflow.catalog.v1.Genre.NamesEntryR\x05names\x12\x18\n" +
+	"\aaliases\x18\x05 \x03(\tR\aaliases\x12\x1f\n" +
+	"\vtitle_count\x18\x06 \x01(\x05R\n" +
+	"titleCount\x1a8\n" +
+	"\n" +
+	"NamesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"?\n" +
+	"\x11ListGenresRequest\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\"G\n" +
+	"\x12ListGenresResponse\x121\n" +
+	"\x06genres\x18\x01 \x03(\v2\x19.aniflow.catalog.v1.GenreR\x06genres\"\xa3\x01\n" +
+	"\x14BrowseByGenreRequest\x12\x16\n" +
+	"\x06genres\x18\x01 \x03(\tR\x06genres\x12\x18\n" +
+	"\aexclude\x18\x02 \x03(\tR\aexclude\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x04 \x01(\tR\x05order\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize2\xfe\x0e\n" +
+	"\aCatalog\x12J\n" +
+	"\bGetAnime\x12#.aniflow.catalog.v1.GetAnimeRequest\x1a\x19.aniflow.catalog.v1.Anime\x12O\n" +
+	"\x06Search\x12!.aniflow.catalog.v1.SearchRequest\x1a\".aniflow.catalog.v1.SearchResponse\x12d\n" +
+	"\rBatchGetAnime\x12(.aniflow.catalog.v1.BatchGetAnimeRequest\x1a).aniflow.catalog.v1.BatchGetAnimeResponse\x12]\n" +
+	"\fSearchStream\x12!.aniflow.catalog.v1.SearchRequest\x1a(.aniflow.catalog.v1.SearchStreamResponse0\x01\x12o\n" +
+	"\x16SubscribeNotifications\x121.aniflow.catalog.v1.SubscribeNotificationsRequest\x1a .aniflow.catalog.v1.Notification0\x01\x12p\n" +
+	"\x11ListNotifications\x12,.aniflow.catalog.v1.ListNotificationsRequest\x1a-.aniflow.catalog.v1.ListNotificationsResponse\x12|\n" +
+	"\x15MarkNotificationsRead\x120.aniflow.catalog.v1.MarkNotificationsReadRequest\x1a1.aniflow.catalog.v1.MarkNotificationsReadResponse\x12^\n" +
+	"\vGetSchedule\x12&.aniflow.catalog.v1.GetScheduleRequest\x1a'.aniflow.catalog.v1.GetScheduleResponse\x12a\n" +
+	"\fResolveAnime\x12'.aniflow.catalog.v1.ResolveAnimeRequest\x1a(.aniflow.catalog.v1.ResolveAnimeResponse\x12[\n" +
+	"\n" +
+	"GetRelated\x12%.aniflow.catalog.v1.GetRelatedRequest\x1a&.aniflow.catalog.v1.GetRelatedResponse\x12X\n" +
+	"\tRecommend\x12$.aniflow.catalog.v1.RecommendRequest\x1a%.aniflow.catalog.v1.RecommendResponse\x12_\n" +
+	"\fListTrending\x12'.aniflow.catalog.v1.ListTrendingRequest\x1a&.aniflow.catalog.v1.ListTitlesResponse\x12_\n" +
+	"\fListTopRated\x12'.aniflow.catalog.v1.ListTopRatedRequest\x1a&.aniflow.catalog.v1.ListTitlesResponse\x12m\n" +
+	"\x13ListRecentlyUpdated\x12..aniflow.catalog.v1.ListRecentlyUpdatedRequest\x1a&.aniflow.catalog.v1.ListTitlesResponse\x12[\n" +
+	"\n" +
+	"ListGenres\x12%.aniflow.catalog.v1.ListGenresRequest\x1a&.aniflow.catalog.v1.ListGenresResponse\x12a\n" +
+	"\rBrowseByGenre\x12(.aniflow.catalog.v1.BrowseByGenreRequest\x1a&.aniflow.catalog.v1.ListTitlesResponse\x12X\n" +
+	"\x10SetMergeOverride\x12!.aniflow.catalog.v1.MergeOverride\x1a!.aniflow.catalog.v1.MergeOverride\x12s\n" +
+	"\x12ListMergeOverrides\x12-.aniflow.catalog.v1.ListMergeOverridesRequest\x1a..aniflow.catalog.v1.ListMergeOverridesResponse\x12v\n" +
+	"\x13DeleteMergeOverride\x12..aniflow.catalog.v1.DeleteMergeOverrideRequest\x1a/.aniflow.catalog.v1.DeleteMergeOverrideResponseB<Z:github.com/greg5320/aniflow/services/catalog/gen;catalogpbb\x06proto3"
 
 var (
 	file_catalog_proto_rawDescOnce sync.Once
@@ -421,30 +3021,132 @@ func file_catalog_proto_rawDescGZIP() []byte {
 	return file_catalog_proto_rawDescData
 }
 
-var file_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_catalog_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_catalog_proto_goTypes = []any{
-	(*Translation)(nil),           // 0: aniflow.catalog.v1.Translation
-	(*Anime)(nil),                 // 1: aniflow.catalog.v1.Anime
-	(*GetAnimeRequest)(nil),       // 2: aniflow.catalog.v1.GetAnimeRequest
-	(*SearchRequest)(nil),         // 3: aniflow.catalog.v1.SearchRequest
-	(*SearchResponse)(nil),        // 4: aniflow.catalog.v1.SearchResponse
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
-	(*structpb.Struct)(nil),       // 6: google.protobuf.Struct
+	(SearchStreamResponse_Source)(0),      // 0: aniflow.catalog.v1.SearchStreamResponse.Source
+	(Notification_Kind)(0),                // 1: aniflow.catalog.v1.Notification.Kind
+	(MergeOverride_Kind)(0),               // 2: aniflow.catalog.v1.MergeOverride.Kind
+	(*Translation)(nil),                   // 3: aniflow.catalog.v1.Translation
+	(*Anime)(nil),                         // 4: aniflow.catalog.v1.Anime
+	(*GetAnimeRequest)(nil),               // 5: aniflow.catalog.v1.GetAnimeRequest
+	(*SearchRequest)(nil),                 // 6: aniflow.catalog.v1.SearchRequest
+	(*SearchResponse)(nil),                // 7: aniflow.catalog.v1.SearchResponse
+	(*BatchGetAnimeRequest)(nil),          // 8: aniflow.catalog.v1.BatchGetAnimeRequest
+	(*BatchGetAnimeResult)(nil),           // 9: aniflow.catalog.v1.BatchGetAnimeResult
+	(*BatchGetAnimeResponse)(nil),         // 10: aniflow.catalog.v1.BatchGetAnimeResponse
+	(*SearchStreamResponse)(nil),          // 11: aniflow.catalog.v1.SearchStreamResponse
+	(*Notification)(nil),                  // 12: aniflow.catalog.v1.Notification
+	(*SubscribeNotificationsRequest)(nil), // 13: aniflow.catalog.v1.SubscribeNotificationsRequest
+	(*ListNotificationsRequest)(nil),      // 14: aniflow.catalog.v1.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),     // 15: aniflow.catalog.v1.ListNotificationsResponse
+	(*MarkNotificationsReadRequest)(nil),  // 16: aniflow.catalog.v1.MarkNotificationsReadRequest
+	(*MarkNotificationsReadResponse)(nil), // 17: aniflow.catalog.v1.MarkNotificationsReadResponse
+	(*GetScheduleRequest)(nil),            // 18: aniflow.catalog.v1.GetScheduleRequest
+	(*ScheduleItem)(nil),                  // 19: aniflow.catalog.v1.ScheduleItem
+	(*ScheduleDay)(nil),                   // 20: aniflow.catalog.v1.ScheduleDay
+	(*GetScheduleResponse)(nil),           // 21: aniflow.catalog.v1.GetScheduleResponse
+	(*ResolveAnimeRequest)(nil),           // 22: aniflow.catalog.v1.ResolveAnimeRequest
+	(*ResolveAnimeResponse)(nil),          // 23: aniflow.catalog.v1.ResolveAnimeResponse
+	(*MergeOverride)(nil),                 // 24: aniflow.catalog.v1.MergeOverride
+	(*ListMergeOverridesRequest)(nil),     // 25: aniflow.catalog.v1.ListMergeOverridesRequest
+	(*ListMergeOverridesResponse)(nil),    // 26: aniflow.catalog.v1.ListMergeOverridesResponse
+	(*DeleteMergeOverrideRequest)(nil),    // 27: aniflow.catalog.v1.DeleteMergeOverrideRequest
+	(*DeleteMergeOverrideResponse)(nil),   // 28: aniflow.catalog.v1.DeleteMergeOverrideResponse
+	(*GetRelatedRequest)(nil),             // 29: aniflow.catalog.v1.GetRelatedRequest
+	(*RelatedTitle)(nil),                  // 30: aniflow.catalog.v1.RelatedTitle
+	(*RelationEdge)(nil),                  // 31: aniflow.catalog.v1.RelationEdge
+	(*GetRelatedResponse)(nil),            // 32: aniflow.catalog.v1.GetRelatedResponse
+	(*RecommendRequest)(nil),              // 33: aniflow.catalog.v1.RecommendRequest
+	(*Recommendation)(nil),                // 34: aniflow.catalog.v1.Recommendation
+	(*RecommendResponse)(nil),             // 35: aniflow.catalog.v1.RecommendResponse
+	(*ListTrendingRequest)(nil),           // 36: aniflow.catalog.v1.ListTrendingRequest
+	(*ListTopRatedRequest)(nil),           // 37: aniflow.catalog.v1.ListTopRatedRequest
+	(*ListRecentlyUpdatedRequest)(nil),    // 38: aniflow.catalog.v1.ListRecentlyUpdatedRequest
+	(*ListTitlesResponse)(nil),            // 39: aniflow.catalog.v1.ListTitlesResponse
+	(*Genre)(nil),                         // 40: aniflow.catalog.v1.Genre
+	(*ListGenresRequest)(nil),             // 41: aniflow.catalog.v1.ListGenresRequest
+	(*ListGenresResponse)(nil),            // 42: aniflow.catalog.v1.ListGenresResponse
+	(*BrowseByGenreRequest)(nil),          // 43: aniflow.catalog.v1.BrowseByGenreRequest
+	nil,                                   // 44: aniflow.catalog.v1.Genre.NamesEntry
+	(*timestamppb.Timestamp)(nil),         // 45: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),               // 46: google.protobuf.Struct
 }
 var file_catalog_proto_depIdxs = []int32{
-	5, // 0: aniflow.catalog.v1.Anime.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 1: aniflow.catalog.v1.Anime.translations:type_name -> aniflow.catalog.v1.Translation
-	6, // 2: aniflow.catalog.v1.Anime.full_data:type_name -> google.protobuf.Struct
-	1, // 3: aniflow.catalog.v1.SearchResponse.items:type_name -> aniflow.catalog.v1.Anime
-	2, // 4: aniflow.catalog.v1.Catalog.GetAnime:input_type -> aniflow.catalog.v1.GetAnimeRequest
-	3, // 5: aniflow.catalog.v1.Catalog.Search:input_type -> aniflow.catalog.v1.SearchRequest
-	1, // 6: aniflow.catalog.v1.Catalog.GetAnime:output_type -> aniflow.catalog.v1.Anime
-	4, // 7: aniflow.catalog.v1.Catalog.Search:output_type -> aniflow.catalog.v1.SearchResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	45, // 0: aniflow.catalog.v1.Anime.updated_at:type_name -> google.protobuf.Timestamp
+	3,  // 1: aniflow.catalog.v1.Anime.translations:type_name -> aniflow.catalog.v1.Translation
+	46, // 2: aniflow.catalog.v1.Anime.full_data:type_name -> google.protobuf.Struct
+	45, // 3: aniflow.catalog.v1.Anime.kodik_updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 4: aniflow.catalog.v1.SearchResponse.items:type_name -> aniflow.catalog.v1.Anime
+	4,  // 5: aniflow.catalog.v1.BatchGetAnimeResult.anime:type_name -> aniflow.catalog.v1.Anime
+	9,  // 6: aniflow.catalog.v1.BatchGetAnimeResponse.results:type_name -> aniflow.catalog.v1.BatchGetAnimeResult
+	4,  // 7: aniflow.catalog.v1.SearchStreamResponse.anime:type_name -> aniflow.catalog.v1.Anime
+	0,  // 8: aniflow.catalog.v1.SearchStreamResponse.source:type_name -> aniflow.catalog.v1.SearchStreamResponse.Source
+	1,  // 9: aniflow.catalog.v1.Notification.kind:type_name -> aniflow.catalog.v1.Notification.Kind
+	3,  // 10: aniflow.catalog.v1.Notification.translation:type_name -> aniflow.catalog.v1.Translation
+	45, // 11: aniflow.catalog.v1.Notification.created_at:type_name -> google.protobuf.Timestamp
+	12, // 12: aniflow.catalog.v1.ListNotificationsResponse.notifications:type_name -> aniflow.catalog.v1.Notification
+	4,  // 13: aniflow.catalog.v1.ScheduleItem.anime:type_name -> aniflow.catalog.v1.Anime
+	45, // 14: aniflow.catalog.v1.ScheduleItem.expected_at:type_name -> google.protobuf.Timestamp
+	45, // 15: aniflow.catalog.v1.ScheduleDay.date:type_name -> google.protobuf.Timestamp
+	19, // 16: aniflow.catalog.v1.ScheduleDay.items:type_name -> aniflow.catalog.v1.ScheduleItem
+	20, // 17: aniflow.catalog.v1.GetScheduleResponse.days:type_name -> aniflow.catalog.v1.ScheduleDay
+	4,  // 18: aniflow.catalog.v1.ResolveAnimeResponse.anime:type_name -> aniflow.catalog.v1.Anime
+	2,  // 19: aniflow.catalog.v1.MergeOverride.kind:type_name -> aniflow.catalog.v1.MergeOverride.Kind
+	45, // 20: aniflow.catalog.v1.MergeOverride.created_at:type_name -> google.protobuf.Timestamp
+	24, // 21: aniflow.catalog.v1.ListMergeOverridesResponse.overrides:type_name -> aniflow.catalog.v1.MergeOverride
+	45, // 22: aniflow.catalog.v1.RelatedTitle.aired_on:type_name -> google.protobuf.Timestamp
+	4,  // 23: aniflow.catalog.v1.RelatedTitle.anime:type_name -> aniflow.catalog.v1.Anime
+	30, // 24: aniflow.catalog.v1.GetRelatedResponse.titles:type_name -> aniflow.catalog.v1.RelatedTitle
+	31, // 25: aniflow.catalog.v1.GetRelatedResponse.edges:type_name -> aniflow.catalog.v1.RelationEdge
+	4,  // 26: aniflow.catalog.v1.Recommendation.anime:type_name -> aniflow.catalog.v1.Anime
+	34, // 27: aniflow.catalog.v1.RecommendResponse.items:type_name -> aniflow.catalog.v1.Recommendation
+	4,  // 28: aniflow.catalog.v1.ListTitlesResponse.items:type_name -> aniflow.catalog.v1.Anime
+	44, // 29: aniflow.catalog.v1.Genre.names:type_name -> aniflow.catalog.v1.Genre.NamesEntry
+	40, // 30: aniflow.catalog.v1.ListGenresResponse.genres:type_name -> aniflow.catalog.v1.Genre
+	5,  // 31: aniflow.catalog.v1.Catalog.GetAnime:input_type -> aniflow.catalog.v1.GetAnimeRequest
+	6,  // 32: aniflow.catalog.v1.Catalog.Search:input_type -> aniflow.catalog.v1.SearchRequest
+	8,  // 33: aniflow.catalog.v1.Catalog.BatchGetAnime:input_type -> aniflow.catalog.v1.BatchGetAnimeRequest
+	6,  // 34: aniflow.catalog.v1.Catalog.SearchStream:input_type -> aniflow.catalog.v1.SearchRequest
+	13, // 35: aniflow.catalog.v1.Catalog.SubscribeNotifications:input_type -> aniflow.catalog.v1.SubscribeNotificationsRequest
+	14, // 36: aniflow.catalog.v1.Catalog.ListNotifications:input_type -> aniflow.catalog.v1.ListNotificationsRequest
+	16, // 37: aniflow.catalog.v1.Catalog.MarkNotificationsRead:input_type -> aniflow.catalog.v1.MarkNotificationsReadRequest
+	18, // 38: aniflow.catalog.v1.Catalog.GetSchedule:input_type -> aniflow.catalog.v1.GetScheduleRequest
+	22, // 39: aniflow.catalog.v1.Catalog.ResolveAnime:input_type -> aniflow.catalog.v1.ResolveAnimeRequest
+	29, // 40: aniflow.catalog.v1.Catalog.GetRelated:input_type -> aniflow.catalog.v1.GetRelatedRequest
+	33, // 41: aniflow.catalog.v1.Catalog.Recommend:input_type -> aniflow.catalog.v1.RecommendRequest
+	36, // 42: aniflow.catalog.v1.Catalog.ListTrending:input_type -> aniflow.catalog.v1.ListTrendingRequest
+	37, // 43: aniflow.catalog.v1.Catalog.ListTopRated:input_type -> aniflow.catalog.v1.ListTopRatedRequest
+	38, // 44: aniflow.catalog.v1.Catalog.ListRecentlyUpdated:input_type -> aniflow.catalog.v1.ListRecentlyUpdatedRequest
+	41, // 45: aniflow.catalog.v1.Catalog.ListGenres:input_type -> aniflow.catalog.v1.ListGenresRequest
+	43, // 46: aniflow.catalog.v1.Catalog.BrowseByGenre:input_type -> aniflow.catalog.v1.BrowseByGenreRequest
+	24, // 47: aniflow.catalog.v1.Catalog.SetMergeOverride:input_type -> aniflow.catalog.v1.MergeOverride
+	25, // 48: aniflow.catalog.v1.Catalog.ListMergeOverrides:input_type -> aniflow.catalog.v1.ListMergeOverridesRequest
+	27, // 49: aniflow.catalog.v1.Catalog.DeleteMergeOverride:input_type -> aniflow.catalog.v1.DeleteMergeOverrideRequest
+	4,  // 50: aniflow.catalog.v1.Catalog.GetAnime:output_type -> aniflow.catalog.v1.Anime
+	7,  // 51: aniflow.catalog.v1.Catalog.Search:output_type -> aniflow.catalog.v1.SearchResponse
+	10, // 52: aniflow.catalog.v1.Catalog.BatchGetAnime:output_type -> aniflow.catalog.v1.BatchGetAnimeResponse
+	11, // 53: aniflow.catalog.v1.Catalog.SearchStream:output_type -> aniflow.catalog.v1.SearchStreamResponse
+	12, // 54: aniflow.catalog.v1.Catalog.SubscribeNotifications:output_type -> aniflow.catalog.v1.Notification
+	15, // 55: aniflow.catalog.v1.Catalog.ListNotifications:output_type -> aniflow.catalog.v1.ListNotificationsResponse
+	17, // 56: aniflow.catalog.v1.Catalog.MarkNotificationsRead:output_type -> aniflow.catalog.v1.MarkNotificationsReadResponse
+	21, // 57: aniflow.catalog.v1.Catalog.GetSchedule:output_type -> aniflow.catalog.v1.GetScheduleResponse
+	23, // 58: aniflow.catalog.v1.Catalog.ResolveAnime:output_type -> aniflow.catalog.v1.ResolveAnimeResponse
+	32, // 59: aniflow.catalog.v1.Catalog.GetRelated:output_type -> aniflow.catalog.v1.GetRelatedResponse
+	35, // 60: aniflow.catalog.v1.Catalog.Recommend:output_type -> aniflow.catalog.v1.RecommendResponse
+	39, // 61: aniflow.catalog.v1.Catalog.ListTrending:output_type -> aniflow.catalog.v1.ListTitlesResponse
+	39, // 62: aniflow.catalog.v1.Catalog.ListTopRated:output_type -> aniflow.catalog.v1.ListTitlesResponse
+	39, // 63: aniflow.catalog.v1.Catalog.ListRecentlyUpdated:output_type -> aniflow.catalog.v1.ListTitlesResponse
+	42, // 64: aniflow.catalog.v1.Catalog.ListGenres:output_type -> aniflow.catalog.v1.ListGenresResponse
+	39, // 65: aniflow.catalog.v1.Catalog.BrowseByGenre:output_type -> aniflow.catalog.v1.ListTitlesResponse
+	24, // 66: aniflow.catalog.v1.Catalog.SetMergeOverride:output_type -> aniflow.catalog.v1.MergeOverride
+	26, // 67: aniflow.catalog.v1.Catalog.ListMergeOverrides:output_type -> aniflow.catalog.v1.ListMergeOverridesResponse
+	28, // 68: aniflow.catalog.v1.Catalog.DeleteMergeOverride:output_type -> aniflow.catalog.v1.DeleteMergeOverrideResponse
+	50, // [50:69] is the sub-list for method output_type
+	31, // [31:50] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_catalog_proto_init() }
@@ -457,13 +3159,14 @@ func file_catalog_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_proto_rawDesc), len(file_catalog_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      3,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_catalog_proto_goTypes,
 		DependencyIndexes: file_catalog_proto_depIdxs,
+		EnumInfos:         file_catalog_proto_enumTypes,
 		MessageInfos:      file_catalog_proto_msgTypes,
 	}.Build()
 	File_catalog_proto = out.File
@@ -19,8 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Catalog_GetAnime_FullMethodName = "/aniflow.catalog.v1.Catalog/GetAnime"
-	Catalog_Search_FullMethodName   = "/aniflow.catalog.v1.Catalog/Search"
+	Catalog_GetAnime_FullMethodName               = "/aniflow.catalog.v1.Catalog/GetAnime"
+	Catalog_Search_FullMethodName                 = "/aniflow.catalog.v1.Catalog/Search"
+	Catalog_BatchGetAnime_FullMethodName          = "/aniflow.catalog.v1.Catalog/BatchGetAnime"
+	Catalog_SearchStream_FullMethodName           = "/aniflow.catalog.v1.Catalog/SearchStream"
+	Catalog_SubscribeNotifications_FullMethodName = "/aniflow.catalog.v1.Catalog/SubscribeNotifications"
+	Catalog_ListNotifications_FullMethodName      = "/aniflow.catalog.v1.Catalog/ListNotifications"
+	Catalog_MarkNotificationsRead_FullMethodName  = "/aniflow.catalog.v1.Catalog/MarkNotificationsRead"
+	Catalog_GetSchedule_FullMethodName            = "/aniflow.catalog.v1.Catalog/GetSchedule"
+	Catalog_ResolveAnime_FullMethodName           = "/aniflow.catalog.v1.Catalog/ResolveAnime"
+	Catalog_GetRelated_FullMethodName             = "/aniflow.catalog.v1.Catalog/GetRelated"
+	Catalog_Recommend_FullMethodName              = "/aniflow.catalog.v1.Catalog/Recommend"
+	Catalog_ListTrending_FullMethodName           = "/aniflow.catalog.v1.Catalog/ListTrending"
+	Catalog_ListTopRated_FullMethodName           = "/aniflow.catalog.v1.Catalog/ListTopRated"
+	Catalog_ListRecentlyUpdated_FullMethodName    = "/aniflow.catalog.v1.Catalog/ListRecentlyUpdated"
+	Catalog_ListGenres_FullMethodName             = "/aniflow.catalog.v1.Catalog/ListGenres"
+	Catalog_BrowseByGenre_FullMethodName          = "/aniflow.catalog.v1.Catalog/BrowseByGenre"
+	Catalog_SetMergeOverride_FullMethodName       = "/aniflow.catalog.v1.Catalog/SetMergeOverride"
+	Catalog_ListMergeOverrides_FullMethodName     = "/aniflow.catalog.v1.Catalog/ListMergeOverrides"
+	Catalog_DeleteMergeOverride_FullMethodName    = "/aniflow.catalog.v1.Catalog/DeleteMergeOverride"
 )
 
 // CatalogClient is the client API for Catalog service.
@@ -30,6 +47,28 @@ type CatalogClient interface {
 	// unary RPCs for simple needs
 	GetAnime(ctx context.Context, in *GetAnimeRequest, opts ...grpc.CallOption) (*Anime, error)
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	BatchGetAnime(ctx context.Context, in *BatchGetAnimeRequest, opts ...grpc.CallOption) (*BatchGetAnimeResponse, error)
+	// SearchStream emits local index hits immediately, then Kodik hits that
+	// are new or add information.
+	SearchStream(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SearchStreamResponse], error)
+	// SubscribeNotifications replays unread notifications, then streams new
+	// ones as the notifier detects them.
+	SubscribeNotifications(ctx context.Context, in *SubscribeNotificationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error)
+	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
+	MarkNotificationsRead(ctx context.Context, in *MarkNotificationsReadRequest, opts ...grpc.CallOption) (*MarkNotificationsReadResponse, error)
+	GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*GetScheduleResponse, error)
+	ResolveAnime(ctx context.Context, in *ResolveAnimeRequest, opts ...grpc.CallOption) (*ResolveAnimeResponse, error)
+	GetRelated(ctx context.Context, in *GetRelatedRequest, opts ...grpc.CallOption) (*GetRelatedResponse, error)
+	Recommend(ctx context.Context, in *RecommendRequest, opts ...grpc.CallOption) (*RecommendResponse, error)
+	ListTrending(ctx context.Context, in *ListTrendingRequest, opts ...grpc.CallOption) (*ListTitlesResponse, error)
+	ListTopRated(ctx context.Context, in *ListTopRatedRequest, opts ...grpc.CallOption) (*ListTitlesResponse, error)
+	ListRecentlyUpdated(ctx context.Context, in *ListRecentlyUpdatedRequest, opts ...grpc.CallOption) (*ListTitlesResponse, error)
+	ListGenres(ctx context.Context, in *ListGenresRequest, opts ...grpc.CallOption) (*ListGenresResponse, error)
+	BrowseByGenre(ctx context.Context, in *BrowseByGenreRequest, opts ...grpc.CallOption) (*ListTitlesResponse, error)
+	// admin: manual merge/split decisions for the merge engine
+	SetMergeOverride(ctx context.Context, in *MergeOverride, opts ...grpc.CallOption) (*MergeOverride, error)
+	ListMergeOverrides(ctx context.Context, in *ListMergeOverridesRequest, opts ...grpc.CallOption) (*ListMergeOverridesResponse, error)
+	DeleteMergeOverride(ctx context.Context, in *DeleteMergeOverrideRequest, opts ...grpc.CallOption) (*DeleteMergeOverrideResponse, error)
 }
 
 type catalogClient struct {
@@ -60,6 +99,194 @@ func (c *catalogClient) Search(ctx context.Context, in *SearchRequest, opts ...g
 	return out, nil
 }
 
+func (c *catalogClient) BatchGetAnime(ctx context.Context, in *BatchGetAnimeRequest, opts ...grpc.CallOption) (*BatchGetAnimeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetAnimeResponse)
+	err := c.cc.Invoke(ctx, Catalog_BatchGetAnime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) SearchStream(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SearchStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Catalog_ServiceDesc.Streams[0], Catalog_SearchStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SearchRequest, SearchStreamResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Catalog_SearchStreamClient = grpc.ServerStreamingClient[SearchStreamResponse]
+
+func (c *catalogClient) SubscribeNotifications(ctx context.Context, in *SubscribeNotificationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Catalog_ServiceDesc.Streams[1], Catalog_SubscribeNotifications_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeNotificationsRequest, Notification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Catalog_SubscribeNotificationsClient = grpc.ServerStreamingClient[Notification]
+
+func (c *catalogClient) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationsResponse)
+	err := c.cc.Invoke(ctx, Catalog_ListNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) MarkNotificationsRead(ctx context.Context, in *MarkNotificationsReadRequest, opts ...grpc.CallOption) (*MarkNotificationsReadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkNotificationsReadResponse)
+	err := c.cc.Invoke(ctx, Catalog_MarkNotificationsRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*GetScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetScheduleResponse)
+	err := c.cc.Invoke(ctx, Catalog_GetSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) ResolveAnime(ctx context.Context, in *ResolveAnimeRequest, opts ...grpc.CallOption) (*ResolveAnimeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveAnimeResponse)
+	err := c.cc.Invoke(ctx, Catalog_ResolveAnime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) GetRelated(ctx context.Context, in *GetRelatedRequest, opts ...grpc.CallOption) (*GetRelatedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedResponse)
+	err := c.cc.Invoke(ctx, Catalog_GetRelated_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) Recommend(ctx context.Context, in *RecommendRequest, opts ...grpc.CallOption) (*RecommendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendResponse)
+	err := c.cc.Invoke(ctx, Catalog_Recommend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) ListTrending(ctx context.Context, in *ListTrendingRequest, opts ...grpc.CallOption) (*ListTitlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTitlesResponse)
+	err := c.cc.Invoke(ctx, Catalog_ListTrending_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) ListTopRated(ctx context.Context, in *ListTopRatedRequest, opts ...grpc.CallOption) (*ListTitlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTitlesResponse)
+	err := c.cc.Invoke(ctx, Catalog_ListTopRated_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) ListRecentlyUpdated(ctx context.Context, in *ListRecentlyUpdatedRequest, opts ...grpc.CallOption) (*ListTitlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTitlesResponse)
+	err := c.cc.Invoke(ctx, Catalog_ListRecentlyUpdated_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) ListGenres(ctx context.Context, in *ListGenresRequest, opts ...grpc.CallOption) (*ListGenresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGenresResponse)
+	err := c.cc.Invoke(ctx, Catalog_ListGenres_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) BrowseByGenre(ctx context.Context, in *BrowseByGenreRequest, opts ...grpc.CallOption) (*ListTitlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTitlesResponse)
+	err := c.cc.Invoke(ctx, Catalog_BrowseByGenre_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) SetMergeOverride(ctx context.Context, in *MergeOverride, opts ...grpc.CallOption) (*MergeOverride, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeOverride)
+	err := c.cc.Invoke(ctx, Catalog_SetMergeOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) ListMergeOverrides(ctx context.Context, in *ListMergeOverridesRequest, opts ...grpc.CallOption) (*ListMergeOverridesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMergeOverridesResponse)
+	err := c.cc.Invoke(ctx, Catalog_ListMergeOverrides_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogClient) DeleteMergeOverride(ctx context.Context, in *DeleteMergeOverrideRequest, opts ...grpc.CallOption) (*DeleteMergeOverrideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMergeOverrideResponse)
+	err := c.cc.Invoke(ctx, Catalog_DeleteMergeOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CatalogServer is the server API for Catalog service.
 // All implementations must embed UnimplementedCatalogServer
 // for forward compatibility.
@@ -67,6 +294,28 @@ type CatalogServer interface {
 	// unary RPCs for simple needs
 	GetAnime(context.Context, *GetAnimeRequest) (*Anime, error)
 	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	BatchGetAnime(context.Context, *BatchGetAnimeRequest) (*BatchGetAnimeResponse, error)
+	// SearchStream emits local index hits immediately, then Kodik hits that
+	// are new or add information.
+	SearchStream(*SearchRequest, grpc.ServerStreamingServer[SearchStreamResponse]) error
+	// SubscribeNotifications replays unread notifications, then streams new
+	// ones as the notifier detects them.
+	SubscribeNotifications(*SubscribeNotificationsRequest, grpc.ServerStreamingServer[Notification]) error
+	ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error)
+	MarkNotificationsRead(context.Context, *MarkNotificationsReadRequest) (*MarkNotificationsReadResponse, error)
+	GetSchedule(context.Context, *GetScheduleRequest) (*GetScheduleResponse, error)
+	ResolveAnime(context.Context, *ResolveAnimeRequest) (*ResolveAnimeResponse, error)
+	GetRelated(context.Context, *GetRelatedRequest) (*GetRelatedResponse, error)
+	Recommend(context.Context, *RecommendRequest) (*RecommendResponse, error)
+	ListTrending(context.Context, *ListTrendingRequest) (*ListTitlesResponse, error)
+	ListTopRated(context.Context, *ListTopRatedRequest) (*ListTitlesResponse, error)
+	ListRecentlyUpdated(context.Context, *ListRecentlyUpdatedRequest) (*ListTitlesResponse, error)
+	ListGenres(context.Context, *ListGenresRequest) (*ListGenresResponse, error)
+	BrowseByGenre(context.Context, *BrowseByGenreRequest) (*ListTitlesResponse, error)
+	// admin: manual merge/split decisions for the merge engine
+	SetMergeOverride(context.Context, *MergeOverride) (*MergeOverride, error)
+	ListMergeOverrides(context.Context, *ListMergeOverridesRequest) (*ListMergeOverridesResponse, error)
+	DeleteMergeOverride(context.Context, *DeleteMergeOverrideRequest) (*DeleteMergeOverrideResponse, error)
 	mustEmbedUnimplementedCatalogServer()
 }
 
@@ -83,6 +332,57 @@ func (UnimplementedCatalogServer) GetAnime(context.Context, *GetAnimeRequest) (*
 func (UnimplementedCatalogServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedCatalogServer) BatchGetAnime(context.Context, *BatchGetAnimeRequest) (*BatchGetAnimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetAnime not implemented")
+}
+func (UnimplementedCatalogServer) SearchStream(*SearchRequest, grpc.ServerStreamingServer[SearchStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SearchStream not implemented")
+}
+func (UnimplementedCatalogServer) SubscribeNotifications(*SubscribeNotificationsRequest, grpc.ServerStreamingServer[Notification]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeNotifications not implemented")
+}
+func (UnimplementedCatalogServer) ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotifications not implemented")
+}
+func (UnimplementedCatalogServer) MarkNotificationsRead(context.Context, *MarkNotificationsReadRequest) (*MarkNotificationsReadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkNotificationsRead not implemented")
+}
+func (UnimplementedCatalogServer) GetSchedule(context.Context, *GetScheduleRequest) (*GetScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedule not implemented")
+}
+func (UnimplementedCatalogServer) ResolveAnime(context.Context, *ResolveAnimeRequest) (*ResolveAnimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveAnime not implemented")
+}
+func (UnimplementedCatalogServer) GetRelated(context.Context, *GetRelatedRequest) (*GetRelatedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelated not implemented")
+}
+func (UnimplementedCatalogServer) Recommend(context.Context, *RecommendRequest) (*RecommendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recommend not implemented")
+}
+func (UnimplementedCatalogServer) ListTrending(context.Context, *ListTrendingRequest) (*ListTitlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrending not implemented")
+}
+func (UnimplementedCatalogServer) ListTopRated(context.Context, *ListTopRatedRequest) (*ListTitlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTopRated not implemented")
+}
+func (UnimplementedCatalogServer) ListRecentlyUpdated(context.Context, *ListRecentlyUpdatedRequest) (*ListTitlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecentlyUpdated not implemented")
+}
+func (UnimplementedCatalogServer) ListGenres(context.Context, *ListGenresRequest) (*ListGenresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGenres not implemented")
+}
+func (UnimplementedCatalogServer) BrowseByGenre(context.Context, *BrowseByGenreRequest) (*ListTitlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BrowseByGenre not implemented")
+}
+func (UnimplementedCatalogServer) SetMergeOverride(context.Context, *MergeOverride) (*MergeOverride, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMergeOverride not implemented")
+}
+func (UnimplementedCatalogServer) ListMergeOverrides(context.Context, *ListMergeOverridesRequest) (*ListMergeOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMergeOverrides not implemented")
+}
+func (UnimplementedCatalogServer) DeleteMergeOverride(context.Context, *DeleteMergeOverrideRequest) (*DeleteMergeOverrideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMergeOverride not implemented")
+}
 func (UnimplementedCatalogServer) mustEmbedUnimplementedCatalogServer() {}
 func (UnimplementedCatalogServer) testEmbeddedByValue()                 {}
 
@@ -140,6 +440,298 @@ func _Catalog_Search_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Catalog_BatchGetAnime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetAnimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).BatchGetAnime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_BatchGetAnime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).BatchGetAnime(ctx, req.(*BatchGetAnimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_SearchStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServer).SearchStream(m, &grpc.GenericServerStream[SearchRequest, SearchStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Catalog_SearchStreamServer = grpc.ServerStreamingServer[SearchStreamResponse]
+
+func _Catalog_SubscribeNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServer).SubscribeNotifications(m, &grpc.GenericServerStream[SubscribeNotificationsRequest, Notification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Catalog_SubscribeNotificationsServer = grpc.ServerStreamingServer[Notification]
+
+func _Catalog_ListNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).ListNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_ListNotifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).ListNotifications(ctx, req.(*ListNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_MarkNotificationsRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkNotificationsReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).MarkNotificationsRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_MarkNotificationsRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).MarkNotificationsRead(ctx, req.(*MarkNotificationsReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetSchedule(ctx, req.(*GetScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_ResolveAnime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveAnimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).ResolveAnime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_ResolveAnime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).ResolveAnime(ctx, req.(*ResolveAnimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_GetRelated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).GetRelated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_GetRelated_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).GetRelated(ctx, req.(*GetRelatedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_Recommend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).Recommend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_Recommend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).Recommend(ctx, req.(*RecommendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_ListTrending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).ListTrending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_ListTrending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).ListTrending(ctx, req.(*ListTrendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_ListTopRated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopRatedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).ListTopRated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_ListTopRated_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).ListTopRated(ctx, req.(*ListTopRatedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_ListRecentlyUpdated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecentlyUpdatedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).ListRecentlyUpdated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_ListRecentlyUpdated_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).ListRecentlyUpdated(ctx, req.(*ListRecentlyUpdatedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_ListGenres_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGenresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).ListGenres(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_ListGenres_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).ListGenres(ctx, req.(*ListGenresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_BrowseByGenre_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BrowseByGenreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).BrowseByGenre(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_BrowseByGenre_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).BrowseByGenre(ctx, req.(*BrowseByGenreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_SetMergeOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeOverride)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).SetMergeOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_SetMergeOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).SetMergeOverride(ctx, req.(*MergeOverride))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_ListMergeOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMergeOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).ListMergeOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_ListMergeOverrides_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).ListMergeOverrides(ctx, req.(*ListMergeOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalog_DeleteMergeOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMergeOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServer).DeleteMergeOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalog_DeleteMergeOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServer).DeleteMergeOverride(ctx, req.(*DeleteMergeOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Catalog_ServiceDesc is the grpc.ServiceDesc for Catalog service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -155,7 +747,78 @@ var Catalog_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Search",
 			Handler:    _Catalog_Search_Handler,
 		},
+		{
+			MethodName: "BatchGetAnime",
+			Handler:    _Catalog_BatchGetAnime_Handler,
+		},
+		{
+			MethodName: "ListNotifications",
+			Handler:    _Catalog_ListNotifications_Handler,
+		},
+		{
+			MethodName: "MarkNotificationsRead",
+			Handler:    _Catalog_MarkNotificationsRead_Handler,
+		},
+		{
+			MethodName: "GetSchedule",
+			Handler:    _Catalog_GetSchedule_Handler,
+		},
+		{
+			MethodName: "ResolveAnime",
+			Handler:    _Catalog_ResolveAnime_Handler,
+		},
+		{
+			MethodName: "GetRelated",
+			Handler:    _Catalog_GetRelated_Handler,
+		},
+		{
+			MethodName: "Recommend",
+			Handler:    _Catalog_Recommend_Handler,
+		},
+		{
+			MethodName: "ListTrending",
+			Handler:    _Catalog_ListTrending_Handler,
+		},
+		{
+			MethodName: "ListTopRated",
+			Handler:    _Catalog_ListTopRated_Handler,
+		},
+		{
+			MethodName: "ListRecentlyUpdated",
+			Handler:    _Catalog_ListRecentlyUpdated_Handler,
+		},
+		{
+			MethodName: "ListGenres",
+			Handler:    _Catalog_ListGenres_Handler,
+		},
+		{
+			MethodName: "BrowseByGenre",
+			Handler:    _Catalog_BrowseByGenre_Handler,
+		},
+		{
+			MethodName: "SetMergeOverride",
+			Handler:    _Catalog_SetMergeOverride_Handler,
+		},
+		{
+			MethodName: "ListMergeOverrides",
+			Handler:    _Catalog_ListMergeOverrides_Handler,
+		},
+		{
+			MethodName: "DeleteMergeOverride",
+			Handler:    _Catalog_DeleteMergeOverride_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SearchStream",
+			Handler:       _Catalog_SearchStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeNotifications",
+			Handler:       _Catalog_SubscribeNotifications_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "catalog.proto",
 }
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// TTL is a size-bounded map whose entries expire after a fixed duration.
+type TTL[K comparable, V any] struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	max   int
+	items map[K]entry[V]
+}
+
+func NewTTL[K comparable, V any](ttl time.Duration, max int) *TTL[K, V] {
+	return &TTL[K, V]{ttl: ttl, max: max, items: make(map[K]entry[V])}
+}
+
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[key]
+	if !ok || time.Now().After(e.expires) {
+		delete(c.items, key)
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *TTL[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.items) >= c.max {
+		c.evict()
+	}
+	c.items[key] = entry[V]{value: value, expires: time.Now().Add(c.ttl)}
+}
+
+// evict drops expired entries, and if that is not enough, arbitrary ones
+// until there is room for one more.
+func (c *TTL[K, V]) evict() {
+	now := time.Now()
+	for k, e := range c.items {
+		if now.After(e.expires) {
+			delete(c.items, k)
+		}
+	}
+	for k := range c.items {
+		if len(c.items) < c.max {
+			break
+		}
+		delete(c.items, k)
+	}
+}
+
+// Clear drops every entry.
+func (c *TTL[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.items)
+}
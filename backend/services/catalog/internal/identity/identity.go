@@ -0,0 +1,270 @@
+// Package identity assigns stable AniFlow IDs to canonical title groups.
+//
+// Kodik material IDs come and go: a release can be re-issued under a new ID,
+// and grouping keys change when Kodik learns an external ID. An identity
+// remembers every key and material ID it ever covered, so it survives such
+// churn. When observations show that two identities are the same title they
+// are merged, the younger one redirecting to the older; when materials of one
+// identity turn up under another identity's key they move there (a split).
+package identity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+type EventKind string
+
+const (
+	EventCreated EventKind = "created"
+	EventMerged  EventKind = "merged"
+	EventSplit   EventKind = "split"
+	EventRekeyed EventKind = "rekeyed"
+)
+
+// Event is one entry of an identity's history. Other names the identity on
+// the other side of a merge or split.
+type Event struct {
+	At       time.Time `json:"at"`
+	Kind     EventKind `json:"kind"`
+	Other    string    `json:"other,omitempty"`
+	Key      string    `json:"key,omitempty"`
+	KodikIDs []string  `json:"kodik_ids,omitempty"`
+}
+
+type Record struct {
+	ID       string   `json:"id"`
+	Keys     []string `json:"keys"`
+	KodikIDs []string `json:"kodik_ids"`
+	// MergedInto is set once this identity was folded into another one;
+	// lookups follow it transparently.
+	MergedInto string    `json:"merged_into,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	History    []Event   `json:"history"`
+}
+
+// Table is the identity store, optionally backed by a JSON file.
+type Table struct {
+	mu      sync.Mutex
+	path    string
+	dirty   bool
+	records map[string]*Record
+	byKey   map[string]string
+	byKodik map[string]string
+}
+
+func New() *Table {
+	return &Table{
+		records: make(map[string]*Record),
+		byKey:   make(map[string]string),
+		byKodik: make(map[string]string),
+	}
+}
+
+func Open(path string) (*Table, error) {
+	t := New()
+	t.path = path
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var recs []*Record
+	if err := json.Unmarshal(b, &recs); err != nil {
+		return nil, err
+	}
+	for _, r := range recs {
+		t.records[r.ID] = r
+		if r.MergedInto != "" {
+			continue
+		}
+		for _, k := range r.Keys {
+			t.byKey[k] = r.ID
+		}
+		for _, id := range r.KodikIDs {
+			t.byKodik[id] = r.ID
+		}
+	}
+	return t, nil
+}
+
+func newID() string {
+	b := make([]byte, 6)
+	rand.Read(b)
+	return "af_" + hex.EncodeToString(b)
+}
+
+// live follows merge redirects; callers hold mu.
+func (t *Table) live(id string) *Record {
+	r := t.records[id]
+	for r != nil && r.MergedInto != "" {
+		r = t.records[r.MergedInto]
+	}
+	return r
+}
+
+// Assign records that key currently groups kodikIDs and returns the AniFlow
+// ID of that group, creating, merging or splitting identities as needed.
+func (t *Table) Assign(key string, kodikIDs []string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now().UTC()
+
+	target := t.live(t.byKey[key])
+	owners := make(map[string]*Record)
+	for _, kid := range kodikIDs {
+		if r := t.live(t.byKodik[kid]); r != nil && r != target {
+			owners[r.ID] = r
+		}
+	}
+
+	if target == nil {
+		switch len(owners) {
+		case 0:
+			target = &Record{ID: newID(), CreatedAt: now}
+			target.History = append(target.History, Event{At: now, Kind: EventCreated, Key: key})
+			t.records[target.ID] = target
+		default:
+			// The oldest identity wins; the rest are folded into it below.
+			for _, r := range owners {
+				if target == nil || r.CreatedAt.Before(target.CreatedAt) ||
+					(r.CreatedAt.Equal(target.CreatedAt) && r.ID < target.ID) {
+					target = r
+				}
+			}
+			delete(owners, target.ID)
+			target.History = append(target.History, Event{At: now, Kind: EventRekeyed, Key: key})
+		}
+		target.Keys = append(target.Keys, key)
+		t.byKey[key] = target.ID
+		t.dirty = true
+	}
+
+	observed := make(map[string]bool, len(kodikIDs))
+	for _, kid := range kodikIDs {
+		observed[kid] = true
+	}
+	owned := make(map[string]bool, len(target.KodikIDs))
+	for _, kid := range target.KodikIDs {
+		owned[kid] = true
+	}
+
+	ids := make([]string, 0, len(owners))
+	for id := range owners {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		other := owners[id]
+		var moved, kept []string
+		for _, kid := range other.KodikIDs {
+			if observed[kid] {
+				moved = append(moved, kid)
+			} else {
+				kept = append(kept, kid)
+			}
+		}
+		if len(kept) == 0 {
+			t.merge(other, target, now)
+			for _, kid := range other.KodikIDs {
+				owned[kid] = true
+			}
+			continue
+		}
+		other.KodikIDs = kept
+		other.History = append(other.History, Event{At: now, Kind: EventSplit, Other: target.ID, KodikIDs: moved})
+		target.History = append(target.History, Event{At: now, Kind: EventSplit, Other: other.ID, KodikIDs: moved})
+		t.dirty = true
+	}
+
+	for _, kid := range kodikIDs {
+		if !owned[kid] {
+			target.KodikIDs = append(target.KodikIDs, kid)
+			owned[kid] = true
+			t.dirty = true
+		}
+		t.byKodik[kid] = target.ID
+	}
+	return target.ID
+}
+
+// merge folds from into into; callers hold mu.
+func (t *Table) merge(from, into *Record, now time.Time) {
+	for _, k := range from.Keys {
+		if !slices.Contains(into.Keys, k) {
+			into.Keys = append(into.Keys, k)
+		}
+		t.byKey[k] = into.ID
+	}
+	for _, kid := range from.KodikIDs {
+		if !slices.Contains(into.KodikIDs, kid) {
+			into.KodikIDs = append(into.KodikIDs, kid)
+		}
+		t.byKodik[kid] = into.ID
+	}
+	from.MergedInto = into.ID
+	from.History = append(from.History, Event{At: now, Kind: EventMerged, Other: into.ID})
+	into.History = append(into.History, Event{At: now, Kind: EventMerged, Other: from.ID, KodikIDs: from.KodikIDs})
+	t.dirty = true
+}
+
+// Get returns the live record for an AniFlow ID, following merges.
+func (t *Table) Get(id string) (Record, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r := t.live(id)
+	if r == nil {
+		return Record{}, false
+	}
+	out := *r
+	out.Keys = slices.Clone(r.Keys)
+	out.KodikIDs = slices.Clone(r.KodikIDs)
+	out.History = slices.Clone(r.History)
+	return out, true
+}
+
+// ForKodik returns the AniFlow ID currently owning a Kodik material.
+func (t *Table) ForKodik(kodikID string) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r := t.live(t.byKodik[kodikID])
+	if r == nil {
+		return "", false
+	}
+	return r.ID, true
+}
+
+// Flush writes the table to its file if it changed.
+func (t *Table) Flush() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.path == "" || !t.dirty {
+		return nil
+	}
+	recs := make([]*Record, 0, len(t.records))
+	for _, r := range t.records {
+		recs = append(recs, r)
+	}
+	sort.Slice(recs, func(i, j int) bool { return recs[i].ID < recs[j].ID })
+	b, err := json.Marshal(recs)
+	if err != nil {
+		return err
+	}
+	tmp := t.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, t.path); err != nil {
+		return err
+	}
+	t.dirty = false
+	return nil
+}
@@ -0,0 +1,244 @@
+package identity
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// step assigns key to kodikIDs and expects the identity named want; steps
+// with the same want must get the same AniFlow ID, different ones a
+// different ID.
+type step struct {
+	key      string
+	kodikIDs []string
+	want     string
+}
+
+// assign runs steps against tab and returns the AniFlow ID of each name.
+// Identities are backdated in the order they appear so "oldest" does not
+// depend on the clock.
+func assign(tb testing.TB, tab *Table, steps []step) map[string]string {
+	tb.Helper()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	names := make(map[string]string)
+	for i, s := range steps {
+		id := tab.Assign(s.key, s.kodikIDs)
+		want, ok := names[s.want]
+		if !ok {
+			for name, other := range names {
+				if other == id {
+					tb.Fatalf("step %d: %s got %s's ID", i, s.want, name)
+				}
+			}
+			names[s.want] = id
+			tab.records[id].CreatedAt = base.Add(time.Duration(len(names)) * time.Hour)
+			continue
+		}
+		if id != want {
+			tb.Fatalf("step %d: %s assigned %s, want %s", i, s.key, id, want)
+		}
+	}
+	return names
+}
+
+func kinds(r Record) []EventKind {
+	var out []EventKind
+	for _, e := range r.History {
+		out = append(out, e.Kind)
+	}
+	return out
+}
+
+func TestAssign(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		steps []step
+		check func(t *testing.T, tab *Table, ids map[string]string)
+	}{
+		{
+			name:  "new key",
+			steps: []step{{"sh:1", []string{"serial-1"}, "a"}},
+			check: func(t *testing.T, tab *Table, ids map[string]string) {
+				r, _ := tab.Get(ids["a"])
+				if !slices.Equal(r.Keys, []string{"sh:1"}) || !slices.Equal(r.KodikIDs, []string{"serial-1"}) {
+					t.Errorf("record = %+v", r)
+				}
+				if !slices.Equal(kinds(r), []EventKind{EventCreated}) {
+					t.Errorf("history = %v", kinds(r))
+				}
+			},
+		},
+		{
+			name: "same key",
+			steps: []step{
+				{"sh:1", []string{"serial-1"}, "a"},
+				{"sh:1", []string{"serial-1"}, "a"},
+				{"sh:1", []string{"serial-1", "serial-2"}, "a"},
+			},
+			check: func(t *testing.T, tab *Table, ids map[string]string) {
+				r, _ := tab.Get(ids["a"])
+				if !slices.Equal(r.KodikIDs, []string{"serial-1", "serial-2"}) || len(r.History) != 1 {
+					t.Errorf("record = %+v", r)
+				}
+			},
+		},
+		{
+			name: "distinct keys",
+			steps: []step{
+				{"sh:1", []string{"serial-1"}, "a"},
+				{"sh:2", []string{"serial-2"}, "b"},
+			},
+		},
+		{
+			name: "rekey",
+			steps: []step{
+				{"ttl:frieren|2023", []string{"serial-1", "serial-2"}, "a"},
+				{"sh:52991", []string{"serial-1", "serial-2"}, "a"},
+			},
+			check: func(t *testing.T, tab *Table, ids map[string]string) {
+				r, _ := tab.Get(ids["a"])
+				if !slices.Equal(r.Keys, []string{"ttl:frieren|2023", "sh:52991"}) {
+					t.Errorf("keys = %v", r.Keys)
+				}
+				if !slices.Equal(kinds(r), []EventKind{EventCreated, EventRekeyed}) {
+					t.Errorf("history = %v", kinds(r))
+				}
+			},
+		},
+		{
+			name: "rekey to the oldest owner",
+			steps: []step{
+				{"sh:1", []string{"serial-1"}, "a"},
+				{"sh:2", []string{"serial-2"}, "b"},
+				{"kp:9", []string{"serial-2", "serial-1"}, "a"},
+			},
+			check: func(t *testing.T, tab *Table, ids map[string]string) {
+				b, ok := tab.Get(ids["b"])
+				if !ok || b.ID != ids["a"] {
+					t.Fatalf("Get(b) = %s, want a", b.ID)
+				}
+				if !slices.Equal(b.Keys, []string{"sh:1", "kp:9", "sh:2"}) {
+					t.Errorf("keys = %v", b.Keys)
+				}
+				if got, _ := tab.ForKodik("serial-2"); got != ids["a"] {
+					t.Errorf("serial-2 owned by %s", got)
+				}
+				if r := tab.records[ids["b"]]; r.MergedInto != ids["a"] || !slices.Equal(kinds(*r), []EventKind{EventCreated, EventMerged}) {
+					t.Errorf("merged record = %+v", r)
+				}
+			},
+		},
+		{
+			name: "merge into the existing key",
+			steps: []step{
+				{"sh:1", []string{"serial-1"}, "a"},
+				{"sh:2", []string{"serial-2"}, "b"},
+				{"sh:2", []string{"serial-2", "serial-1"}, "b"},
+			},
+			check: func(t *testing.T, tab *Table, ids map[string]string) {
+				// Folding follows the key, not age: a has nothing left.
+				a, _ := tab.Get(ids["a"])
+				if a.ID != ids["b"] || !slices.Equal(a.KodikIDs, []string{"serial-2", "serial-1"}) {
+					t.Errorf("Get(a) = %+v", a)
+				}
+				if got := tab.Assign("sh:1", []string{"serial-1"}); got != ids["b"] {
+					t.Errorf("old key assigned %s", got)
+				}
+			},
+		},
+		{
+			name: "split",
+			steps: []step{
+				{"sh:1", []string{"serial-1", "serial-2"}, "a"},
+				{"sh:2", []string{"serial-3"}, "b"},
+				{"sh:2", []string{"serial-2", "serial-3"}, "b"},
+			},
+			check: func(t *testing.T, tab *Table, ids map[string]string) {
+				a, _ := tab.Get(ids["a"])
+				b, _ := tab.Get(ids["b"])
+				if a.ID != ids["a"] || !slices.Equal(a.KodikIDs, []string{"serial-1"}) {
+					t.Errorf("a = %+v", a)
+				}
+				if !slices.Equal(b.KodikIDs, []string{"serial-3", "serial-2"}) {
+					t.Errorf("b owns %v", b.KodikIDs)
+				}
+				for _, side := range []struct {
+					r     Record
+					other string
+				}{{a, ids["b"]}, {b, ids["a"]}} {
+					e := side.r.History[len(side.r.History)-1]
+					if e.Kind != EventSplit || e.Other != side.other || !slices.Equal(e.KodikIDs, []string{"serial-2"}) {
+						t.Errorf("%s history ends with %+v", side.r.ID, e)
+					}
+				}
+				if got, _ := tab.ForKodik("serial-2"); got != ids["b"] {
+					t.Errorf("serial-2 owned by %s", got)
+				}
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tab := New()
+			ids := assign(t, tab, tc.steps)
+			if tc.check != nil {
+				tc.check(t, tab, ids)
+			}
+		})
+	}
+
+	if _, ok := New().Get("af_missing"); ok {
+		t.Error("Get found an unknown ID")
+	}
+}
+
+func TestOpenFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identities.json")
+	tab, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := assign(t, tab, []step{
+		{"sh:1", []string{"serial-1"}, "a"},
+		{"sh:2", []string{"serial-2"}, "b"},
+		{"kp:9", []string{"serial-1", "serial-2"}, "a"},
+		{"sh:3", []string{"serial-3", "serial-4"}, "c"},
+		{"sh:4", []string{"serial-5"}, "d"},
+		{"sh:4", []string{"serial-4", "serial-5"}, "d"},
+	})
+	if err := tab.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if tab.dirty {
+		t.Error("still dirty after Flush")
+	}
+	tab.Assign("sh:1", []string{"serial-1"})
+	if tab.dirty {
+		t.Error("an unchanged observation dirtied the table")
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{"a": "a", "b": "a", "c": "c", "d": "d"} {
+		got, ok := reopened.Get(ids[name])
+		if !ok || got.ID != ids[want] {
+			t.Errorf("Get(%s) = %s, want %s", name, got.ID, want)
+		}
+	}
+	for kodikID, want := range map[string]string{"serial-2": "a", "serial-3": "c", "serial-4": "d"} {
+		if got, _ := reopened.ForKodik(kodikID); got != ids[want] {
+			t.Errorf("%s owned by %s, want %s", kodikID, got, want)
+		}
+	}
+	// The merged identity's key still leads to the survivor.
+	if got := reopened.Assign("sh:2", []string{"serial-2"}); got != ids["a"] {
+		t.Errorf("sh:2 assigned %s after reopening", got)
+	}
+	c, _ := reopened.Get(ids["c"])
+	if !slices.Equal(kinds(c), []EventKind{EventCreated, EventSplit}) {
+		t.Errorf("c history = %v", kinds(c))
+	}
+}
@@ -0,0 +1,197 @@
+package index
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+)
+
+// Index is a local copy of every Kodik material the catalog has seen, so
+// searches can be answered before Kodik responds. It is optionally backed
+// by a JSON file.
+type Index struct {
+	mu        sync.RWMutex
+	path      string
+	dirty     bool
+	materials map[string]kodik.Material
+}
+
+type snapshot struct {
+	Materials []kodik.Material `json:"materials"`
+}
+
+func New() *Index {
+	return &Index{materials: make(map[string]kodik.Material)}
+}
+
+// Open loads the index from path. A missing file yields an empty index
+// that will be written there on Flush.
+func Open(path string) (*Index, error) {
+	idx := New()
+	idx.path = path
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snap snapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		return nil, err
+	}
+	for _, m := range snap.Materials {
+		idx.materials[m.ID] = m
+	}
+	return idx, nil
+}
+
+// Put adds or replaces materials by Kodik ID.
+func (x *Index) Put(ms ...kodik.Material) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	for _, m := range ms {
+		if m.ID == "" {
+			continue
+		}
+		m.Raw = nil
+		x.materials[m.ID] = m
+		x.dirty = true
+	}
+}
+
+func (x *Index) Get(id string) (kodik.Material, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	m, ok := x.materials[id]
+	return m, ok
+}
+
+// Range calls fn for every material while holding the read lock, so fn
+// must not call back into the index.
+func (x *Index) Range(fn func(kodik.Material)) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	for _, m := range x.materials {
+		fn(m)
+	}
+}
+
+// Select returns every material for which keep reports true.
+func (x *Index) Select(keep func(kodik.Material) bool) []kodik.Material {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	var out []kodik.Material
+	for _, m := range x.materials {
+		if keep(m) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// Search returns up to limit materials whose titles contain every word of
+// query. Exact title matches come first, then prefix matches.
+func (x *Index) Search(query string, limit int) []kodik.Material {
+	q := normalize(query)
+	if q == "" {
+		return nil
+	}
+	words := strings.Fields(q)
+
+	type hit struct {
+		m    kodik.Material
+		rank int
+	}
+	var hits []hit
+
+	x.mu.RLock()
+	for _, m := range x.materials {
+		titles := []string{normalize(m.Title), normalize(m.TitleOrig), normalize(m.OtherTitle)}
+		all := strings.Join(titles, " ")
+		matched := true
+		for _, w := range words {
+			if !strings.Contains(all, w) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		rank := 2
+		for _, t := range titles {
+			if t == q {
+				rank = 0
+				break
+			}
+			if strings.HasPrefix(t, q) {
+				rank = 1
+			}
+		}
+		hits = append(hits, hit{m: m, rank: rank})
+	}
+	x.mu.RUnlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].rank != hits[j].rank {
+			return hits[i].rank < hits[j].rank
+		}
+		return hits[i].m.ID < hits[j].m.ID
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	out := make([]kodik.Material, len(hits))
+	for i, h := range hits {
+		out[i] = h.m
+	}
+	return out
+}
+
+// Flush writes the index to its file if it changed since the last flush.
+func (x *Index) Flush() error {
+	x.mu.Lock()
+	if x.path == "" || !x.dirty {
+		x.mu.Unlock()
+		return nil
+	}
+	snap := snapshot{Materials: make([]kodik.Material, 0, len(x.materials))}
+	for _, m := range x.materials {
+		snap.Materials = append(snap.Materials, m)
+	}
+	x.dirty = false
+	path := x.path
+	x.mu.Unlock()
+
+	sort.Slice(snap.Materials, func(i, j int) bool {
+		return snap.Materials[i].ID < snap.Materials[j].ID
+	})
+	if err := writeFile(path, snap); err != nil {
+		x.mu.Lock()
+		x.dirty = true
+		x.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func writeFile(path string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
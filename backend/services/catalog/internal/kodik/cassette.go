@@ -0,0 +1,167 @@
+package kodik
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CassetteMode selects what a cassette does with Kodik traffic.
+type CassetteMode string
+
+const (
+	// CassetteRecord passes requests through and saves every exchange.
+	CassetteRecord CassetteMode = "record"
+	// CassetteReplay answers from saved exchanges and never touches the
+	// network; requests without a recording fail.
+	CassetteReplay CassetteMode = "replay"
+)
+
+// WithCassette records Kodik exchanges to dir or replays them from it.
+// Recordings never contain the API token, so a directory of them can be
+// attached to a bug report. Requests are matched on method, path and query
+// without the token, whatever mirror they were sent to; recording the same
+// request again replaces the earlier answer.
+func WithCassette(dir string, mode CassetteMode) Option {
+	return func(c *Client) {
+		c.cassetteDir = dir
+		c.cassetteMode = mode
+	}
+}
+
+// exchange is one recorded request and its response. Bodies that are JSON,
+// as Kodik's always are, are kept as JSON so cassettes stay readable.
+type exchange struct {
+	Method     string          `json:"method"`
+	Path       string          `json:"path"`
+	Query      string          `json:"query"`
+	Status     int             `json:"status"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	BodyText   string          `json:"body_text,omitempty"`
+	RecordedAt time.Time       `json:"recorded_at"`
+}
+
+// cassetteKey returns the query of req without the token and the file its
+// exchange is kept in, e.g. "list-3fa85f6457174562.json".
+func cassetteKey(req *http.Request) (query, file string) {
+	q := req.URL.Query()
+	q.Del("token")
+	query = q.Encode()
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.Path + "?" + query))
+	name := strings.ReplaceAll(strings.Trim(req.URL.Path, "/"), "/", "_")
+	if name == "" {
+		name = "root"
+	}
+	return query, name + "-" + hex.EncodeToString(sum[:8]) + ".json"
+}
+
+type cassetteRecorder struct {
+	dir  string
+	base http.RoundTripper
+	mu   sync.Mutex
+}
+
+func (r *cassetteRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	saved := body
+	// Kodik repeats the token in next_page and prev_page links.
+	if token := req.URL.Query().Get("token"); token != "" {
+		saved = bytes.ReplaceAll(saved, []byte(token), []byte("REDACTED"))
+	}
+	query, file := cassetteKey(req)
+	ex := exchange{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Query:      query,
+		Status:     resp.StatusCode,
+		RecordedAt: time.Now().UTC(),
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		ex.Header = http.Header{"Content-Type": {ct}}
+	}
+	if json.Valid(saved) {
+		ex.Body = saved
+	} else {
+		ex.BodyText = string(saved)
+	}
+	if err := r.save(file, ex); err != nil {
+		return nil, fmt.Errorf("kodik cassette: %w", err)
+	}
+	return resp, nil
+}
+
+func (r *cassetteRecorder) save(file string, ex exchange) error {
+	b, err := json.MarshalIndent(ex, "", "  ")
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(r.dir, file)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+type cassettePlayer struct {
+	dir string
+}
+
+func (p cassettePlayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	query, file := cassetteKey(req)
+	b, err := os.ReadFile(filepath.Join(p.dir, file))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("kodik cassette: no recording of %s %s?%s in %s", req.Method, req.URL.Path, query, p.dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("kodik cassette: %w", err)
+	}
+	var ex exchange
+	if err := json.Unmarshal(b, &ex); err != nil {
+		return nil, fmt.Errorf("kodik cassette %s: %w", file, err)
+	}
+	body := []byte(ex.Body)
+	if len(body) == 0 {
+		body = []byte(ex.BodyText)
+	}
+	header := ex.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", ex.Status, http.StatusText(ex.Status)),
+		StatusCode:    ex.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package kodik_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik/kodiktest"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	dir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	srv := kodiktest.NewServer()
+	srv.HideFromList("serial-60114")
+	rec := kodik.NewClient(kodiktest.Token,
+		kodik.WithBaseURL(srv.URL),
+		kodik.WithCassette(dir, kodik.CassetteRecord),
+		kodik.WithLogger(logger),
+	)
+	want, err := rec.FetchByID(ctx, "serial-60114", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, err := rec.List(ctx, kodik.ListOptions{Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 {
+		t.Errorf("recorded %d exchanges, want 3", len(files))
+	}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(b), kodiktest.Token) {
+			t.Errorf("%s contains the token", filepath.Base(f))
+		}
+	}
+
+	// Replay needs neither the server nor the token.
+	play := kodik.NewClient("",
+		kodik.WithBaseURL(srv.URL),
+		kodik.WithCassette(dir, kodik.CassetteReplay),
+		kodik.WithLogger(logger),
+	)
+	got, err := play.FetchByID(ctx, "serial-60114", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != want.ID || got.Title != want.Title || got.EpisodesAired != want.EpisodesAired {
+		t.Errorf("replayed %+v, want %+v", got, want)
+	}
+	again, err := play.List(ctx, kodik.ListOptions{Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kodik.NextCursor(again) != kodik.NextCursor(page) || len(again.Results) != len(page.Results) {
+		t.Errorf("replayed page differs from the recorded one")
+	}
+
+	_, err = play.Search(ctx, "never recorded", 5, false)
+	if err == nil || !strings.Contains(err.Error(), "no recording") {
+		t.Errorf("err = %v, want a missing recording", err)
+	}
+}
@@ -3,18 +3,92 @@ package kodik
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync/atomic"
 	"time"
 	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-const baseURL = "https://kodikapi.com/list"
+// DefaultBaseURL is the public Kodik API.
+const DefaultBaseURL = "https://kodikapi.com"
 
 type Client struct {
 	token  string
 	client *http.Client
+	log    *slog.Logger
+
+	// bases are the API mirrors in order of preference; current indexes
+	// the one that answered last, where the next request starts.
+	bases   []string
+	current atomic.Int32
+
+	transport http.RoundTripper
+	proxy     *url.URL
+	timeout   time.Duration
+	userAgent string
+
+	cassetteDir  string
+	cassetteMode CassetteMode
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL points the client at another API host, e.g.
+// "https://kodikapi.com". Further URLs are mirrors: when one fails to
+// answer or answers with a 5xx, the request is retried on the next, which
+// then serves later requests too. Trailing slashes are ignored.
+func WithBaseURL(urls ...string) Option {
+	return func(c *Client) {
+		c.bases = c.bases[:0]
+		for _, u := range urls {
+			if u = strings.TrimRight(u, "/"); u != "" {
+				c.bases = append(c.bases, u)
+			}
+		}
+		if len(c.bases) == 0 {
+			c.bases = append(c.bases, DefaultBaseURL)
+		}
+	}
+}
+
+// WithTransport sends requests through rt instead of
+// http.DefaultTransport. Requests are still traced.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) { c.transport = rt }
+}
+
+// WithProxy sends requests through the HTTP(S) proxy at u rather than the
+// one named by HTTPS_PROXY. It needs the transport to be an
+// *http.Transport, which it is unless WithTransport says otherwise.
+func WithProxy(u *url.URL) Option {
+	return func(c *Client) { c.proxy = u }
+}
+
+// WithTimeout bounds each HTTP request, reading the body included. A
+// request failing over to a mirror gets a fresh timeout there.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.timeout = d }
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// WithLogger sets the logger for request and lookup details. Tokens in
+// URLs are never logged.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) { c.log = l }
 }
 
 type Translation struct {
@@ -33,12 +107,26 @@ type Material struct {
 	Description    string                 `json:"description"`
 	Year           int                    `json:"year"`
 	EpisodesCount  int                    `json:"episodes_count"`
+	LastEpisode    int                    `json:"last_episode"`
+	EpisodesAired  int                    `json:"episodes_aired"`
+	EpisodesTotal  int                    `json:"episodes_total"`
+	AnimeStatus    string                 `json:"anime_status"`
+	NextEpisodeAt  time.Time              `json:"next_episode_at"`
 	PosterURL      string                 `json:"poster_url"`
 	Image          string                 `json:"image"`
 	AnimePosterURL string                 `json:"anime_poster_url"` 
 	Genres         []string               `json:"genres"`
+	AnimeGenres    []string               `json:"anime_genres,omitempty"`
+	Studios        []string               `json:"studios,omitempty"`
 	KinopoiskID    string                 `json:"kinopoisk_id"`
+	ShikimoriID    string                 `json:"shikimori_id"`
+	IMDbID         string                 `json:"imdb_id"`
+	WorldArtLink   string                 `json:"worldart_link"`
 	KinopoiskRating float64               `json:"kinopoisk_rating"`
+	KinopoiskVotes int                    `json:"kinopoisk_votes,omitempty"`
+	ShikimoriRating float64               `json:"shikimori_rating,omitempty"`
+	ShikimoriVotes int                    `json:"shikimori_votes,omitempty"`
+	UpdatedAt      time.Time              `json:"updated_at,omitzero"`
 	Translation    *Translation           `json:"translation"`
 	Raw            map[string]interface{} `json:"-"`
 }
@@ -51,6 +139,19 @@ type ListResponse struct {
 	Results  []Material `json:"results"`
 }
 
+func toInt(v interface{}) int {
+	switch t := v.(type) {
+	case float64:
+		return int(t)
+	case int:
+		return t
+	case string:
+		n, _ := strconv.Atoi(t)
+		return n
+	}
+	return 0
+}
+
 func toStr(v interface{}) string {
 	if v == nil {
 		return ""
@@ -67,159 +168,69 @@ func toStr(v interface{}) string {
 	}
 }
 
-func NewClient(token string) *Client {
-	return &Client{
-		token: token,
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-	}
-}
-
-
-func (c *Client) FetchPage(ctx context.Context, limit int, next string, types string, withEpisodes bool, withMaterialData bool) (*ListResponse, error) {
-	u, _ := url.Parse(baseURL)
-	q := u.Query()
-	q.Set("token", c.token)
-	if limit <= 0 || limit > 100 {
-		limit = 50
-	}
-	q.Set("limit", fmt.Sprintf("%d", limit))
-	if next != "" {
-		q.Set("next", next)
-	}
-	if types != "" {
-		q.Set("types", types)
-	}
-	if withEpisodes {
-		q.Set("with_episodes", "true")
-	}
-	if withMaterialData {
-		q.Set("with_material_data", "true")
-	}
-	u.RawQuery = q.Encode()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var lr ListResponse
-	dec := json.NewDecoder(resp.Body)
-	if err := dec.Decode(&lr); err != nil {
-		return nil, err
-	}
-
-	for i := range lr.Results {
-		if lr.Results[i].PosterURL == "" && lr.Results[i].Image != "" {
-			lr.Results[i].PosterURL = lr.Results[i].Image
+func NewClient(token string, opts ...Option) *Client {
+	c := &Client{
+		token:     token,
+		log:       slog.Default(),
+		bases:     []string{DefaultBaseURL},
+		transport: http.DefaultTransport,
+		timeout:   10 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	rt := c.transport
+	if c.proxy != nil {
+		if t, ok := rt.(*http.Transport); ok {
+			t = t.Clone()
+			t.Proxy = http.ProxyURL(c.proxy)
+			rt = t
+		} else {
+			c.log.Warn("kodik proxy ignored: transport is not an *http.Transport", "transport", fmt.Sprintf("%T", rt))
 		}
 	}
-	return &lr, nil
-}
-
-func (c *Client) FetchByID(ctx context.Context, id string, withMaterialData bool) (*Material, error) {
-	doRequest := func(u string) ([]map[string]interface{}, error) {
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
-		if err != nil {
-			return nil, err
-		}
-		resp, err := c.client.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-
-		var raw map[string]interface{}
-		dec := json.NewDecoder(resp.Body)
-		if err := dec.Decode(&raw); err != nil {
-			return nil, err
-		}
-		resI, _ := raw["results"].([]interface{})
-		out := make([]map[string]interface{}, 0, len(resI))
-		for _, ri := range resI {
-			if ri == nil {
-				continue
-			}
-			if im, ok := ri.(map[string]interface{}); ok {
-				out = append(out, im)
-			}
-		}
-		return out, nil
+	switch c.cassetteMode {
+	case "":
+	case CassetteRecord:
+		rt = &cassetteRecorder{dir: c.cassetteDir, base: rt}
+	case CassetteReplay:
+		rt = cassettePlayer{dir: c.cassetteDir}
+	default:
+		c.log.Warn("unknown kodik cassette mode ignored", "mode", c.cassetteMode)
 	}
-
-	u1, _ := url.Parse(baseURL)
-	q1 := u1.Query()
-	q1.Set("token", c.token)
-	q1.Set("id", id)
-	q1.Set("limit", "50")
-	if withMaterialData {
-		q1.Set("with_material_data", "true")
+	c.client = &http.Client{
+		Timeout:   c.timeout,
+		Transport: tracingTransport{base: rt},
 	}
-	u1.RawQuery = q1.Encode()
-	fmt.Printf("[kodik] FetchByID try /list URL=%s\n", u1.String())
+	return c
+}
 
-	results, err := doRequest(u1.String())
-	if err != nil {
-		return nil, err
+func toFloat(v interface{}) float64 {
+	switch t := v.(type) {
+	case float64:
+		return t
+	case string:
+		f, _ := strconv.ParseFloat(t, 64)
+		return f
 	}
+	return 0
+}
 
-	var found map[string]interface{}
-	for _, im := range results {
-		if toStr(im["id"]) == id {
-			found = im
-			break
-		}
-	}
-
-	if found == nil {
-		u2, _ := url.Parse("https://kodikapi.com/search")
-		q2 := u2.Query()
-		q2.Set("token", c.token)
-		q2.Set("id", id)
-		q2.Set("limit", "50")
-		if withMaterialData {
-			q2.Set("with_material_data", "true")
-		}
-		u2.RawQuery = q2.Encode()
-		fmt.Printf("[kodik] FetchByID fallback /search URL=%s\n", u2.String())
-
-		results2, err2 := doRequest(u2.String())
-		if err2 != nil {
-			return nil, fmt.Errorf("fetch by id failed (list not found, search fetch error: %v)", err2)
-		}
-		for _, im := range results2 {
-			if toStr(im["id"]) == id {
-				found = im
-				break
-			}
-		}
-		if found == nil {
-			firstIDs := make([]string, 0, 6)
-			for i, im := range results {
-				if i >= 5 {
-					break
-				}
-				firstIDs = append(firstIDs, toStr(im["id"]))
-			}
-			for i, im := range results2 {
-				if i >= 5 {
-					break
-				}
-				firstIDs = append(firstIDs, toStr(im["id"]))
-			}
-			return nil, fmt.Errorf("material with id %s not found in results (examples: %v)", id, firstIDs)
+func toStrings(v interface{}) []string {
+	arr, _ := v.([]interface{})
+	var out []string
+	for _, x := range arr {
+		if s := toStr(x); s != "" {
+			out = append(out, s)
 		}
 	}
+	return out
+}
 
-	itemMap := found
-	m := &Material{Raw: itemMap}
+// parseMaterial builds a Material from one raw Kodik result, reading both
+// top-level fields and material_data.
+func parseMaterial(itemMap map[string]interface{}) Material {
+	m := Material{Raw: itemMap}
 	m.ID = toStr(itemMap["id"])
 	m.Type = toStr(itemMap["type"])
 	m.Link = toStr(itemMap["link"])
@@ -227,22 +238,9 @@ func (c *Client) FetchByID(ctx context.Context, id string, withMaterialData bool
 	m.TitleOrig = toStr(itemMap["title_orig"])
 	m.OtherTitle = toStr(itemMap["other_title"])
 	m.Description = toStr(itemMap["description"])
-	if yv, ok := itemMap["year"]; ok {
-		switch t := yv.(type) {
-		case float64:
-			m.Year = int(t)
-		case int:
-			m.Year = t
-		}
-	}
-	if ev, ok := itemMap["episodes_count"]; ok {
-		switch t := ev.(type) {
-		case float64:
-			m.EpisodesCount = int(t)
-		case int:
-			m.EpisodesCount = t
-		}
-	}
+	m.Year = toInt(itemMap["year"])
+	m.EpisodesCount = toInt(itemMap["episodes_count"])
+	m.LastEpisode = toInt(itemMap["last_episode"])
 	if v := itemMap["poster_url"]; v != nil && toStr(v) != "" {
 		m.PosterURL = toStr(v)
 	} else if v := itemMap["image"]; v != nil {
@@ -258,6 +256,14 @@ func (c *Client) FetchByID(ctx context.Context, id string, withMaterialData bool
 	if kp := itemMap["kinopoisk_id"]; kp != nil {
 		m.KinopoiskID = toStr(kp)
 	}
+	if sh := itemMap["shikimori_id"]; sh != nil {
+		m.ShikimoriID = toStr(sh)
+	}
+	m.IMDbID = toStr(itemMap["imdb_id"])
+	m.WorldArtLink = toStr(itemMap["worldart_link"])
+	if t, err := time.Parse(time.RFC3339, toStr(itemMap["updated_at"])); err == nil {
+		m.UpdatedAt = t
+	}
 	if md, ok := itemMap["material_data"].(map[string]interface{}); ok {
 		if p := md["poster_url"]; p != nil && toStr(p) != "" {
 			m.AnimePosterURL = toStr(p)
@@ -279,19 +285,24 @@ func (c *Client) FetchByID(ctx context.Context, id string, withMaterialData bool
 				}
 			}
 		}
+		m.AnimeGenres = toStrings(md["anime_genres"])
+		if len(m.Genres) == 0 {
+			m.Genres = m.AnimeGenres
+		}
+		m.Studios = toStrings(md["anime_studios"])
+		m.KinopoiskVotes = toInt(md["kinopoisk_votes"])
+		m.ShikimoriRating = toFloat(md["shikimori_rating"])
+		m.ShikimoriVotes = toInt(md["shikimori_votes"])
+		m.AnimeStatus = toStr(md["anime_status"])
+		m.EpisodesAired = toInt(md["episodes_aired"])
+		m.EpisodesTotal = toInt(md["episodes_total"])
+		if t, err := time.Parse(time.RFC3339, toStr(md["next_episode_at"])); err == nil {
+			m.NextEpisodeAt = t
+		}
 	}
 	if tr, ok := itemMap["translation"].(map[string]interface{}); ok {
-		idF := 0
-		if idv := tr["id"]; idv != nil {
-			switch t := idv.(type) {
-			case float64:
-				idF = int(t)
-			case int:
-				idF = t
-			}
-		}
 		m.Translation = &Translation{
-			ID:    idF,
+			ID:    toInt(tr["id"]),
 			Title: toStr(tr["title"]),
 			Type:  toStr(tr["type"]),
 		}
@@ -299,122 +310,301 @@ func (c *Client) FetchByID(ctx context.Context, id string, withMaterialData bool
 	if m.PosterURL == "" && m.AnimePosterURL != "" {
 		m.PosterURL = m.AnimePosterURL
 	}
+	return m
+}
 
-	fmt.Printf("[kodik] FetchByID returning id=%s title=%s\n", m.ID, m.Title)
-	return m, nil
+// apiError is the error field of a Kodik answer.
+type apiError string
+
+func (e apiError) Error() string { return string(e) }
+
+func decodeList(r io.Reader) (*ListResponse, error) {
+	var raw map[string]interface{}
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	if msg := toStr(raw["error"]); msg != "" {
+		return nil, apiError(msg)
+	}
+	lr := &ListResponse{Results: make([]Material, 0)}
+	lr.Time = toStr(raw["time"])
+	lr.Total = toInt(raw["total"])
+	if v, ok := raw["next_page"].(string); ok && v != "" {
+		lr.NextPage = &v
+	}
+	if v, ok := raw["prev_page"].(string); ok && v != "" {
+		lr.PrevPage = &v
+	}
+	resultsI, _ := raw["results"].([]interface{})
+	for _, ri := range resultsI {
+		if itemMap, ok := ri.(map[string]interface{}); ok {
+			lr.Results = append(lr.Results, parseMaterial(itemMap))
+		}
+	}
+	return lr, nil
 }
 
-func (c *Client) Search(ctx context.Context, title string, limit int, withMaterialData bool) (*ListResponse, error) {
-	u, _ := url.Parse("https://kodikapi.com/search")
-	q := u.Query()
+// do sends a GET for endpoint with q and the token, starting at the
+// current mirror and moving on to the next while they fail or answer with
+// a 5xx. The last mirror's answer is returned whatever it is.
+func (c *Client) do(ctx context.Context, endpoint string, q url.Values) (*http.Response, *url.URL, error) {
 	q.Set("token", c.token)
+	first := int(c.current.Load())
+	for i := range c.bases {
+		n := (first + i) % len(c.bases)
+		u, err := url.Parse(c.bases[n] + endpoint)
+		if err != nil {
+			return nil, nil, err
+		}
+		u.RawQuery = q.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if c.userAgent != "" {
+			req.Header.Set("User-Agent", c.userAgent)
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			redactError(err, req.URL)
+		}
+		last := i == len(c.bases)-1 || ctx.Err() != nil
+		if err == nil && (resp.StatusCode < 500 || last) {
+			if n != first {
+				c.current.CompareAndSwap(int32(first), int32(n))
+			}
+			return resp, req.URL, nil
+		}
+		if last {
+			return nil, req.URL, err
+		}
+		if err == nil {
+			resp.Body.Close()
+			err = fmt.Errorf("%s", resp.Status)
+		}
+		mirrorFailovers.Inc()
+		c.log.WarnContext(ctx, "kodik mirror failed, trying next",
+			"mirror", c.bases[n], "next", c.bases[(n+1)%len(c.bases)], "endpoint", endpoint, "error", err)
+	}
+	return nil, nil, fmt.Errorf("no Kodik base URL")
+}
+
+func (c *Client) get(ctx context.Context, endpoint string, q url.Values) (*ListResponse, error) {
+	start := time.Now()
+	defer func() {
+		upstreamDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+	}()
+	resp, u, err := c.do(ctx, endpoint, q)
+	if err != nil {
+		upstreamRequests.WithLabelValues(endpoint, "error").Inc()
+		c.log.WarnContext(ctx, "kodik request failed", "endpoint", endpoint, "error", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		upstreamRequests.WithLabelValues(endpoint, strconv.Itoa(resp.StatusCode)).Inc()
+		var body struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		err := fmt.Errorf("kodik %s: %s", endpoint, strings.TrimSpace(resp.Status+" "+body.Error))
+		c.log.WarnContext(ctx, "kodik request rejected", "endpoint", endpoint, "status", resp.StatusCode, "error", body.Error)
+		return nil, err
+	}
+	lr, err := decodeList(resp.Body)
+	var rejected apiError
+	if errors.As(err, &rejected) {
+		// Kodik reports some failures with a 200 and an error field.
+		upstreamRequests.WithLabelValues(endpoint, "rejected").Inc()
+		c.log.WarnContext(ctx, "kodik request rejected", "endpoint", endpoint, "status", resp.StatusCode, "error", string(rejected))
+		return nil, fmt.Errorf("kodik %s: %s", endpoint, rejected)
+	}
+	if err != nil {
+		upstreamRequests.WithLabelValues(endpoint, "decode_error").Inc()
+		c.log.WarnContext(ctx, "kodik response undecodable", "endpoint", endpoint, "status", resp.StatusCode, "error", err)
+		return nil, err
+	}
+	upstreamRequests.WithLabelValues(endpoint, strconv.Itoa(resp.StatusCode)).Inc()
+	c.log.DebugContext(ctx, "kodik request",
+		"endpoint", endpoint,
+		"host", u.Host,
+		"query", redactedQuery(u),
+		"status", resp.StatusCode,
+		"results", len(lr.Results),
+		"duration", time.Since(start),
+	)
+	return lr, nil
+}
+
+// ListOptions are the /list parameters the catalog uses. Next is the cursor
+// taken from a previous page, see NextCursor.
+type ListOptions struct {
+	Limit            int
+	Next             string
+	Types            string
+	AnimeStatus      string
+	Sort             string
+	Order            string
+	WithEpisodes     bool
+	WithMaterialData bool
+}
+
+func (c *Client) List(ctx context.Context, opts ListOptions) (*ListResponse, error) {
+	q := url.Values{}
+	limit := opts.Limit
 	if limit <= 0 || limit > 100 {
-		limit = 20
+		limit = 50
 	}
 	q.Set("limit", fmt.Sprintf("%d", limit))
-	if title != "" {
-		q.Set("title", title)
+	if opts.Next != "" {
+		q.Set("next", opts.Next)
 	}
-	if withMaterialData {
+	if opts.Types != "" {
+		q.Set("types", opts.Types)
+	}
+	if opts.AnimeStatus != "" {
+		q.Set("anime_status", opts.AnimeStatus)
+	}
+	if opts.Sort != "" {
+		q.Set("sort", opts.Sort)
+	}
+	if opts.Order != "" {
+		q.Set("order", opts.Order)
+	}
+	if opts.WithEpisodes {
+		q.Set("with_episodes", "true")
+	}
+	if opts.WithMaterialData {
 		q.Set("with_material_data", "true")
 	}
-	u.RawQuery = q.Encode()
+	return c.get(ctx, "/list", q)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+// NextCursor extracts the cursor for ListOptions.Next from lr.NextPage, or
+// returns "" on the last page.
+func NextCursor(lr *ListResponse) string {
+	if lr == nil || lr.NextPage == nil {
+		return ""
+	}
+	u, err := url.Parse(*lr.NextPage)
 	if err != nil {
-		return nil, err
+		return ""
+	}
+	return u.Query().Get("next")
+}
+
+func (c *Client) FetchPage(ctx context.Context, limit int, next string, types string, withEpisodes bool, withMaterialData bool) (*ListResponse, error) {
+	return c.List(ctx, ListOptions{
+		Limit:            limit,
+		Next:             next,
+		Types:            types,
+		WithEpisodes:     withEpisodes,
+		WithMaterialData: withMaterialData,
+	})
+}
+
+func (c *Client) FetchByID(ctx context.Context, id string, withMaterialData bool) (*Material, error) {
+	ctx, span := tracer.Start(ctx, "kodik.FetchByID", trace.WithAttributes(attribute.String("kodik.id", id)))
+	defer span.End()
+
+	q1 := url.Values{}
+	q1.Set("id", id)
+	q1.Set("limit", "50")
+	if withMaterialData {
+		q1.Set("with_material_data", "true")
 	}
-	resp, err := c.client.Do(req)
+	c.log.DebugContext(ctx, "fetch by id", "id", id, "endpoint", "/list")
+
+	lr1, err := c.get(ctx, "/list", q1)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var raw map[string]interface{}
-	dec := json.NewDecoder(resp.Body)
-	if err := dec.Decode(&raw); err != nil {
-		return nil, err
+	var found *Material
+	for i := range lr1.Results {
+		if lr1.Results[i].ID == id {
+			found = &lr1.Results[i]
+			break
+		}
 	}
-	resultsI, _ := raw["results"].([]interface{})
-	lr := &ListResponse{Results: make([]Material, 0)}
-	for _, ri := range resultsI {
-		if ri == nil {
-			continue
+
+	if found == nil {
+		fetchByIDFallbacks.Inc()
+		span.AddEvent("fallback to /search")
+		q2 := url.Values{}
+		q2.Set("id", id)
+		q2.Set("limit", "50")
+		if withMaterialData {
+			q2.Set("with_material_data", "true")
 		}
-		if itemMap, ok := ri.(map[string]interface{}); ok {
-			m := Material{Raw: itemMap}
-			m.ID = toStr(itemMap["id"])
-			m.Title = toStr(itemMap["title"])
-			m.Description = toStr(itemMap["description"])
-			if v := itemMap["poster_url"]; v != nil && toStr(v) != "" {
-				m.PosterURL = toStr(v)
-			} else if v := itemMap["image"]; v != nil {
-				m.PosterURL = toStr(v)
-			}
-			if g, ok := itemMap["genres"]; ok && g != nil {
-				if arr, ok := g.([]interface{}); ok {
-					for _, gi := range arr {
-						m.Genres = append(m.Genres, toStr(gi))
-					}
-				}
-			}
-			if kp := itemMap["kinopoisk_id"]; kp != nil {
-				m.KinopoiskID = toStr(kp)
+		c.log.DebugContext(ctx, "fetch by id fallback", "id", id, "endpoint", "/search")
+
+		lr2, err2 := c.get(ctx, "/search", q2)
+		if err2 != nil {
+			return nil, fmt.Errorf("fetch by id failed (list not found, search fetch error: %v)", err2)
+		}
+		for i := range lr2.Results {
+			if lr2.Results[i].ID == id {
+				found = &lr2.Results[i]
+				break
 			}
-			if md, ok := itemMap["material_data"].(map[string]interface{}); ok {
-				if p := md["poster_url"]; p != nil && toStr(p) != "" {
-					m.AnimePosterURL = toStr(p)
-				}
-				if r := md["kinopoisk_rating"]; r != nil {
-					switch t := r.(type) {
-					case float64:
-						m.KinopoiskRating = t
-					case string:
-						if v, err := strconv.ParseFloat(t, 64); err == nil {
-							m.KinopoiskRating = v
-						}
-					}
-				}
-				if g2 := md["genres"]; g2 != nil {
-					if arr, ok := g2.([]interface{}); ok && len(m.Genres) == 0 {
-						for _, gi := range arr {
-							m.Genres = append(m.Genres, toStr(gi))
-						}
-					}
+		}
+		if found == nil {
+			firstIDs := make([]string, 0, 6)
+			for i, m := range lr1.Results {
+				if i >= 5 {
+					break
 				}
+				firstIDs = append(firstIDs, m.ID)
 			}
-			if tr, ok := itemMap["translation"].(map[string]interface{}); ok {
-				idF := 0
-				if idv := tr["id"]; idv != nil {
-					switch t := idv.(type) {
-					case float64:
-						idF = int(t)
-					case int:
-						idF = t
-					}
-				}
-				m.Translation = &Translation{
-					ID:    idF,
-					Title: toStr(tr["title"]),
-					Type:  toStr(tr["type"]),
+			for i, m := range lr2.Results {
+				if i >= 5 {
+					break
 				}
+				firstIDs = append(firstIDs, m.ID)
 			}
-			lr.Results = append(lr.Results, m)
+			return nil, fmt.Errorf("material with id %s not found in results (examples: %v)", id, firstIDs)
 		}
 	}
-	if t, ok := raw["total"]; ok {
-		if tf, ok := t.(float64); ok {
-			lr.Total = int(tf)
-		}
+
+	c.log.DebugContext(ctx, "fetch by id found", "id", found.ID, "title", found.Title)
+	return found, nil
+}
+
+func (c *Client) Search(ctx context.Context, title string, limit int, withMaterialData bool) (*ListResponse, error) {
+	q := url.Values{}
+	if limit <= 0 || limit > 100 {
+		limit = 20
 	}
-	return lr, nil
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	if title != "" {
+		q.Set("title", title)
+	}
+	if withMaterialData {
+		q.Set("with_material_data", "true")
+	}
+	return c.get(ctx, "/search", q)
 }
 
+// External ID parameters understood by /search. Shikimori reuses
+// MyAnimeList IDs, so a MAL ID is looked up with ByShikimoriID.
+const (
+	ByKinopoiskID  = "kinopoisk_id"
+	ByShikimoriID  = "shikimori_id"
+	ByIMDbID       = "imdb_id"
+	ByWorldArtLink = "worldart_link"
+)
+
 func (c *Client) SearchByKinopoiskID(ctx context.Context, kinopoiskID string, limit int, withMaterialData bool) (*ListResponse, error) {
-	u, _ := url.Parse("https://kodikapi.com/search")
-	q := u.Query()
-	q.Set("token", c.token)
-	q.Set("kinopoisk_id", kinopoiskID)
+	return c.SearchByExternalID(ctx, ByKinopoiskID, kinopoiskID, limit, withMaterialData)
+}
+
+// SearchByExternalID returns all materials whose param (one of the By*
+// constants) equals value.
+func (c *Client) SearchByExternalID(ctx context.Context, param, value string, limit int, withMaterialData bool) (*ListResponse, error) {
+	q := url.Values{}
+	q.Set(param, value)
 	if limit <= 0 || limit > 100 {
 		limit = 100
 	}
@@ -422,28 +612,27 @@ func (c *Client) SearchByKinopoiskID(ctx context.Context, kinopoiskID string, li
 	if withMaterialData {
 		q.Set("with_material_data", "true")
 	}
-	u.RawQuery = q.Encode()
+	return c.get(ctx, "/search", q)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.Do(req)
+// Ping checks that the API answers and accepts the token with the
+// smallest possible /list request.
+func (c *Client) Ping(ctx context.Context) error {
+	q := url.Values{}
+	q.Set("limit", "1")
+	resp, _, err := c.do(ctx, "/list", q)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
-
-	var lr ListResponse
-	dec := json.NewDecoder(resp.Body)
-	if err := dec.Decode(&lr); err != nil {
-		return nil, err
+	var body struct {
+		Error string `json:"error"`
 	}
-	for i := range lr.Results {
-		if lr.Results[i].PosterURL == "" && lr.Results[i].Image != "" {
-			lr.Results[i].PosterURL = lr.Results[i].Image
-		}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("kodik ping: %s: %w", resp.Status, err)
 	}
-	return &lr, nil
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		return fmt.Errorf("kodik ping: %s %s", resp.Status, body.Error)
+	}
+	return nil
 }
-
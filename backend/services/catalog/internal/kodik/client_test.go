@@ -0,0 +1,260 @@
+package kodik_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik/kodiktest"
+)
+
+func newClient(t *testing.T, urls ...string) *kodik.Client {
+	t.Helper()
+	return kodik.NewClient(kodiktest.Token,
+		kodik.WithBaseURL(urls...),
+		kodik.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	)
+}
+
+func TestFetchByIDFromList(t *testing.T) {
+	srv := kodiktest.NewServer()
+	defer srv.Close()
+
+	m, err := newClient(t, srv.URL).FetchByID(context.Background(), "serial-45213", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ID != "serial-45213" || m.TitleOrig != "Chainsaw Man" || m.ShikimoriID != "44511" {
+		t.Errorf("got %s %q shikimori %s", m.ID, m.TitleOrig, m.ShikimoriID)
+	}
+	if m.AnimeStatus != "released" || len(m.Studios) != 1 || m.Studios[0] != "MAPPA" {
+		t.Errorf("material data not parsed: status %q studios %v", m.AnimeStatus, m.Studios)
+	}
+	if got := srv.Endpoints(); !slices.Equal(got, []string{"/list"}) {
+		t.Errorf("requests = %v, want only /list", got)
+	}
+}
+
+func TestFetchByIDFallsBackToSearch(t *testing.T) {
+	srv := kodiktest.NewServer()
+	defer srv.Close()
+	srv.HideFromList("serial-60114")
+
+	m, err := newClient(t, srv.URL).FetchByID(context.Background(), "serial-60114", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ID != "serial-60114" || m.EpisodesAired != 9 {
+		t.Errorf("got %s with %d aired episodes", m.ID, m.EpisodesAired)
+	}
+	if got := srv.Endpoints(); !slices.Equal(got, []string{"/list", "/search"}) {
+		t.Fatalf("requests = %v, want /list then /search", got)
+	}
+	q := srv.Requests()[1].Query
+	if q.Get("id") != "serial-60114" || q.Get("with_material_data") != "true" {
+		t.Errorf("search query = %v", q)
+	}
+}
+
+func TestFetchByIDNotFound(t *testing.T) {
+	srv := kodiktest.NewServer()
+	defer srv.Close()
+
+	_, err := newClient(t, srv.URL).FetchByID(context.Background(), "serial-1", false)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("err = %v, want not found", err)
+	}
+	if got := srv.Endpoints(); !slices.Equal(got, []string{"/list", "/search"}) {
+		t.Errorf("requests = %v, want /list then /search", got)
+	}
+}
+
+func TestFetchByIDSearchFails(t *testing.T) {
+	srv := kodiktest.NewServer()
+	defer srv.Close()
+	srv.HideFromList("serial-60114")
+	srv.Fail("/search", http.StatusInternalServerError, 1)
+
+	c := newClient(t, srv.URL)
+	_, err := c.FetchByID(context.Background(), "serial-60114", false)
+	if err == nil || !strings.Contains(err.Error(), "search fetch error") || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("err = %v, want the /search failure", err)
+	}
+	// The failure was injected once; the next lookup goes through.
+	if _, err := c.FetchByID(context.Background(), "serial-60114", false); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFetchByIDListFails(t *testing.T) {
+	srv := kodiktest.NewServer()
+	defer srv.Close()
+	srv.Fail("/list", http.StatusBadGateway, -1)
+
+	_, err := newClient(t, srv.URL).FetchByID(context.Background(), "serial-45213", false)
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("err = %v, want 502", err)
+	}
+	if got := srv.Endpoints(); !slices.Equal(got, []string{"/list"}) {
+		t.Errorf("requests = %v, want no fallback after a failed /list", got)
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	srv := kodiktest.NewServer()
+	defer srv.Close()
+	c := newClient(t, srv.URL)
+
+	var ids []string
+	next := ""
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("pagination does not end")
+		}
+		lr, err := c.List(context.Background(), kodik.ListOptions{Limit: 5, Next: next})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lr.Total != len(kodiktest.Fixtures()) {
+			t.Errorf("total = %d", lr.Total)
+		}
+		for _, m := range lr.Results {
+			ids = append(ids, m.ID)
+		}
+		if next = kodik.NextCursor(lr); next == "" {
+			break
+		}
+	}
+	if len(ids) != len(kodiktest.Fixtures()) {
+		t.Fatalf("listed %d materials, want %d: %v", len(ids), len(kodiktest.Fixtures()), ids)
+	}
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if seen[id] {
+			t.Errorf("%s listed twice", id)
+		}
+		seen[id] = true
+	}
+	// Newest first, like Kodik's default order.
+	if ids[0] != "movie-58210" {
+		t.Errorf("first = %s, want the most recently updated material", ids[0])
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	srv := kodiktest.NewServer()
+	defer srv.Close()
+
+	lr, err := newClient(t, srv.URL).List(context.Background(), kodik.ListOptions{
+		Types:            "anime",
+		WithMaterialData: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range lr.Results {
+		if m.Type != "anime" {
+			t.Errorf("%s has type %s", m.ID, m.Type)
+		}
+	}
+	if len(lr.Results) != 3 {
+		t.Errorf("got %d movies, want 3", len(lr.Results))
+	}
+}
+
+func TestSearchByKinopoiskID(t *testing.T) {
+	srv := kodiktest.NewServer()
+	defer srv.Close()
+
+	// Kinopoisk lists Attack on Titan as one series; Kodik has a material
+	// per season.
+	lr, err := newClient(t, srv.URL).SearchByKinopoiskID(context.Background(), "749374", 10, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, m := range lr.Results {
+		ids = append(ids, m.ID)
+		if m.AnimeStatus != "" {
+			t.Errorf("%s has material data though it was not asked for", m.ID)
+		}
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, []string{"serial-19455", "serial-8821"}) {
+		t.Errorf("got %v", ids)
+	}
+}
+
+func TestSearchByTitle(t *testing.T) {
+	srv := kodiktest.NewServer()
+	defer srv.Close()
+
+	lr, err := newClient(t, srv.URL).Search(context.Background(), "frieren", 20, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lr.Results) != 3 {
+		t.Errorf("got %d results, want the 3 Frieren releases", len(lr.Results))
+	}
+}
+
+func TestBadTokenIsRedacted(t *testing.T) {
+	srv := kodiktest.NewServer()
+	defer srv.Close()
+
+	c := kodik.NewClient("secret-token",
+		kodik.WithBaseURL(srv.URL),
+		kodik.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	)
+	_, err := c.Search(context.Background(), "frieren", 5, false)
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("err = %v, want 403", err)
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("token leaked into %q", err)
+	}
+	if err := c.Ping(context.Background()); err == nil {
+		t.Error("Ping accepted a bad token")
+	}
+}
+
+func TestErrorWithOK(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"error":"Неверный тип"}`)
+	}))
+	defer srv.Close()
+
+	_, err := newClient(t, srv.URL).List(context.Background(), kodik.ListOptions{Types: "cartoon"})
+	if err == nil || !strings.Contains(err.Error(), "Неверный тип") {
+		t.Fatalf("err = %v, want the error Kodik answered with", err)
+	}
+}
+
+func TestMirrorFailover(t *testing.T) {
+	primary := kodiktest.NewServer()
+	defer primary.Close()
+	mirror := kodiktest.NewServer()
+	defer mirror.Close()
+	primary.Fail("/search", http.StatusServiceUnavailable, -1)
+
+	c := newClient(t, primary.URL, mirror.URL)
+	for range 2 {
+		if _, err := c.Search(context.Background(), "chainsaw", 5, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The mirror that answered keeps serving.
+	if n := len(primary.Requests()); n != 1 {
+		t.Errorf("primary got %d requests, want 1", n)
+	}
+	if n := len(mirror.Requests()); n != 2 {
+		t.Errorf("mirror got %d requests, want 2", n)
+	}
+}
@@ -0,0 +1,424 @@
+// Package kodiktest fakes the Kodik API for tests. It serves /list and
+// /search from recorded materials, paginates /list with next cursors the
+// way Kodik does and can be told to fail.
+//
+// Like Kodik, /list ignores the id parameter; only /search looks materials
+// up by ID or external ID. Materials hidden from /list are found by
+// /search alone, which is what kodik.Client.FetchByID falls back to.
+package kodiktest
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Token is the API token a Server started by NewServer expects.
+const Token = "kodiktest-token"
+
+//go:embed fixtures/materials.json
+var fixtures []byte
+
+// Fixtures returns the recorded materials, decoded afresh on every call so
+// tests may change them.
+func Fixtures() []map[string]any {
+	ms, err := decode(fixtures)
+	if err != nil {
+		panic("kodiktest: bad fixtures: " + err.Error())
+	}
+	return ms
+}
+
+// LoadFixtures reads the results of a Kodik /list or /search response
+// saved to path.
+func LoadFixtures(path string) ([]map[string]any, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ms, err := decode(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return ms, nil
+}
+
+func decode(b []byte) ([]map[string]any, error) {
+	var resp struct {
+		Results []map[string]any `json:"results"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&resp); err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// Request is a request the fake received, without its token.
+type Request struct {
+	Endpoint string
+	Query    url.Values
+}
+
+type failure struct {
+	status int
+	left   int
+}
+
+// Handler is the fake API as an http.Handler, for use with
+// httptest.NewServer or NewUnstartedServer.
+type Handler struct {
+	// Token is the token requests must carry; empty accepts any.
+	Token string
+
+	mu        sync.Mutex
+	materials []map[string]any
+	hidden    map[string]bool
+	failures  map[string]*failure
+	requests  []Request
+}
+
+func NewHandler(materials []map[string]any) *Handler {
+	return &Handler{
+		materials: materials,
+		hidden:    make(map[string]bool),
+		failures:  make(map[string]*failure),
+	}
+}
+
+// Add serves more materials, replacing those with the same ID.
+func (h *Handler) Add(ms ...map[string]any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, m := range ms {
+		replaced := false
+		for i, old := range h.materials {
+			if str(old["id"]) == str(m["id"]) {
+				h.materials[i] = m
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			h.materials = append(h.materials, m)
+		}
+	}
+}
+
+// HideFromList keeps materials out of /list; /search still finds them.
+func (h *Handler) HideFromList(ids ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, id := range ids {
+		h.hidden[id] = true
+	}
+}
+
+// Fail makes the next times requests to endpoint, e.g. "/search", answer
+// with status and a Kodik-style error body. A negative times fails every
+// request until Fail is called again with 0.
+func (h *Handler) Fail(endpoint string, status, times int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if times == 0 {
+		delete(h.failures, endpoint)
+		return
+	}
+	h.failures[endpoint] = &failure{status: status, left: times}
+}
+
+// Requests returns the requests received so far, oldest first.
+func (h *Handler) Requests() []Request {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Request(nil), h.requests...)
+}
+
+// Endpoints returns the endpoints of the requests received so far, e.g.
+// ["/list", "/search"].
+func (h *Handler) Endpoints() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]string, len(h.requests))
+	for i, r := range h.requests {
+		out[i] = r.Endpoint
+	}
+	return out
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	token := q.Get("token")
+	logged := make(url.Values, len(q))
+	for k, v := range q {
+		if k != "token" {
+			logged[k] = v
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.requests = append(h.requests, Request{Endpoint: r.URL.Path, Query: logged})
+
+	if f := h.failures[r.URL.Path]; f != nil {
+		if f.left > 0 {
+			f.left--
+			if f.left == 0 {
+				delete(h.failures, r.URL.Path)
+			}
+		}
+		writeError(w, f.status, http.StatusText(f.status))
+		return
+	}
+	if h.Token != "" && token != h.Token {
+		writeError(w, http.StatusForbidden, "Отсутствует или неверный токен")
+		return
+	}
+
+	switch r.URL.Path {
+	case "/list":
+		h.list(w, r, q)
+	case "/search":
+		h.search(w, q)
+	default:
+		writeError(w, http.StatusNotFound, "Неизвестный метод")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+type response struct {
+	Time     string           `json:"time"`
+	Total    int              `json:"total"`
+	PrevPage *string          `json:"prev_page"`
+	NextPage *string          `json:"next_page"`
+	Results  []map[string]any `json:"results"`
+}
+
+func writeResults(w http.ResponseWriter, resp response, q url.Values) {
+	if q.Get("with_material_data") != "true" {
+		for i, m := range resp.Results {
+			resp.Results[i] = without(m, "material_data")
+		}
+	}
+	if resp.Results == nil {
+		resp.Results = []map[string]any{}
+	}
+	resp.Time = "0ms"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request, q url.Values) {
+	limit, ok := limitParam(q, 50)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Неверный limit")
+		return
+	}
+	offset := 0
+	if next := q.Get("next"); next != "" {
+		b, err := base64.RawURLEncoding.DecodeString(next)
+		if err == nil {
+			offset, err = strconv.Atoi(string(b))
+		}
+		if err != nil || offset < 0 {
+			writeError(w, http.StatusBadRequest, "Неверный next")
+			return
+		}
+	}
+
+	var ms []map[string]any
+	for _, m := range h.materials {
+		if !h.hidden[str(m["id"])] && matchList(m, q) {
+			ms = append(ms, m)
+		}
+	}
+	sortMaterials(ms, q.Get("sort"), q.Get("order"))
+
+	resp := response{Total: len(ms)}
+	if offset < len(ms) {
+		resp.Results = ms[offset:min(offset+limit, len(ms))]
+	}
+	if offset+limit < len(ms) {
+		resp.NextPage = pageURL(r, q, offset+limit)
+	}
+	if offset > 0 {
+		resp.PrevPage = pageURL(r, q, max(offset-limit, 0))
+	}
+	writeResults(w, resp, q)
+}
+
+// searchParams are the /search parameters matched against a material's
+// field of the same name.
+var searchParams = []string{"id", "kinopoisk_id", "shikimori_id", "imdb_id", "worldart_link"}
+
+func (h *Handler) search(w http.ResponseWriter, q url.Values) {
+	limit, ok := limitParam(q, 50)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Неверный limit")
+		return
+	}
+	title := strings.ToLower(q.Get("title"))
+	given := title != ""
+	for _, p := range searchParams {
+		given = given || q.Get(p) != ""
+	}
+	if !given {
+		writeError(w, http.StatusBadRequest, "Не указан хотя бы один параметр для поиска")
+		return
+	}
+
+	var ms []map[string]any
+	for _, m := range h.materials {
+		if !matchTypes(m, q) {
+			continue
+		}
+		match := true
+		for _, p := range searchParams {
+			if v := q.Get(p); v != "" && str(m[p]) != v {
+				match = false
+			}
+		}
+		if title != "" && !strings.Contains(strings.ToLower(str(m["title"])), title) &&
+			!strings.Contains(strings.ToLower(str(m["title_orig"])), title) &&
+			!strings.Contains(strings.ToLower(str(m["other_title"])), title) {
+			match = false
+		}
+		if match {
+			ms = append(ms, m)
+		}
+	}
+	resp := response{Total: len(ms), Results: ms[:min(limit, len(ms))]}
+	writeResults(w, resp, q)
+}
+
+func limitParam(q url.Values, def int) (int, bool) {
+	v := q.Get("limit")
+	if v == "" {
+		return def, true
+	}
+	n, err := strconv.Atoi(v)
+	return n, err == nil && n > 0 && n <= 100
+}
+
+func matchTypes(m map[string]any, q url.Values) bool {
+	types := q.Get("types")
+	if types == "" {
+		return true
+	}
+	for _, t := range strings.Split(types, ",") {
+		if strings.TrimSpace(t) == str(m["type"]) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchList(m map[string]any, q url.Values) bool {
+	if !matchTypes(m, q) {
+		return false
+	}
+	if st := q.Get("anime_status"); st != "" {
+		md, _ := m["material_data"].(map[string]any)
+		if str(md["anime_status"]) != st {
+			return false
+		}
+	}
+	if y := q.Get("year"); y != "" && str(m["year"]) != y {
+		return false
+	}
+	return true
+}
+
+// sortMaterials orders ms like /list: by updated_at unless sort names
+// created_at or year, descending unless order is asc. Ties keep fixture
+// order.
+func sortMaterials(ms []map[string]any, by, order string) {
+	if by == "" {
+		by = "updated_at"
+	}
+	sort.SliceStable(ms, func(i, j int) bool {
+		a, b := str(ms[i][by]), str(ms[j][by])
+		if by == "year" {
+			x, _ := strconv.Atoi(a)
+			y, _ := strconv.Atoi(b)
+			a, b = fmt.Sprintf("%06d", x), fmt.Sprintf("%06d", y)
+		}
+		if order == "asc" {
+			return a < b
+		}
+		return a > b
+	})
+}
+
+// pageURL is the next_page or prev_page link for offset. Kodik returns
+// absolute URLs carrying every parameter of the request.
+func pageURL(r *http.Request, q url.Values, offset int) *string {
+	pq := make(url.Values, len(q))
+	for k, v := range q {
+		pq[k] = v
+	}
+	pq.Set("next", base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset))))
+	u := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawQuery: pq.Encode()}
+	if r.TLS != nil {
+		u.Scheme = "https"
+	}
+	s := u.String()
+	return &s
+}
+
+func without(m map[string]any, key string) map[string]any {
+	if _, ok := m[key]; !ok {
+		return m
+	}
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if k != key {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func str(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// Server is a running fake API.
+type Server struct {
+	*httptest.Server
+	*Handler
+}
+
+// NewServer starts a fake API serving materials, or the recorded fixtures
+// when there are none, and expecting Token. Close it when done.
+func NewServer(materials ...map[string]any) *Server {
+	if len(materials) == 0 {
+		materials = Fixtures()
+	}
+	h := NewHandler(materials)
+	h.Token = Token
+	return &Server{Server: httptest.NewServer(h), Handler: h}
+}
@@ -0,0 +1,26 @@
+package kodik
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	upstreamRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kodik_requests_total",
+		Help: "Requests to the Kodik API by endpoint and outcome: an HTTP status code, \"error\" for transport failures or \"decode_error\".",
+	}, []string{"endpoint", "outcome"})
+	upstreamDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kodik_request_duration_seconds",
+		Help:    "Kodik API latency by endpoint, including reading the body.",
+		Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"endpoint"})
+	mirrorFailovers = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "kodik_mirror_failovers_total",
+		Help: "Requests retried on the next Kodik API mirror after one failed or answered with a 5xx.",
+	})
+	fetchByIDFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "kodik_fetch_by_id_fallback_total",
+		Help: "FetchByID lookups /list missed that were retried on /search.",
+	})
+)
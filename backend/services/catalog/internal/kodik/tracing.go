@@ -0,0 +1,68 @@
+package kodik
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik")
+
+// redactedQuery returns the query of u without the API token, safe to put
+// on a span.
+func redactedQuery(u *url.URL) string {
+	q := u.Query()
+	if q.Has("token") {
+		q.Set("token", "REDACTED")
+	}
+	return q.Encode()
+}
+
+// redactError hides the token in a url.Error for u, which quotes the
+// whole URL.
+func redactError(err error, u *url.URL) {
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		ue.URL = u.Scheme + "://" + u.Host + u.Path + "?" + redactedQuery(u)
+	}
+}
+
+// tracingTransport starts a client span for every Kodik request and
+// propagates the trace context in its headers. It is used instead of
+// otelhttp so the token in the query string never reaches a span.
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+func (t tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracer.Start(req.Context(), "kodik "+req.URL.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+			attribute.String("url.query", redactedQuery(req.URL)),
+		),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
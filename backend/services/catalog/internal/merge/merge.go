@@ -0,0 +1,384 @@
+// Package merge decides which Kodik materials describe the same title.
+//
+// Materials are joined with union-find: first by manual merge overrides,
+// then by shared Shikimori/MAL, IMDb or Kinopoisk IDs, and finally by fuzzy
+// matching of original titles for groups whose IDs do not contradict each
+// other. Shikimori IDs are per season, so two groups with different ones
+// are never joined by the engine, and manual split overrides are never
+// crossed.
+package merge
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+)
+
+// minTitleSimilarity is the normalized edit-distance similarity two
+// original titles need to be treated as the same title.
+const minTitleSimilarity = 0.9
+
+type Group struct {
+	Key     string
+	Members []kodik.Material
+}
+
+// namespaces in the order they are used for keys; Shikimori IDs double as
+// MyAnimeList IDs.
+var namespaces = []string{"sh", "imdb", "kp"}
+
+func externalID(m kodik.Material, ns string) string {
+	switch ns {
+	case "sh":
+		return m.ShikimoriID
+	case "imdb":
+		return m.IMDbID
+	case "kp":
+		return m.KinopoiskID
+	}
+	return ""
+}
+
+type component struct {
+	members []int
+	ids     map[string]string
+}
+
+type engine struct {
+	ms     []kodik.Material
+	parent []int
+	comps  map[int]*component
+	splits map[[2]string]bool
+}
+
+func (e *engine) find(i int) int {
+	for e.parent[i] != i {
+		e.parent[i] = e.parent[e.parent[i]]
+		i = e.parent[i]
+	}
+	return i
+}
+
+// conflict reports whether two components carry different IDs in the same
+// namespace. Unless strict, only Shikimori IDs count.
+func (e *engine) conflict(a, b *component, strict bool) bool {
+	for _, ns := range namespaces {
+		if !strict && ns != "sh" {
+			continue
+		}
+		va, vb := a.ids[ns], b.ids[ns]
+		if va != "" && vb != "" && va != vb {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *engine) splitApart(a, b *component) bool {
+	if len(e.splits) == 0 {
+		return false
+	}
+	for _, i := range a.members {
+		for _, j := range b.members {
+			if e.splits[pairKey(e.ms[i].ID, e.ms[j].ID)] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// union joins the components of i and j. Unless forced it refuses when the
+// components conflict or a split override separates them.
+func (e *engine) union(i, j int, strict, forced bool) bool {
+	ri, rj := e.find(i), e.find(j)
+	if ri == rj {
+		return false
+	}
+	a, b := e.comps[ri], e.comps[rj]
+	if !forced && (e.conflict(a, b, strict) || e.splitApart(a, b)) {
+		return false
+	}
+	if len(a.members) < len(b.members) {
+		ri, rj, a, b = rj, ri, b, a
+	}
+	e.parent[rj] = ri
+	a.members = append(a.members, b.members...)
+	for ns, v := range b.ids {
+		if a.ids[ns] == "" {
+			a.ids[ns] = v
+		}
+	}
+	delete(e.comps, rj)
+	return true
+}
+
+// Partition splits ms into titles. Materials repeated by ID are collapsed
+// to their last occurrence, kept at the position of the first. Groups come
+// out in order of their first member.
+func Partition(ms []kodik.Material, ov *Overrides) []Group {
+	pos := make(map[string]int, len(ms))
+	uniq := make([]kodik.Material, 0, len(ms))
+	for _, m := range ms {
+		if i, ok := pos[m.ID]; ok {
+			uniq[i] = m
+			continue
+		}
+		pos[m.ID] = len(uniq)
+		uniq = append(uniq, m)
+	}
+
+	e := &engine{
+		ms:     uniq,
+		parent: make([]int, len(uniq)),
+		comps:  make(map[int]*component, len(uniq)),
+		splits: make(map[[2]string]bool),
+	}
+	for i, m := range uniq {
+		e.parent[i] = i
+		c := &component{members: []int{i}, ids: make(map[string]string)}
+		for _, ns := range namespaces {
+			if v := externalID(m, ns); v != "" {
+				c.ids[ns] = v
+			}
+		}
+		e.comps[i] = c
+	}
+
+	for _, o := range ov.List() {
+		i, okA := pos[o.A]
+		j, okB := pos[o.B]
+		switch {
+		case o.Kind == KindSplit:
+			e.splits[pairKey(o.A, o.B)] = true
+		case okA && okB:
+			e.union(i, j, false, true)
+		}
+	}
+
+	for _, ns := range namespaces {
+		first := make(map[string]int)
+		for i, m := range uniq {
+			v := externalID(m, ns)
+			if v == "" {
+				continue
+			}
+			if j, ok := first[v]; ok {
+				e.union(j, i, false, false)
+			} else {
+				first[v] = i
+			}
+		}
+	}
+
+	titles := make([]title, len(uniq))
+	for i, m := range uniq {
+		titles[i] = title{orig: []rune(normalizeTitle(m.TitleOrig)), local: []rune(normalizeTitle(m.Title)), year: m.Year}
+	}
+	roots := make([]int, 0, len(e.comps))
+	rootID := make(map[int]string, len(e.comps))
+	for i := range uniq {
+		if e.find(i) == i {
+			roots = append(roots, i)
+			members := make([]kodik.Material, 0, len(e.comps[i].members))
+			for _, j := range e.comps[i].members {
+				members = append(members, uniq[j])
+			}
+			rootID[i] = minID(members)
+		}
+	}
+	// A title can match several groups; visiting them by smallest member
+	// ID keeps the one it joins independent of input order.
+	sort.Slice(roots, func(x, y int) bool { return rootID[roots[x]] < rootID[roots[y]] })
+	for x := 0; x < len(roots); x++ {
+		for y := x + 1; y < len(roots); y++ {
+			a, b := roots[x], roots[y]
+			if e.find(a) == e.find(b) || !sameTitle(titles[a], titles[b]) {
+				continue
+			}
+			e.union(a, b, true, false)
+		}
+	}
+
+	byRoot := make(map[int]int)
+	var out []Group
+	for i, m := range uniq {
+		r := e.find(i)
+		gi, ok := byRoot[r]
+		if !ok {
+			gi = len(out)
+			byRoot[r] = gi
+			out = append(out, Group{})
+		}
+		out[gi].Members = append(out[gi].Members, m)
+	}
+
+	// Keys must not depend on input order. When groups share a key, the
+	// one with the smallest member ID keeps it and the others are told
+	// apart by theirs.
+	first := make([]string, len(out))
+	order := make([]int, len(out))
+	for gi, g := range out {
+		first[gi] = minID(g.Members)
+		order[gi] = gi
+	}
+	sort.Slice(order, func(i, j int) bool { return first[order[i]] < first[order[j]] })
+	used := make(map[string]bool, len(out))
+	for _, gi := range order {
+		k := key(out[gi].Members)
+		if used[k] {
+			k += "#" + first[gi]
+		}
+		used[k] = true
+		out[gi].Key = k
+	}
+	return out
+}
+
+func minID(members []kodik.Material) string {
+	id := members[0].ID
+	for _, m := range members[1:] {
+		if m.ID < id {
+			id = m.ID
+		}
+	}
+	return id
+}
+
+// key names a group after its most specific external ID, the smallest if
+// members disagree, falling back to the title and year of the member with
+// the smallest ID.
+func key(members []kodik.Material) string {
+	for _, ns := range namespaces {
+		id := ""
+		for _, m := range members {
+			if v := externalID(m, ns); v != "" && (id == "" || v < id) {
+				id = v
+			}
+		}
+		if id != "" {
+			return ns + ":" + id
+		}
+	}
+	m := members[0]
+	for _, o := range members[1:] {
+		if o.ID < m.ID {
+			m = o
+		}
+	}
+	return fmt.Sprintf("ttl:%s|%d", strings.ToLower(strings.TrimSpace(m.Title)), m.Year)
+}
+
+// LegacyKeys returns the keys the catalog gave the members of g before the
+// merge engine, when every material was keyed by its Kinopoisk ID, then its
+// Shikimori ID, then its title and year. Stores keyed by title use them to
+// carry data saved under those keys over to g.Key.
+func (g Group) LegacyKeys() []string {
+	var out []string
+	for _, m := range g.Members {
+		for _, k := range legacyKeys(m) {
+			if k != g.Key && !slices.Contains(out, k) {
+				out = append(out, k)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// legacyKeys returns every key m may have had. Search results used to be
+// parsed without Shikimori IDs or years, so a material first seen through
+// a search was keyed by its title and year 0.
+func legacyKeys(m kodik.Material) []string {
+	if m.KinopoiskID != "" {
+		return []string{"kp:" + m.KinopoiskID}
+	}
+	title := strings.ToLower(strings.TrimSpace(m.Title))
+	out := []string{fmt.Sprintf("ttl:%s|0", title)}
+	switch {
+	case m.ShikimoriID != "":
+		out = append(out, "sh:"+m.ShikimoriID)
+	case m.Year != 0:
+		out = append(out, fmt.Sprintf("ttl:%s|%d", title, m.Year))
+	}
+	return out
+}
+
+func normalizeTitle(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			space = false
+		case !space && b.Len() > 0:
+			b.WriteRune(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// title is a material's normalized titles, computed once per Partition.
+type title struct {
+	orig, local []rune
+	year        int
+}
+
+// sameTitle compares original titles (or local ones when either lacks it)
+// and years, tolerating a one-year difference between releases.
+func sameTitle(a, b title) bool {
+	if a.year != 0 && b.year != 0 && (a.year-b.year > 1 || b.year-a.year > 1) {
+		return false
+	}
+	ta, tb := a.orig, b.orig
+	if len(ta) == 0 || len(tb) == 0 {
+		ta, tb = a.local, b.local
+	}
+	if len(ta) == 0 || len(tb) == 0 {
+		return false
+	}
+	return similarity(ta, tb) >= minTitleSimilarity
+}
+
+// similarity is 1 minus the Levenshtein distance over the longer length.
+func similarity(ra, rb []rune) float64 {
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	// The distance is at least the length difference; skip the table when
+	// that alone rules a match out.
+	longest := float64(max(len(ra), len(rb)))
+	if 1-float64(abs(len(ra)-len(rb)))/longest < minTitleSimilarity {
+		return 0
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return 1 - float64(prev[len(rb)])/longest
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
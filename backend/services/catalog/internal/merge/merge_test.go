@@ -0,0 +1,210 @@
+package merge
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+	"github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik/kodiktest"
+)
+
+// fixtures lists every recorded material through the fake API, so they
+// are parsed exactly as in production.
+func fixtures(t *testing.T) []kodik.Material {
+	t.Helper()
+	srv := kodiktest.NewServer()
+	defer srv.Close()
+	c := kodik.NewClient(kodiktest.Token,
+		kodik.WithBaseURL(srv.URL),
+		kodik.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	)
+	lr, err := c.List(context.Background(), kodik.ListOptions{Limit: 100, WithMaterialData: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lr.Results
+}
+
+// partition maps each group key to its sorted member IDs.
+func partition(ms []kodik.Material, ov *Overrides) map[string][]string {
+	out := make(map[string][]string)
+	for _, g := range Partition(ms, ov) {
+		for _, m := range g.Members {
+			out[g.Key] = append(out[g.Key], m.ID)
+		}
+		slices.Sort(out[g.Key])
+	}
+	return out
+}
+
+func groupOf(groups map[string][]string, id string) string {
+	for k, ids := range groups {
+		if slices.Contains(ids, id) {
+			return k
+		}
+	}
+	return ""
+}
+
+func TestPartitionFixtures(t *testing.T) {
+	got := partition(fixtures(t), nil)
+	want := map[string][]string{
+		// Joined by Shikimori ID; serial-51802 has no IDs and is joined by
+		// its original title.
+		"sh:52991": {"serial-51732", "serial-51745", "serial-51802"},
+		"sh:44511": {"serial-45213", "serial-45301"},
+		// The movie shares a studio and half a title, not an ID.
+		"sh:57555": {"movie-58210"},
+		// Both seasons share a Kinopoisk ID, but Shikimori IDs are per
+		// season and keep them apart.
+		"sh:16498": {"serial-8821"},
+		"sh:25777": {"serial-19455"},
+		"sh:50265": {"serial-49920"},
+		"sh:58567": {"serial-60114"},
+		"sh:32281": {"movie-21370", "movie-21371"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d groups, want %d: %v", len(got), len(want), got)
+	}
+	for k, ids := range want {
+		if !slices.Equal(got[k], ids) {
+			t.Errorf("group %s = %v, want %v", k, got[k], ids)
+		}
+	}
+}
+
+func TestPartitionOverrides(t *testing.T) {
+	ms := fixtures(t)
+	ov := NewOverrides()
+	if _, err := ov.Set(Override{Kind: KindSplit, A: "serial-51802", B: "serial-51732"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ov.Set(Override{Kind: KindMerge, A: "serial-8821", B: "serial-19455"}); err != nil {
+		t.Fatal(err)
+	}
+	got := partition(ms, ov)
+
+	if g := groupOf(got, "serial-51802"); g == groupOf(got, "serial-51732") || g == groupOf(got, "serial-51745") {
+		t.Errorf("split override crossed: %v", got)
+	}
+	if !slices.Equal(got["sh:52991"], []string{"serial-51732", "serial-51745"}) {
+		t.Errorf("sh:52991 = %v", got["sh:52991"])
+	}
+	if g := groupOf(got, "serial-8821"); g == "" || g != groupOf(got, "serial-19455") {
+		t.Errorf("merge override not applied: %v", got)
+	}
+}
+
+func TestPartitionCollapsesRepeats(t *testing.T) {
+	ms := fixtures(t)
+	first := ms[0]
+	again := first
+	again.Title = "updated"
+	groups := Partition(append(ms, again), nil)
+
+	n := 0
+	for _, g := range groups {
+		for _, m := range g.Members {
+			if m.ID == first.ID {
+				n++
+				if m.Title != "updated" {
+					t.Errorf("kept %q, want the last occurrence", m.Title)
+				}
+			}
+		}
+	}
+	if n != 1 {
+		t.Errorf("%s appears %d times", first.ID, n)
+	}
+}
+
+func TestPartitionTitleMatching(t *testing.T) {
+	ms := []kodik.Material{
+		{ID: "a", Title: "Бездомный бог", TitleOrig: "Noragami", Year: 2014},
+		{ID: "b", Title: "Бездомный бог", TitleOrig: "Noragami!", Year: 2015},
+		// Too far apart in time to be the same release.
+		{ID: "c", Title: "Бездомный бог", TitleOrig: "Noragami", Year: 2020},
+		// Titles alone never join releases whose IDs disagree.
+		{ID: "d", TitleOrig: "Noragami", Year: 2014, KinopoiskID: "1"},
+		{ID: "e", TitleOrig: "Noragami", Year: 2014, KinopoiskID: "2"},
+	}
+	got := partition(ms, nil)
+	if groupOf(got, "a") != groupOf(got, "b") {
+		t.Errorf("a and b not joined: %v", got)
+	}
+	if groupOf(got, "a") == groupOf(got, "c") {
+		t.Errorf("c joined despite the year: %v", got)
+	}
+	if groupOf(got, "d") == groupOf(got, "e") {
+		t.Errorf("d and e joined despite different Kinopoisk IDs: %v", got)
+	}
+}
+
+func TestPartitionKeysAreUnique(t *testing.T) {
+	ms := []kodik.Material{
+		{ID: "a", Title: "Untitled", Year: 2020, ShikimoriID: "1"},
+		{ID: "b", Title: "Untitled", Year: 2020, ShikimoriID: "2"},
+		{ID: "c", Title: "Без названия", Year: 2001},
+		{ID: "d", Title: "Без названия", Year: 2001, KinopoiskID: "7"},
+		{ID: "e", Title: "Без названия", Year: 2001, KinopoiskID: "8"},
+	}
+	seen := make(map[string]bool)
+	for _, g := range Partition(ms, nil) {
+		if seen[g.Key] {
+			t.Errorf("key %s used twice", g.Key)
+		}
+		seen[g.Key] = true
+	}
+}
+
+func TestPartitionKeysIgnoreOrder(t *testing.T) {
+	ms := fixtures(t)
+	ov := NewOverrides()
+	// Splitting off a release with the same Shikimori ID makes two groups
+	// compete for sh:52991.
+	if _, err := ov.Set(Override{Kind: KindSplit, A: "serial-51745", B: "serial-51732"}); err != nil {
+		t.Fatal(err)
+	}
+	want := partition(ms, ov)
+	// The group holding the smallest ID keeps the key; the other is told
+	// apart by its own smallest ID. serial-51802 matches both by title and
+	// joins the first by ID.
+	if !slices.Equal(want["sh:52991"], []string{"serial-51732", "serial-51802"}) ||
+		!slices.Equal(want["sh:52991#serial-51745"], []string{"serial-51745"}) {
+		t.Fatalf("got %v", want)
+	}
+
+	reversed := slices.Clone(ms)
+	slices.Reverse(reversed)
+	got := partition(reversed, ov)
+	if len(got) != len(want) {
+		t.Fatalf("got %d groups, want %d", len(got), len(want))
+	}
+	for k, ids := range want {
+		if !slices.Equal(got[k], ids) {
+			t.Errorf("reversed input: group %s = %v, want %v", k, got[k], ids)
+		}
+	}
+}
+
+func TestLegacyKeys(t *testing.T) {
+	byKey := make(map[string][]string)
+	for _, g := range Partition(fixtures(t), nil) {
+		byKey[g.Key] = g.LegacyKeys()
+	}
+	for k, want := range map[string][]string{
+		// Keyed by Kinopoisk ID before; serial-51802 by its title, with or
+		// without the year depending on whether a search found it.
+		"sh:52991": {"kp:4616588", "ttl:фрирен, провожающая в последний путь|0", "ttl:фрирен, провожающая в последний путь|2023"},
+		// Both seasons were one title under their shared Kinopoisk ID.
+		"sh:16498": {"kp:749374"},
+		"sh:25777": {"kp:749374"},
+	} {
+		if got := byKey[k]; !slices.Equal(got, want) {
+			t.Errorf("legacy keys of %s = %q, want %q", k, got, want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package merge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+type Kind string
+
+const (
+	// KindMerge forces two Kodik materials into the same title.
+	KindMerge Kind = "merge"
+	// KindSplit keeps two Kodik materials in different titles.
+	KindSplit Kind = "split"
+)
+
+// Override is a manual decision about a pair of Kodik material IDs. It wins
+// over anything the engine would infer for that pair.
+type Override struct {
+	Kind      Kind      `json:"kind"`
+	A         string    `json:"a"`
+	B         string    `json:"b"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func pairKey(a, b string) [2]string {
+	if b < a {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
+
+// Overrides is the admin override table, optionally backed by a JSON file
+// that is rewritten on every change.
+type Overrides struct {
+	mu   sync.RWMutex
+	path string
+	list []Override
+}
+
+func NewOverrides() *Overrides {
+	return &Overrides{}
+}
+
+func OpenOverrides(path string) (*Overrides, error) {
+	ov := &Overrides{path: path}
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ov, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &ov.list); err != nil {
+		return nil, err
+	}
+	return ov, nil
+}
+
+// save must be called with mu held.
+func (ov *Overrides) save() error {
+	if ov.path == "" {
+		return nil
+	}
+	b, err := json.Marshal(ov.list)
+	if err != nil {
+		return err
+	}
+	tmp := ov.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, ov.path)
+}
+
+// Set adds o, replacing any earlier override for the same pair.
+func (ov *Overrides) Set(o Override) (Override, error) {
+	if o.Kind != KindMerge && o.Kind != KindSplit {
+		return Override{}, fmt.Errorf("unknown override kind %q", o.Kind)
+	}
+	if o.A == "" || o.B == "" || o.A == o.B {
+		return Override{}, fmt.Errorf("override needs two different kodik ids")
+	}
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = time.Now().UTC()
+	}
+	ov.mu.Lock()
+	defer ov.mu.Unlock()
+	ov.remove(o.A, o.B)
+	ov.list = append(ov.list, o)
+	return o, ov.save()
+}
+
+// Delete drops the override for a pair and reports whether there was one.
+func (ov *Overrides) Delete(a, b string) (bool, error) {
+	ov.mu.Lock()
+	defer ov.mu.Unlock()
+	if !ov.remove(a, b) {
+		return false, nil
+	}
+	return true, ov.save()
+}
+
+// remove must be called with mu held.
+func (ov *Overrides) remove(a, b string) bool {
+	k := pairKey(a, b)
+	for i, o := range ov.list {
+		if pairKey(o.A, o.B) == k {
+			ov.list = append(ov.list[:i], ov.list[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (ov *Overrides) List() []Override {
+	if ov == nil {
+		return nil
+	}
+	ov.mu.RLock()
+	defer ov.mu.RUnlock()
+	return append([]Override(nil), ov.list...)
+}
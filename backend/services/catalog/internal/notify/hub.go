@@ -0,0 +1,45 @@
+package notify
+
+import "sync"
+
+// hub fans notifications out to live subscribers. Delivery is best effort:
+// a subscriber that falls behind misses live events but still finds them
+// unread in the Store.
+type hub struct {
+	mu   sync.Mutex
+	subs map[string]map[chan Notification]struct{}
+}
+
+func newHub() *hub {
+	return &hub{subs: make(map[string]map[chan Notification]struct{})}
+}
+
+func (h *hub) subscribe(userID string) (<-chan Notification, func()) {
+	ch := make(chan Notification, 16)
+	h.mu.Lock()
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[chan Notification]struct{})
+	}
+	h.subs[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs[userID], ch)
+		if len(h.subs[userID]) == 0 {
+			delete(h.subs, userID)
+		}
+		h.mu.Unlock()
+	}
+}
+
+func (h *hub) publish(n Notification) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[n.UserID] {
+		select {
+		case ch <- n:
+		default:
+		}
+	}
+}
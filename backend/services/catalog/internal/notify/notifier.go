@@ -0,0 +1,201 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+)
+
+// Title is the merged state of one canonical title as the catalog sees it.
+type Title struct {
+	Key string
+	// LegacyKeys are keys the title may have been observed under before.
+	LegacyKeys    []string
+	KodikID       string
+	Title         string
+	EpisodesCount int
+	Translations  []kodik.Translation
+}
+
+// Change is one difference between two observations of a title.
+type Change struct {
+	Title       Title
+	Kind        Kind
+	Episode     int
+	Translation kodik.Translation
+}
+
+// WatchersFunc returns every watched kodik_id with the users watching it.
+type WatchersFunc func(ctx context.Context) (map[string][]string, error)
+
+// ResolveFunc fetches the current state of a title from Kodik.
+type ResolveFunc func(ctx context.Context, kodikID string) (Title, error)
+
+// Notifier periodically re-resolves watchlisted titles, turns changes in
+// episode count or translations into per-user notifications and pushes them
+// to live subscribers.
+type Notifier struct {
+	store    *Store
+	hub      *hub
+	watchers WatchersFunc
+	resolve  ResolveFunc
+	onChange []func(Change)
+	log      *slog.Logger
+}
+
+// Option configures a Notifier.
+type Option func(*Notifier)
+
+// WithLogger sets the logger for failed checks and lookups.
+func WithLogger(l *slog.Logger) Option {
+	return func(n *Notifier) { n.log = l }
+}
+
+func New(store *Store, watchers WatchersFunc, resolve ResolveFunc, opts ...Option) *Notifier {
+	n := &Notifier{
+		store:    store,
+		hub:      newHub(),
+		watchers: watchers,
+		resolve:  resolve,
+		log:      slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// OnChange registers fn to be called for every detected change, once it and
+// the matching notifications are stored. It must be called before Run.
+func (n *Notifier) OnChange(fn func(Change)) {
+	n.onChange = append(n.onChange, fn)
+}
+
+// Run calls Check every interval until ctx is done.
+func (n *Notifier) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		if err := n.Check(ctx); err != nil {
+			n.log.ErrorContext(ctx, "notification check failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// resolveConcurrency bounds how many titles Check resolves at once.
+const resolveConcurrency = 8
+
+// Check runs one detection pass over all watched titles.
+func (n *Notifier) Check(ctx context.Context) error {
+	watchers, err := n.watchers(ctx)
+	if err != nil {
+		return err
+	}
+
+	kodikIDs := make([]string, 0, len(watchers))
+	for kodikID := range watchers {
+		kodikIDs = append(kodikIDs, kodikID)
+	}
+	sort.Strings(kodikIDs)
+	titles := make([]*Title, len(kodikIDs))
+	sem := make(chan struct{}, resolveConcurrency)
+	var wg sync.WaitGroup
+	for i, kodikID := range kodikIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			t, err := n.resolve(ctx, kodikID)
+			if err != nil {
+				n.log.WarnContext(ctx, "notification title lookup failed", "kodik_id", kodikID, "error", err)
+				return
+			}
+			titles[i] = &t
+		}()
+	}
+	wg.Wait()
+
+	type group struct {
+		title Title
+		// users maps each watcher to the kodik_id on their own watchlist
+		users map[string]string
+	}
+	groups := make(map[string]*group)
+	var keys []string
+	for i, kodikID := range kodikIDs {
+		t := titles[i]
+		if t == nil {
+			continue
+		}
+		// The title resolved through the smallest kodik_id represents its
+		// group, so consecutive checks compare the same release.
+		g, ok := groups[t.Key]
+		if !ok {
+			g = &group{title: *t, users: make(map[string]string)}
+			groups[t.Key] = g
+			keys = append(keys, t.Key)
+		}
+		for _, u := range watchers[kodikID] {
+			g.users[u] = kodikID
+		}
+	}
+
+	for _, key := range keys {
+		g := groups[key]
+		changes := n.store.Diff(g.title)
+		var ns []Notification
+		for _, ch := range changes {
+			for userID, kodikID := range g.users {
+				nt := Notification{
+					UserID:    userID,
+					KodikID:   kodikID,
+					Title:     ch.Title.Title,
+					Kind:      ch.Kind,
+					Episode:   ch.Episode,
+					CreatedAt: time.Now().UTC(),
+				}
+				if ch.Kind == KindNewTranslation {
+					tr := ch.Translation
+					nt.Translation = &tr
+				}
+				ns = append(ns, nt)
+			}
+		}
+		ns, err := n.store.Commit(g.title, ns)
+		if err != nil {
+			return err
+		}
+		for _, ch := range changes {
+			for _, fn := range n.onChange {
+				fn(ch)
+			}
+		}
+		for _, nt := range ns {
+			n.hub.publish(nt)
+		}
+	}
+	return nil
+}
+
+// Subscribe delivers the user's new notifications until cancel is called.
+func (n *Notifier) Subscribe(userID string) (<-chan Notification, func()) {
+	return n.hub.subscribe(userID)
+}
+
+func (n *Notifier) List(userID string, unreadOnly bool) []Notification {
+	return n.store.List(userID, unreadOnly)
+}
+
+func (n *Notifier) MarkRead(userID string, ids []string) (int, error) {
+	return n.store.MarkRead(userID, ids)
+}
@@ -0,0 +1,172 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+)
+
+// fakeTitles resolves every kodik_id to the same title key, named after
+// the release it was resolved through.
+type fakeTitles struct {
+	mu       sync.Mutex
+	episodes int
+}
+
+func (f *fakeTitles) resolve(ctx context.Context, kodikID string) (Title, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return Title{
+		Key:           "sh:1",
+		KodikID:       kodikID,
+		Title:         "release " + kodikID,
+		EpisodesCount: f.episodes,
+		Translations:  []kodik.Translation{{ID: 610, Title: "AniLibria"}},
+	}, nil
+}
+
+func watching(ctx context.Context) (map[string][]string, error) {
+	return map[string][]string{
+		"serial-2": {"bob"},
+		"serial-1": {"alice"},
+		"serial-3": {"carol"},
+	}, nil
+}
+
+func TestCheckNotifiesWatchers(t *testing.T) {
+	titles := &fakeTitles{episodes: 3}
+	st := NewStore()
+	n := New(st, watching, titles.resolve)
+	var changes []Change
+	n.OnChange(func(ch Change) { changes = append(changes, ch) })
+
+	if err := n.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("first check reported %d changes, want a baseline", len(changes))
+	}
+
+	titles.episodes = 4
+	if err := n.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Kind != KindNewEpisode || changes[0].Episode != 4 {
+		t.Fatalf("changes = %+v", changes)
+	}
+	for user, kodikID := range map[string]string{"alice": "serial-1", "bob": "serial-2", "carol": "serial-3"} {
+		got := n.List(user, true)
+		if len(got) != 1 || got[0].KodikID != kodikID || got[0].ID == "" {
+			t.Errorf("%s got %+v", user, got)
+			continue
+		}
+		// The smallest kodik_id represents the title whatever map order
+		// the watchers came in.
+		if got[0].Title != "release serial-1" {
+			t.Errorf("%s notified about %q", user, got[0].Title)
+		}
+	}
+}
+
+func TestCheckRetriesUnsavedChanges(t *testing.T) {
+	titles := &fakeTitles{episodes: 3}
+	dir := t.TempDir()
+	st, err := OpenStore(filepath.Join(dir, "notify.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := New(st, watching, titles.resolve)
+	var changes int
+	n.OnChange(func(Change) { changes++ })
+	if err := n.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	titles.episodes = 4
+	st.path = filepath.Join(dir, "missing", "notify.json")
+	if err := n.Check(context.Background()); err == nil {
+		t.Fatal("check succeeded without saving")
+	}
+	if got := n.List("alice", false); len(got) != 0 || changes != 0 {
+		t.Fatalf("unsaved check left %d notifications and %d changes", len(got), changes)
+	}
+
+	st.path = filepath.Join(dir, "notify.json")
+	if err := n.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := n.List("alice", false); len(got) != 1 || changes != 1 {
+		t.Fatalf("retry left %d notifications and %d changes, want 1 each", len(got), changes)
+	}
+
+	reopened, err := OpenStore(st.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reopened.List("bob", false); len(got) != 1 {
+		t.Errorf("saved %d notifications for bob, want 1", len(got))
+	}
+	if s := reopened.data.Snapshots["sh:1"]; s.EpisodesCount != 4 {
+		t.Errorf("saved snapshot %+v", s)
+	}
+}
+
+func TestCommitMigratesLegacyKey(t *testing.T) {
+	st := NewStore()
+	old := Title{Key: "kp:749374", EpisodesCount: 12}
+	if _, err := st.Commit(old, nil); err != nil {
+		t.Fatal(err)
+	}
+	t2 := Title{Key: "sh:16498", LegacyKeys: []string{"kp:749374"}, EpisodesCount: 13}
+	changes := st.Diff(t2)
+	if len(changes) != 1 || changes[0].Episode != 13 {
+		t.Fatalf("changes = %+v, want episode 13 against the legacy snapshot", changes)
+	}
+	if _, err := st.Commit(t2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := st.data.Snapshots["kp:749374"]; ok {
+		t.Error("legacy snapshot kept")
+	}
+	if got := fmt.Sprint(st.data.Snapshots["sh:16498"]); got != "{13 []}" {
+		t.Errorf("snapshot = %s", got)
+	}
+}
+
+func TestCommitPrunesNotifications(t *testing.T) {
+	dir := t.TempDir()
+	st, err := OpenStore(filepath.Join(dir, "notify.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UTC()
+	var ns []Notification
+	ns = append(ns, Notification{UserID: "bob", CreatedAt: now.Add(-notificationTTL - time.Hour)})
+	for i := range maxNotifications + 10 {
+		ns = append(ns, Notification{UserID: "alice", Episode: i, CreatedAt: now.Add(time.Duration(i-maxNotifications-10) * time.Minute)})
+	}
+	if _, err := st.Commit(Title{Key: "sh:1", EpisodesCount: 1}, ns); err != nil {
+		t.Fatal(err)
+	}
+	alice := st.List("alice", false)
+	if len(alice) != maxNotifications || alice[0].Episode != maxNotifications+9 || alice[len(alice)-1].Episode != 10 {
+		t.Fatalf("kept %d notifications for alice, newest %d", len(alice), alice[0].Episode)
+	}
+	if got := st.List("bob", false); len(got) != 0 {
+		t.Errorf("kept %d expired notifications for bob", len(got))
+	}
+
+	// A failed save restores the lists as they were before the commit.
+	st.path = filepath.Join(dir, "missing", "notify.json")
+	if _, err := st.Commit(Title{Key: "sh:1", EpisodesCount: 2}, []Notification{{UserID: "alice", Episode: -1, CreatedAt: now}}); err == nil {
+		t.Fatal("commit succeeded without saving")
+	}
+	if got := st.List("alice", false); len(got) != maxNotifications || got[0].Episode != maxNotifications+9 || got[len(got)-1].Episode != 10 {
+		t.Errorf("failed commit left %d notifications, newest %d", len(got), got[0].Episode)
+	}
+}
@@ -0,0 +1,249 @@
+package notify
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	kodik "github.com/greg5320/AniFlow/backend/services/catalog/internal/kodik"
+)
+
+type Kind string
+
+const (
+	KindNewEpisode     Kind = "new_episode"
+	KindNewTranslation Kind = "new_translation"
+)
+
+type Notification struct {
+	ID          string             `json:"id"`
+	UserID      string             `json:"user_id"`
+	KodikID     string             `json:"kodik_id"`
+	Title       string             `json:"title"`
+	Kind        Kind               `json:"kind"`
+	Episode     int                `json:"episode,omitempty"`
+	Translation *kodik.Translation `json:"translation,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	Read        bool               `json:"read"`
+}
+
+// Snapshot is the last observed state of one canonical title.
+type Snapshot struct {
+	EpisodesCount int   `json:"episodes_count"`
+	Translations  []int `json:"translations"`
+}
+
+// A user keeps at most maxNotifications, none older than notificationTTL;
+// the rest are pruned on the next Commit.
+const (
+	maxNotifications = 500
+	notificationTTL  = 90 * 24 * time.Hour
+)
+
+// Store holds title snapshots and per-user notifications. When opened with
+// a path, every mutation is written back to that JSON file.
+type Store struct {
+	mu   sync.Mutex
+	path string
+	data storeFile
+}
+
+type storeFile struct {
+	Snapshots     map[string]Snapshot       `json:"snapshots"`
+	Notifications map[string][]Notification `json:"notifications"`
+}
+
+func NewStore() *Store {
+	return &Store{data: storeFile{
+		Snapshots:     make(map[string]Snapshot),
+		Notifications: make(map[string][]Notification),
+	}}
+}
+
+func OpenStore(path string) (*Store, error) {
+	st := NewStore()
+	st.path = path
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &st.data); err != nil {
+		return nil, err
+	}
+	if st.data.Snapshots == nil {
+		st.data.Snapshots = make(map[string]Snapshot)
+	}
+	if st.data.Notifications == nil {
+		st.data.Notifications = make(map[string][]Notification)
+	}
+	return st, nil
+}
+
+// save must be called with mu held.
+func (st *Store) save() error {
+	if st.path == "" {
+		return nil
+	}
+	b, err := json.Marshal(st.data)
+	if err != nil {
+		return err
+	}
+	tmp := st.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, st.path)
+}
+
+// Diff returns what changed in t since the last committed observation of
+// its title, which may have been saved under one of t.LegacyKeys. The
+// first observation of a title only establishes a baseline.
+func (st *Store) Diff(t Title) []Change {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	prev, _, seen := st.previous(t)
+	if !seen {
+		return nil
+	}
+
+	var changes []Change
+	if t.EpisodesCount > prev.EpisodesCount {
+		changes = append(changes, Change{Title: t, Kind: KindNewEpisode, Episode: t.EpisodesCount})
+	}
+	known := make(map[int]bool, len(prev.Translations))
+	for _, id := range prev.Translations {
+		known[id] = true
+	}
+	for _, tr := range t.Translations {
+		if !known[tr.ID] {
+			changes = append(changes, Change{Title: t, Kind: KindNewTranslation, Translation: tr})
+		}
+	}
+	return changes
+}
+
+// previous must be called with mu held.
+func (st *Store) previous(t Title) (Snapshot, string, bool) {
+	if prev, ok := st.data.Snapshots[t.Key]; ok {
+		return prev, t.Key, true
+	}
+	for _, k := range t.LegacyKeys {
+		if prev, ok := st.data.Snapshots[k]; ok {
+			return prev, k, true
+		}
+	}
+	return Snapshot{}, "", false
+}
+
+// Commit records t as the latest state of its title and stores ns as
+// unread, with ids assigned, in a single write so notifications are never
+// lost to a saved snapshot or sent twice for an unsaved one.
+func (st *Store) Commit(t Title, ns []Notification) ([]Notification, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	next := Snapshot{EpisodesCount: t.EpisodesCount}
+	for _, tr := range t.Translations {
+		next.Translations = append(next.Translations, tr.ID)
+	}
+	sort.Ints(next.Translations)
+	prev, key, seen := st.previous(t)
+	if seen && key == t.Key && len(ns) == 0 &&
+		prev.EpisodesCount == next.EpisodesCount && slices.Equal(prev.Translations, next.Translations) {
+		return nil, nil
+	}
+	if seen && key != t.Key {
+		delete(st.data.Snapshots, key)
+	}
+	st.data.Snapshots[t.Key] = next
+
+	// Appending and pruning only reslice, so the old lists stay intact.
+	lists := maps.Clone(st.data.Notifications)
+	for i := range ns {
+		ns[i].ID = newID()
+		ns[i].Read = false
+		st.data.Notifications[ns[i].UserID] = append(st.data.Notifications[ns[i].UserID], ns[i])
+	}
+	st.prune(time.Now())
+	if err := st.save(); err != nil {
+		// Undo so the next check finds the same changes again.
+		delete(st.data.Snapshots, t.Key)
+		if seen {
+			st.data.Snapshots[key] = prev
+		}
+		st.data.Notifications = lists
+		return nil, err
+	}
+	return ns, nil
+}
+
+// prune drops notifications past the retention limits. Lists are kept in
+// creation order, so the expired ones are a prefix. It must be called with
+// mu held.
+func (st *Store) prune(now time.Time) {
+	cutoff := now.Add(-notificationTTL)
+	for userID, all := range st.data.Notifications {
+		i := sort.Search(len(all), func(i int) bool { return all[i].CreatedAt.After(cutoff) })
+		i = max(i, len(all)-maxNotifications)
+		switch {
+		case i == len(all):
+			delete(st.data.Notifications, userID)
+		case i > 0:
+			st.data.Notifications[userID] = all[i:]
+		}
+	}
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// List returns the user's notifications, newest first.
+func (st *Store) List(userID string, unreadOnly bool) []Notification {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	all := st.data.Notifications[userID]
+	out := make([]Notification, 0, len(all))
+	for i := len(all) - 1; i >= 0; i-- {
+		if unreadOnly && all[i].Read {
+			continue
+		}
+		out = append(out, all[i])
+	}
+	return out
+}
+
+// MarkRead marks the given notifications (or all, if ids is empty) as read
+// and reports how many changed.
+func (st *Store) MarkRead(userID string, ids []string) (int, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	n := 0
+	all := st.data.Notifications[userID]
+	for i := range all {
+		if all[i].Read || (len(want) > 0 && !want[all[i].ID]) {
+			continue
+		}
+		all[i].Read = true
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return n, st.save()
+}
@@ -0,0 +1,256 @@
+// Package recommend ranks titles against a taste profile built from what a
+// user has watched and rated.
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Weights of the parts of a candidate's score.
+const (
+	genreWeight  = 0.6
+	studioWeight = 0.25
+	ratingWeight = 0.15
+	// coWatchWeight scales the collaborative part, which is normalized to
+	// 0-1 across the candidates.
+	coWatchWeight = 0.5
+)
+
+// highRating is the rating from which a title with nothing in common with
+// the library is still explained as highly rated.
+const highRating = 7.5
+
+// Features is what the recommender knows about a title.
+type Features struct {
+	ID      string
+	Title   string
+	Genres  []string
+	Studios []string
+	Year    int
+	// Rating on a 0-10 scale.
+	Rating float64
+}
+
+// Seed is a title from the user's library.
+type Seed struct {
+	Features
+	Status   string
+	Score    int
+	Progress int
+}
+
+// Weight turns a library entry into how much it says about the user's
+// taste: an explicit score wins, otherwise the status decides. Negative
+// weights push similar titles down.
+func (s Seed) Weight() float64 {
+	if s.Score > 0 {
+		return (float64(s.Score) - 5.5) / 4.5
+	}
+	switch s.Status {
+	case "completed":
+		return 0.6
+	case "watching":
+		if s.Progress > 0 {
+			return 0.5
+		}
+		return 0.4
+	case "on_hold":
+		return 0.1
+	case "dropped":
+		return -0.6
+	default:
+		return 0.2
+	}
+}
+
+// Profile is a user's weighted preference for genres and studios.
+type Profile struct {
+	seeds      []Seed
+	genres     map[string]float64
+	studios    map[string]float64
+	genreNorm  float64
+	studioNorm float64
+}
+
+func norm(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func BuildProfile(seeds []Seed) *Profile {
+	p := &Profile{
+		seeds:   seeds,
+		genres:  make(map[string]float64),
+		studios: make(map[string]float64),
+	}
+	for _, s := range seeds {
+		w := s.Weight()
+		for _, g := range s.Genres {
+			p.genres[norm(g)] += w
+		}
+		for _, st := range s.Studios {
+			p.studios[norm(st)] += w
+		}
+	}
+	p.genreNorm = length(p.genres)
+	p.studioNorm = length(p.studios)
+	return p
+}
+
+func length(v map[string]float64) float64 {
+	var sum float64
+	for _, w := range v {
+		sum += w * w
+	}
+	return math.Sqrt(sum)
+}
+
+// cosine is the cosine similarity between the profile vector v and a title
+// that has each of keys once.
+func cosine(v map[string]float64, vnorm float64, keys []string) float64 {
+	if vnorm == 0 || len(keys) == 0 {
+		return 0
+	}
+	var dot float64
+	for _, k := range keys {
+		dot += v[norm(k)]
+	}
+	return dot / (vnorm * math.Sqrt(float64(len(keys))))
+}
+
+// Empty reports whether the profile has nothing to rank by.
+func (p *Profile) Empty() bool {
+	return p.genreNorm == 0 && p.studioNorm == 0
+}
+
+// Score rates how well f fits the profile.
+func (p *Profile) Score(f Features) float64 {
+	return genreWeight*cosine(p.genres, p.genreNorm, f.Genres) +
+		studioWeight*cosine(p.studios, p.studioNorm, f.Studios) +
+		ratingWeight*min(f.Rating, 10)/10
+}
+
+// CoWatch is the collaborative signal for one candidate: how strongly users
+// who have the library's titles also have it, and the library title that
+// contributes most.
+type CoWatch struct {
+	Score float64
+	Seed  string
+}
+
+type Recommendation struct {
+	Features
+	Score   float64
+	Reasons []string
+	// Because lists the IDs of library titles the recommendation is based on.
+	Because []string
+}
+
+// Rank scores candidates, adding the collaborative signal from co keyed by
+// candidate ID, and returns the best limit of them with explanations.
+// Candidates are expected to exclude the user's library.
+func (p *Profile) Rank(candidates []Features, co map[string]CoWatch, limit int) []Recommendation {
+	var top float64
+	for _, c := range co {
+		top = max(top, c.Score)
+	}
+	out := make([]Recommendation, 0, len(candidates))
+	for _, c := range candidates {
+		r := Recommendation{Features: c, Score: p.Score(c)}
+		if cw, ok := co[c.ID]; ok && top > 0 {
+			r.Score += coWatchWeight * cw.Score / top
+		}
+		out = append(out, r)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	for i := range out {
+		p.explain(&out[i], co[out[i].ID])
+	}
+	return out
+}
+
+func (p *Profile) seed(id string) (Seed, bool) {
+	for _, s := range p.seeds {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return Seed{}, false
+}
+
+// explain names the library title that overlaps most with r and what they
+// have in common, and the title other users watched it with.
+func (p *Profile) explain(r *Recommendation, cw CoWatch) {
+	p.explainContent(r)
+	if s, ok := p.seed(cw.Seed); ok {
+		if !slices.Contains(r.Because, s.ID) {
+			r.Because = append(r.Because, s.ID)
+		}
+		r.Reasons = append(r.Reasons, fmt.Sprintf("users who watched %s also watched this", s.Title))
+	}
+}
+
+func (p *Profile) explainContent(r *Recommendation) {
+	genres := make(map[string]bool, len(r.Genres))
+	for _, g := range r.Genres {
+		genres[norm(g)] = true
+	}
+	studios := make(map[string]bool, len(r.Studios))
+	for _, st := range r.Studios {
+		studios[norm(st)] = true
+	}
+
+	var best *Seed
+	var bestOverlap float64
+	var sharedGenres, sharedStudios []string
+	for i := range p.seeds {
+		s := &p.seeds[i]
+		w := s.Weight()
+		if w <= 0 {
+			continue
+		}
+		var sg, ss []string
+		for _, g := range s.Genres {
+			if genres[norm(g)] {
+				sg = append(sg, g)
+			}
+		}
+		for _, st := range s.Studios {
+			if studios[norm(st)] {
+				ss = append(ss, st)
+			}
+		}
+		overlap := w * (float64(len(sg)) + 2*float64(len(ss)))
+		if overlap > bestOverlap {
+			best, bestOverlap, sharedGenres, sharedStudios = s, overlap, sg, ss
+		}
+	}
+	if best == nil {
+		if r.Rating >= highRating {
+			r.Reasons = append(r.Reasons, fmt.Sprintf("highly rated (%.1f)", r.Rating))
+		}
+		return
+	}
+
+	r.Because = append(r.Because, best.ID)
+	switch {
+	case best.Score > 0:
+		r.Reasons = append(r.Reasons, fmt.Sprintf("because you rated %s %d/10", best.Title, best.Score))
+	case best.Status == "planned":
+		r.Reasons = append(r.Reasons, fmt.Sprintf("because %s is on your watchlist", best.Title))
+	default:
+		r.Reasons = append(r.Reasons, fmt.Sprintf("because you watched %s", best.Title))
+	}
+	if len(sharedGenres) > 0 {
+		r.Reasons = append(r.Reasons, "shares genres: "+strings.Join(sharedGenres, ", "))
+	}
+	if len(sharedStudios) > 0 {
+		r.Reasons = append(r.Reasons, "same studio: "+strings.Join(sharedStudios, ", "))
+	}
+}
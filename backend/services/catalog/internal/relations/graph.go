@@ -0,0 +1,352 @@
+// Package relations stores links between titles of one franchise (sequels,
+// prequels, side stories, ...) keyed by Shikimori ID, which is also the
+// MyAnimeList ID.
+package relations
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Kind string
+
+const (
+	Sequel             Kind = "sequel"
+	Prequel            Kind = "prequel"
+	SideStory          Kind = "side_story"
+	ParentStory        Kind = "parent_story"
+	SpinOff            Kind = "spin_off"
+	AlternativeVersion Kind = "alternative_version"
+	AlternativeSetting Kind = "alternative_setting"
+	Summary            Kind = "summary"
+	FullStory          Kind = "full_story"
+	Character          Kind = "character"
+	Other              Kind = "other"
+)
+
+// inverse maps a relation to the one implied in the opposite direction.
+var inverse = map[Kind]Kind{
+	Sequel:             Prequel,
+	Prequel:            Sequel,
+	SideStory:          ParentStory,
+	ParentStory:        SideStory,
+	SpinOff:            ParentStory,
+	Summary:            FullStory,
+	FullStory:          Summary,
+	AlternativeVersion: AlternativeVersion,
+	AlternativeSetting: AlternativeSetting,
+	Character:          Character,
+	Other:              Other,
+}
+
+// ParseKind accepts the spellings used by MAL and Shikimori dumps, e.g.
+// "Side story", "side_story" or "Spin-off".
+func ParseKind(s string) (Kind, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.NewReplacer(" ", "_", "-", "_").Replace(s)
+	k := Kind(s)
+	_, ok := inverse[k]
+	return k, ok
+}
+
+// franchise reports whether a relation keeps a traversal inside one
+// franchise; character and other links tend to jump between franchises.
+func (k Kind) franchise() bool {
+	return k != Character && k != Other
+}
+
+// Title is one node of the graph.
+type Title struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name,omitempty"`
+	Format  string    `json:"kind,omitempty"`
+	AiredOn time.Time `json:"aired_on,omitzero"`
+}
+
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind Kind   `json:"relation"`
+}
+
+// Graph holds titles and directed relations between them. Importing an
+// edge also adds its inverse unless the dump states one explicitly.
+type Graph struct {
+	mu     sync.RWMutex
+	titles map[string]Title
+	edges  map[string]map[string]Kind
+}
+
+func New() *Graph {
+	return &Graph{
+		titles: make(map[string]Title),
+		edges:  make(map[string]map[string]Kind),
+	}
+}
+
+// Open loads a dump file; see Import for the format.
+func Open(path string) (*Graph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	g := New()
+	if _, err := g.Import(f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return g, nil
+}
+
+// dumpEntry is one title in a dump. IDs may be numbers or strings and the
+// related list follows the Shikimori /related shape, with "anime_id" or a
+// nested "anime" object.
+type dumpEntry struct {
+	ID      json.Number `json:"id"`
+	Name    string      `json:"name"`
+	Kind    string      `json:"kind"`
+	AiredOn string      `json:"aired_on"`
+	Related []struct {
+		Relation string      `json:"relation"`
+		AnimeID  json.Number `json:"anime_id"`
+		Anime    *struct {
+			ID   json.Number `json:"id"`
+			Name string      `json:"name"`
+		} `json:"anime"`
+	} `json:"related"`
+}
+
+// Import reads a dump, either a JSON array of titles or one title per line,
+// and returns how many titles it contained.
+func (g *Graph) Import(r io.Reader) (int, error) {
+	br := bufio.NewReader(r)
+	var entries []dumpEntry
+	first, err := peekNonSpace(br)
+	if err != nil {
+		return 0, err
+	}
+	dec := json.NewDecoder(br)
+	dec.UseNumber()
+	if first == '[' {
+		if err := dec.Decode(&entries); err != nil {
+			return 0, err
+		}
+	} else {
+		for {
+			var e dumpEntry
+			if err := dec.Decode(&e); err == io.EOF {
+				break
+			} else if err != nil {
+				return 0, err
+			}
+			entries = append(entries, e)
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	explicit := make(map[[2]string]bool)
+	for _, e := range entries {
+		id := e.ID.String()
+		if id == "" {
+			continue
+		}
+		t := g.titles[id]
+		t.ID = id
+		if e.Name != "" {
+			t.Name = e.Name
+		}
+		if e.Kind != "" {
+			t.Format = e.Kind
+		}
+		if d, err := time.Parse(time.DateOnly, e.AiredOn); err == nil {
+			t.AiredOn = d
+		}
+		g.titles[id] = t
+
+		for _, rel := range e.Related {
+			kind, ok := ParseKind(rel.Relation)
+			if !ok {
+				continue
+			}
+			to := rel.AnimeID.String()
+			if rel.Anime != nil {
+				to = rel.Anime.ID.String()
+				if _, seen := g.titles[to]; !seen && rel.Anime.Name != "" {
+					g.titles[to] = Title{ID: to, Name: rel.Anime.Name}
+				}
+			}
+			if to == "" || to == id {
+				continue
+			}
+			if _, seen := g.titles[to]; !seen {
+				g.titles[to] = Title{ID: to}
+			}
+			g.link(id, to, kind)
+			explicit[[2]string{id, to}] = true
+			if !explicit[[2]string{to, id}] {
+				g.link(to, id, inverse[kind])
+			}
+		}
+	}
+	return len(entries), nil
+}
+
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, br.UnreadByte()
+	}
+}
+
+// link must be called with mu held.
+func (g *Graph) link(from, to string, kind Kind) {
+	m := g.edges[from]
+	if m == nil {
+		m = make(map[string]Kind)
+		g.edges[from] = m
+	}
+	m[to] = kind
+}
+
+func (g *Graph) Len() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return len(g.titles)
+}
+
+// Franchise is the part of the graph reachable from one title.
+type Franchise struct {
+	// Titles in watch order.
+	Titles []Title
+	// Direct holds the relation of each title to the requested one, when
+	// they are linked directly.
+	Direct map[string]Kind
+	Edges  []Edge
+}
+
+// Franchise walks franchise relations from id, visiting at most limit
+// titles, and orders the result for watching: prequels before sequels,
+// side stories and summaries after the story they belong to, and release
+// date between titles that are otherwise unordered.
+func (g *Graph) Franchise(id string, limit int) (Franchise, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if _, ok := g.titles[id]; !ok {
+		return Franchise{}, false
+	}
+
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	for i := 0; i < len(queue) && len(seen) < limit; i++ {
+		for _, to := range sortedKeys(g.edges[queue[i]]) {
+			if seen[to] || !g.edges[queue[i]][to].franchise() || len(seen) >= limit {
+				continue
+			}
+			seen[to] = true
+			queue = append(queue, to)
+		}
+	}
+
+	f := Franchise{Direct: make(map[string]Kind)}
+	for to, kind := range g.edges[id] {
+		if seen[to] {
+			f.Direct[to] = kind
+		}
+	}
+
+	// after[a] lists titles to watch after a.
+	indeg := make(map[string]int, len(queue))
+	after := make(map[string][]string, len(queue))
+	for _, from := range queue {
+		for _, to := range sortedKeys(g.edges[from]) {
+			if !seen[to] {
+				continue
+			}
+			kind := g.edges[from][to]
+			f.Edges = append(f.Edges, Edge{From: from, To: to, Kind: kind})
+			switch kind {
+			case Sequel, SideStory, SpinOff, Summary:
+				after[from] = append(after[from], to)
+				indeg[to]++
+			}
+		}
+	}
+
+	earlier := func(a, b string) bool {
+		ta, tb := g.titles[a], g.titles[b]
+		if !ta.AiredOn.Equal(tb.AiredOn) {
+			if ta.AiredOn.IsZero() || tb.AiredOn.IsZero() {
+				return !ta.AiredOn.IsZero()
+			}
+			return ta.AiredOn.Before(tb.AiredOn)
+		}
+		return lessID(a, b)
+	}
+
+	var ready []string
+	for _, n := range queue {
+		if indeg[n] == 0 {
+			ready = append(ready, n)
+		}
+	}
+	done := make(map[string]bool, len(queue))
+	for len(done) < len(queue) {
+		if len(ready) == 0 {
+			// A cycle in the dump: release the earliest remaining title.
+			for _, n := range queue {
+				if !done[n] && (len(ready) == 0 || earlier(n, ready[0])) {
+					ready = []string{n}
+				}
+			}
+		}
+		sort.Slice(ready, func(i, j int) bool { return earlier(ready[i], ready[j]) })
+		n := ready[0]
+		ready = ready[1:]
+		if done[n] {
+			continue
+		}
+		done[n] = true
+		f.Titles = append(f.Titles, g.titles[n])
+		for _, m := range after[n] {
+			if indeg[m]--; indeg[m] == 0 && !done[m] {
+				ready = append(ready, m)
+			}
+		}
+	}
+	return f, true
+}
+
+func sortedKeys(m map[string]Kind) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Slice(out, func(i, j int) bool { return lessID(out[i], out[j]) })
+	return out
+}
+
+// lessID orders numeric IDs numerically and anything else as strings.
+func lessID(a, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		return na < nb
+	}
+	return a < b
+}
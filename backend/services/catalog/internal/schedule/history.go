@@ -0,0 +1,108 @@
+package schedule
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// Mark records when the catalog first saw a title reach an episode.
+type Mark struct {
+	Episode int       `json:"episode"`
+	SeenAt  time.Time `json:"seen_at"`
+}
+
+// History is the per-title episode timeline built by catalog syncs,
+// optionally backed by a JSON file.
+type History struct {
+	mu    sync.Mutex
+	path  string
+	dirty bool
+	marks map[string][]Mark
+}
+
+func NewHistory() *History {
+	return &History{marks: make(map[string][]Mark)}
+}
+
+func OpenHistory(path string) (*History, error) {
+	h := NewHistory()
+	h.path = path
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &h.marks); err != nil {
+		return nil, err
+	}
+	if h.marks == nil {
+		h.marks = make(map[string][]Mark)
+	}
+	return h, nil
+}
+
+// Record notes that key has reached episode at time at. Only progress is
+// recorded; seeing the same or an older episode again is a no-op.
+func (h *History) Record(key string, episode int, at time.Time) {
+	if episode <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ms := h.marks[key]
+	if n := len(ms); n > 0 && ms[n-1].Episode >= episode {
+		return
+	}
+	h.marks[key] = append(ms, Mark{Episode: episode, SeenAt: at.UTC()})
+	h.dirty = true
+}
+
+// Migrate moves the marks of the first of legacy that has any to key,
+// unless key already has its own.
+func (h *History) Migrate(key string, legacy []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.marks[key]; ok {
+		return
+	}
+	for _, k := range legacy {
+		if ms, ok := h.marks[k]; ok {
+			h.marks[key] = ms
+			delete(h.marks, k)
+			h.dirty = true
+			return
+		}
+	}
+}
+
+func (h *History) Marks(key string) []Mark {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Mark(nil), h.marks[key]...)
+}
+
+// Flush writes the history to its file if it changed.
+func (h *History) Flush() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.path == "" || !h.dirty {
+		return nil
+	}
+	b, err := json.Marshal(h.marks)
+	if err != nil {
+		return err
+	}
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, h.path); err != nil {
+		return err
+	}
+	h.dirty = false
+	return nil
+}
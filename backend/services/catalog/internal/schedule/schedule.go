@@ -0,0 +1,115 @@
+// Package schedule estimates when ongoing titles air their next episode and
+// arranges them into a weekly calendar.
+package schedule
+
+import (
+	"sort"
+	"time"
+)
+
+// defaultInterval is assumed when a title's history is too short to
+// measure its cadence.
+const defaultInterval = 7 * 24 * time.Hour
+
+type Title struct {
+	Key           string
+	LastEpisode   int
+	EpisodesTotal int
+	// NextEpisodeAt comes from Kodik material data and wins over estimates.
+	NextEpisodeAt time.Time
+}
+
+type Item struct {
+	Title
+	NextEpisode int
+	ExpectedAt  time.Time
+	// Estimated is set when ExpectedAt was derived from History.
+	Estimated bool
+}
+
+type Day struct {
+	Date  time.Time
+	Items []Item
+}
+
+// interval is the median time between consecutive episodes in marks.
+func interval(marks []Mark) time.Duration {
+	var gaps []time.Duration
+	for i := 1; i < len(marks); i++ {
+		eps := marks[i].Episode - marks[i-1].Episode
+		if eps <= 0 {
+			continue
+		}
+		gaps = append(gaps, marks[i].SeenAt.Sub(marks[i-1].SeenAt)/time.Duration(eps))
+	}
+	if len(gaps) == 0 {
+		return defaultInterval
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return gaps[len(gaps)/2]
+}
+
+// Expect estimates the next episode of t. It reports false when there is
+// neither an announced date nor any history to go on, or the title is
+// already complete.
+func Expect(t Title, h *History, now time.Time) (Item, bool) {
+	it := Item{Title: t}
+	marks := h.Marks(t.Key)
+	if n := len(marks); n > 0 && marks[n-1].Episode > it.LastEpisode {
+		it.LastEpisode = marks[n-1].Episode
+	}
+	if t.EpisodesTotal > 0 && it.LastEpisode >= t.EpisodesTotal {
+		return Item{}, false
+	}
+	it.NextEpisode = it.LastEpisode + 1
+
+	if !t.NextEpisodeAt.IsZero() {
+		it.ExpectedAt = t.NextEpisodeAt
+		return it, true
+	}
+	if len(marks) == 0 {
+		return Item{}, false
+	}
+	step := interval(marks)
+	if step < time.Hour {
+		step = defaultInterval
+	}
+	next := marks[len(marks)-1].SeenAt.Add(step)
+	// An overdue episode is expected at the next slot of its cadence.
+	for next.Before(now) {
+		next = next.Add(step)
+	}
+	it.ExpectedAt = next
+	it.Estimated = true
+	return it, true
+}
+
+// Week lays out items expected in the seven days starting at now's date in
+// loc. Items outside that window are dropped.
+func Week(items []Item, now time.Time, loc *time.Location) []Day {
+	local := now.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	days := make([]Day, 7)
+	for i := range days {
+		days[i].Date = start.AddDate(0, 0, i)
+	}
+	end := start.AddDate(0, 0, len(days))
+	for _, it := range items {
+		at := it.ExpectedAt.In(loc)
+		if at.Before(start) || !at.Before(end) {
+			continue
+		}
+		for d := len(days) - 1; d >= 0; d-- {
+			if !at.Before(days[d].Date) {
+				days[d].Items = append(days[d].Items, it)
+				break
+			}
+		}
+	}
+	for i := range days {
+		sort.Slice(days[i].Items, func(a, b int) bool {
+			return days[i].Items[a].ExpectedAt.Before(days[i].Items[b].ExpectedAt)
+		})
+	}
+	return days
+}
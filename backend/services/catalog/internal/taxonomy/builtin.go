@@ -0,0 +1,61 @@
+package taxonomy
+
+func g(slug string, kind Kind, en, ru string, aliases ...string) Genre {
+	return Genre{Slug: slug, Kind: kind, Names: map[string]string{"en": en, "ru": ru}, Aliases: aliases}
+}
+
+// builtin covers Shikimori anime genres and the Kinopoisk genres that show
+// up on anime. Aliases hold the other spellings seen in Kodik data.
+func builtin() []Genre {
+	return []Genre{
+		g("action", KindGenre, "Action", "Экшен", "боевик", "экшн"),
+		g("adventure", KindGenre, "Adventure", "Приключения"),
+		g("comedy", KindGenre, "Comedy", "Комедия"),
+		g("drama", KindGenre, "Drama", "Драма"),
+		g("fantasy", KindGenre, "Fantasy", "Фэнтези", "фентези"),
+		g("sci-fi", KindGenre, "Sci-Fi", "Фантастика", "science fiction", "научная фантастика"),
+		g("romance", KindGenre, "Romance", "Романтика", "мелодрама"),
+		g("slice-of-life", KindGenre, "Slice of Life", "Повседневность"),
+		g("mystery", KindGenre, "Mystery", "Детектив"),
+		g("horror", KindGenre, "Horror", "Ужасы"),
+		g("suspense", KindGenre, "Suspense", "Триллер", "thriller"),
+		g("psychological", KindGenre, "Psychological", "Психологическое", "психологический"),
+		g("supernatural", KindGenre, "Supernatural", "Сверхъестественное"),
+		g("sports", KindGenre, "Sports", "Спорт", "sport", "спортивный"),
+		g("mecha", KindGenre, "Mecha", "Меха"),
+		g("music", KindGenre, "Music", "Музыка", "мюзикл", "музыкальный"),
+		g("crime", KindGenre, "Crime", "Криминал"),
+		g("family", KindGenre, "Family", "Семейный"),
+		g("military", KindGenre, "Military", "Военное", "военный", "war"),
+		g("historical", KindGenre, "Historical", "Исторический", "история", "history"),
+		g("ecchi", KindGenre, "Ecchi", "Этти"),
+
+		g("shounen", KindDemographic, "Shounen", "Сёнен", "shonen", "сенэн"),
+		g("seinen", KindDemographic, "Seinen", "Сэйнэн", "сейнен"),
+		g("shoujo", KindDemographic, "Shoujo", "Сёдзё", "shojo", "седзе"),
+		g("josei", KindDemographic, "Josei", "Дзёсэй", "дзесей"),
+		g("kids", KindDemographic, "Kids", "Детское", "детский", "детские"),
+
+		g("school", KindTheme, "School", "Школа"),
+		g("isekai", KindTheme, "Isekai", "Исекай"),
+		g("magic", KindTheme, "Magic", "Магия"),
+		g("space", KindTheme, "Space", "Космос"),
+		g("parody", KindTheme, "Parody", "Пародия"),
+		g("samurai", KindTheme, "Samurai", "Самураи"),
+		g("super-power", KindTheme, "Super Power", "Супер сила", "суперсила", "superpower"),
+		g("gaming", KindTheme, "Gaming", "Игры", "game", "games"),
+		g("martial-arts", KindTheme, "Martial Arts", "Боевые искусства"),
+		g("vampire", KindTheme, "Vampire", "Вампиры"),
+		g("demons", KindTheme, "Demons", "Демоны"),
+		g("harem", KindTheme, "Harem", "Гарем"),
+		g("police", KindTheme, "Police", "Полиция"),
+		g("gourmet", KindTheme, "Gourmet", "Гурман"),
+		g("cars", KindTheme, "Racing", "Машины", "racing"),
+		g("idols", KindTheme, "Idols", "Идолы", "идолы (жен.)", "идолы (муж.)"),
+		g("workplace", KindTheme, "Workplace", "Работа"),
+
+		g("anime", KindTag, "Anime", "Аниме"),
+		g("cartoon", KindTag, "Cartoon", "Мультфильм", "мультфильмы"),
+		g("short", KindTag, "Short", "Короткометражка", "короткометражный"),
+	}
+}
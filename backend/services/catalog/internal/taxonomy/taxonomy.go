@@ -0,0 +1,150 @@
+// Package taxonomy maps the genre strings found in Kodik data, which mix
+// Kinopoisk and Shikimori naming in Russian and English, onto one set of
+// genres, demographics and themes with localized names.
+package taxonomy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+type Kind string
+
+const (
+	KindGenre       Kind = "genre"
+	KindDemographic Kind = "demographic"
+	KindTheme       Kind = "theme"
+	// KindTag covers labels like "anime" that describe the format.
+	KindTag Kind = "tag"
+)
+
+type Genre struct {
+	Slug    string            `json:"slug"`
+	Kind    Kind              `json:"kind"`
+	Names   map[string]string `json:"names"`
+	Aliases []string          `json:"aliases,omitempty"`
+}
+
+// Name returns the genre's name in locale, falling back to English and
+// then the slug.
+func (g Genre) Name(locale string) string {
+	if n := g.Names[locale]; n != "" {
+		return n
+	}
+	if n := g.Names["en"]; n != "" {
+		return n
+	}
+	return g.Slug
+}
+
+type Taxonomy struct {
+	genres []Genre
+	bySlug map[string]int
+	lookup map[string]string
+}
+
+func normalize(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.ReplaceAll(s, "ё", "е")
+	s = strings.NewReplacer("-", " ", "_", " ").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// New builds a taxonomy. Later genres with the same slug extend earlier
+// ones: names are overwritten per locale and aliases are added.
+func New(genres []Genre) *Taxonomy {
+	t := &Taxonomy{bySlug: make(map[string]int), lookup: make(map[string]string)}
+	for _, g := range genres {
+		i, ok := t.bySlug[g.Slug]
+		if !ok {
+			i = len(t.genres)
+			t.bySlug[g.Slug] = i
+			t.genres = append(t.genres, Genre{Slug: g.Slug, Kind: g.Kind, Names: make(map[string]string)})
+		}
+		cur := &t.genres[i]
+		if g.Kind != "" {
+			cur.Kind = g.Kind
+		}
+		for loc, n := range g.Names {
+			cur.Names[loc] = n
+		}
+		cur.Aliases = append(cur.Aliases, g.Aliases...)
+
+		t.lookup[normalize(g.Slug)] = g.Slug
+		for _, n := range g.Names {
+			t.lookup[normalize(n)] = g.Slug
+		}
+		for _, a := range g.Aliases {
+			t.lookup[normalize(a)] = g.Slug
+		}
+	}
+	return t
+}
+
+// Load extends the built-in taxonomy with genres from a JSON file holding
+// {"genres": [...]}.
+func Load(path string) (*Taxonomy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f struct {
+		Genres []Genre `json:"genres"`
+	}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return New(append(builtin(), f.Genres...)), nil
+}
+
+func Builtin() *Taxonomy {
+	return New(builtin())
+}
+
+// Resolve returns the slug for a raw genre string, a slug or an alias.
+func (t *Taxonomy) Resolve(raw string) (string, bool) {
+	slug, ok := t.lookup[normalize(raw)]
+	return slug, ok
+}
+
+// Normalize maps raw genre strings to slugs, dropping unknown and repeated
+// ones and keeping first-seen order.
+func (t *Taxonomy) Normalize(raw ...[]string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, list := range raw {
+		for _, r := range list {
+			slug, ok := t.Resolve(r)
+			if !ok || seen[slug] {
+				continue
+			}
+			seen[slug] = true
+			out = append(out, slug)
+		}
+	}
+	return out
+}
+
+func (t *Taxonomy) Get(slug string) (Genre, bool) {
+	i, ok := t.bySlug[slug]
+	if !ok {
+		return Genre{}, false
+	}
+	return t.genres[i], true
+}
+
+// All returns every genre ordered by kind, then slug.
+func (t *Taxonomy) All() []Genre {
+	order := map[Kind]int{KindGenre: 0, KindDemographic: 1, KindTheme: 2, KindTag: 3}
+	out := append([]Genre(nil), t.genres...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return order[out[i].Kind] < order[out[j].Kind]
+		}
+		return out[i].Slug < out[j].Slug
+	})
+	return out
+}
@@ -0,0 +1,266 @@
+// Package config loads the settings of every AniFlow service. Values come
+// from built-in defaults, then an optional YAML or TOML file, then
+// environment variables, then command-line flags, each overriding the
+// one before. The result is validated for the service being started.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Service names a binary; it selects which sections are validated and
+// which flags are offered.
+type Service string
+
+const (
+	CatalogService Service = "catalog"
+	LibraryService Service = "library"
+	GatewayService Service = "gateway"
+)
+
+// sections lists the parts of Config each service reads.
+var sections = map[Service][]string{
+	CatalogService: {"log", "kodik", "catalog", "webhooks"},
+	LibraryService: {"log", "library", "webhooks"},
+	GatewayService: {"log", "gateway"},
+}
+
+// Duration is a time.Duration written as "15m" or "1h30m" in files, env
+// and flags.
+type Duration time.Duration
+
+func (d Duration) String() string { return time.Duration(d).String() }
+
+func (d Duration) MarshalText() ([]byte, error) { return []byte(d.String()), nil }
+
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+type Config struct {
+	Log      Log      `yaml:"log" toml:"log"`
+	Kodik    Kodik    `yaml:"kodik" toml:"kodik"`
+	Catalog  Catalog  `yaml:"catalog" toml:"catalog"`
+	Library  Library  `yaml:"library" toml:"library"`
+	Gateway  Gateway  `yaml:"gateway" toml:"gateway"`
+	Webhooks Webhooks `yaml:"webhooks" toml:"webhooks"`
+}
+
+type Log struct {
+	// Level is debug, info, warn or error.
+	Level string `yaml:"level" toml:"level"`
+	// Format is json or text.
+	Format string `yaml:"format" toml:"format"`
+}
+
+type Kodik struct {
+	Token   string `yaml:"token" toml:"token"`
+	BaseURL string `yaml:"base_url" toml:"base_url"`
+	// Mirrors are tried in order when BaseURL fails or answers with a 5xx.
+	Mirrors []string `yaml:"mirrors" toml:"mirrors"`
+	// Proxy is an http(s) proxy URL; empty falls back to HTTPS_PROXY.
+	Proxy     string   `yaml:"proxy" toml:"proxy"`
+	UserAgent string   `yaml:"user_agent" toml:"user_agent"`
+	Timeout   Duration `yaml:"timeout" toml:"timeout"`
+	// PingInterval is how often the catalog checks Kodik for its health
+	// status.
+	PingInterval Duration `yaml:"ping_interval" toml:"ping_interval"`
+	// CassetteMode is record, to save Kodik traffic to CassetteDir, or
+	// replay, to serve it from there without the network or a token.
+	CassetteMode string `yaml:"cassette_mode" toml:"cassette_mode"`
+	CassetteDir  string `yaml:"cassette_dir" toml:"cassette_dir"`
+}
+
+type Catalog struct {
+	Port        int    `yaml:"port" toml:"port"`
+	MetricsPort int    `yaml:"metrics_port" toml:"metrics_port"`
+	LibraryAddr string `yaml:"library_addr" toml:"library_addr"`
+
+	// Files backing the catalog's stores; empty keeps them in memory.
+	// The identity table is always persisted: libraries store AniFlow IDs,
+	// which would stop resolving if a restart re-issued them.
+	IndexFile     string `yaml:"index_file" toml:"index_file"`
+	IdentityFile  string `yaml:"identity_file" toml:"identity_file"`
+	OverridesFile string `yaml:"overrides_file" toml:"overrides_file"`
+	TaxonomyFile  string `yaml:"taxonomy_file" toml:"taxonomy_file"`
+	RelationsDump string `yaml:"relations_dump" toml:"relations_dump"`
+	NotifyFile    string `yaml:"notify_file" toml:"notify_file"`
+	HistoryFile   string `yaml:"history_file" toml:"history_file"`
+
+	NotifyInterval Duration `yaml:"notify_interval" toml:"notify_interval"`
+	SyncInterval   Duration `yaml:"sync_interval" toml:"sync_interval"`
+
+	CacheTTL  Duration `yaml:"cache_ttl" toml:"cache_ttl"`
+	CacheSize int      `yaml:"cache_size" toml:"cache_size"`
+
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+}
+
+type Library struct {
+	Port            int      `yaml:"port" toml:"port"`
+	CatalogAddr     string   `yaml:"catalog_addr" toml:"catalog_addr"`
+	SimilarInterval Duration `yaml:"similar_interval" toml:"similar_interval"`
+	// MaxImportBytes bounds an uploaded watchlist export.
+	MaxImportBytes  int      `yaml:"max_import_bytes" toml:"max_import_bytes"`
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+}
+
+type Gateway struct {
+	Port        int    `yaml:"port" toml:"port"`
+	CatalogAddr string `yaml:"catalog_addr" toml:"catalog_addr"`
+	LibraryAddr string `yaml:"library_addr" toml:"library_addr"`
+	// AdminToken enables the /v1/admin routes when set.
+	AdminToken     string `yaml:"admin_token" toml:"admin_token"`
+	MaxImportBytes int    `yaml:"max_import_bytes" toml:"max_import_bytes"`
+	MaxExportBytes int    `yaml:"max_export_bytes" toml:"max_export_bytes"`
+	// DrainDelay is how long /readyz fails on shutdown before the
+	// listener closes.
+	DrainDelay      Duration `yaml:"drain_delay" toml:"drain_delay"`
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+}
+
+type Webhooks struct {
+	// File lists the endpoints; none are called when it is empty.
+	File           string `yaml:"file" toml:"file"`
+	DeadLetterFile string `yaml:"dead_letter_file" toml:"dead_letter_file"`
+}
+
+// Default returns the settings used when nothing overrides them.
+func Default() Config {
+	return Config{
+		Log: Log{Level: "info", Format: "json"},
+		Kodik: Kodik{
+			BaseURL:      "https://kodikapi.com",
+			Timeout:      Duration(10 * time.Second),
+			PingInterval: Duration(30 * time.Second),
+		},
+		Catalog: Catalog{
+			Port:            50051,
+			MetricsPort:     9090,
+			LibraryAddr:     "localhost:50052",
+			IdentityFile:    "identities.json",
+			NotifyInterval:  Duration(15 * time.Minute),
+			SyncInterval:    Duration(time.Hour),
+			CacheTTL:        Duration(10 * time.Minute),
+			CacheSize:       5000,
+			ShutdownTimeout: Duration(20 * time.Second),
+		},
+		Library: Library{
+			Port:            50052,
+			CatalogAddr:     "localhost:50051",
+			SimilarInterval: Duration(time.Hour),
+			MaxImportBytes:  32 << 20,
+			ShutdownTimeout: Duration(20 * time.Second),
+		},
+		Gateway: Gateway{
+			Port:            8080,
+			CatalogAddr:     "localhost:50051",
+			LibraryAddr:     "localhost:50052",
+			MaxImportBytes:  32 << 20,
+			MaxExportBytes:  64 << 20,
+			DrainDelay:      Duration(5 * time.Second),
+			ShutdownTimeout: Duration(20 * time.Second),
+		},
+	}
+}
+
+// Validate reports every invalid setting service relies on.
+func (c *Config) Validate(service Service) error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+	port := func(key string, p int) {
+		check(p > 0 && p < 65536, key, "port %d out of range", p)
+	}
+	positive := func(key string, d Duration) {
+		check(d > 0, key, "must be positive, got %s", d)
+	}
+	addr := func(key, a string) {
+		check(a != "" && strings.Contains(a, ":"), key, "want host:port, got %q", a)
+	}
+	httpURL := func(key, s string) {
+		u, err := url.Parse(s)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			key, "want an http(s) URL, got %q", s)
+	}
+
+	uses := make(map[string]bool)
+	for _, s := range sections[service] {
+		uses[s] = true
+	}
+	if len(uses) == 0 {
+		return fmt.Errorf("unknown service %q", service)
+	}
+
+	if uses["log"] {
+		switch strings.ToLower(c.Log.Level) {
+		case "debug", "info", "warn", "error":
+		default:
+			check(false, "log.level", "want debug, info, warn or error, got %q", c.Log.Level)
+		}
+		switch strings.ToLower(c.Log.Format) {
+		case "json", "text":
+		default:
+			check(false, "log.format", "want json or text, got %q", c.Log.Format)
+		}
+	}
+	if uses["kodik"] {
+		switch c.Kodik.CassetteMode {
+		case "", "record", "replay":
+		default:
+			check(false, "kodik.cassette_mode", "want record or replay, got %q", c.Kodik.CassetteMode)
+		}
+		check(c.Kodik.CassetteMode == "" || c.Kodik.CassetteDir != "", "kodik.cassette_dir", "required with kodik.cassette_mode")
+		check(c.Kodik.Token != "" || c.Kodik.CassetteMode == "replay", "kodik.token", "required (KODIK_API_TOKEN)")
+		httpURL("kodik.base_url", c.Kodik.BaseURL)
+		for _, m := range c.Kodik.Mirrors {
+			httpURL("kodik.mirrors", m)
+		}
+		if c.Kodik.Proxy != "" {
+			httpURL("kodik.proxy", c.Kodik.Proxy)
+		}
+		positive("kodik.timeout", c.Kodik.Timeout)
+		positive("kodik.ping_interval", c.Kodik.PingInterval)
+	}
+	if uses["catalog"] {
+		port("catalog.port", c.Catalog.Port)
+		port("catalog.metrics_port", c.Catalog.MetricsPort)
+		check(c.Catalog.MetricsPort != c.Catalog.Port, "catalog.metrics_port", "must differ from catalog.port")
+		addr("catalog.library_addr", c.Catalog.LibraryAddr)
+		check(c.Catalog.IdentityFile != "", "catalog.identity_file", "required")
+		positive("catalog.notify_interval", c.Catalog.NotifyInterval)
+		positive("catalog.sync_interval", c.Catalog.SyncInterval)
+		positive("catalog.cache_ttl", c.Catalog.CacheTTL)
+		check(c.Catalog.CacheSize > 0, "catalog.cache_size", "must be positive, got %d", c.Catalog.CacheSize)
+		positive("catalog.shutdown_timeout", c.Catalog.ShutdownTimeout)
+	}
+	if uses["library"] {
+		port("library.port", c.Library.Port)
+		addr("library.catalog_addr", c.Library.CatalogAddr)
+		positive("library.similar_interval", c.Library.SimilarInterval)
+		check(c.Library.MaxImportBytes > 0, "library.max_import_bytes", "must be positive, got %d", c.Library.MaxImportBytes)
+		positive("library.shutdown_timeout", c.Library.ShutdownTimeout)
+	}
+	if uses["gateway"] {
+		port("gateway.port", c.Gateway.Port)
+		addr("gateway.catalog_addr", c.Gateway.CatalogAddr)
+		addr("gateway.library_addr", c.Gateway.LibraryAddr)
+		check(c.Gateway.MaxImportBytes > 0, "gateway.max_import_bytes", "must be positive, got %d", c.Gateway.MaxImportBytes)
+		check(c.Gateway.MaxExportBytes > 0, "gateway.max_export_bytes", "must be positive, got %d", c.Gateway.MaxExportBytes)
+		check(c.Gateway.DrainDelay >= 0, "gateway.drain_delay", "must not be negative, got %s", c.Gateway.DrainDelay)
+		positive("gateway.shutdown_timeout", c.Gateway.ShutdownTimeout)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv blanks every variable Load reads, which it treats as unset.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("CONFIG_FILE", "")
+	for _, f := range fields {
+		t.Setenv(f.env, "")
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	yamlFile := "log:\n  level: debug\ngateway:\n  port: 1000\n  drain_delay: 2s\n"
+	tomlFile := "[log]\nlevel = \"debug\"\n\n[gateway]\nport = 1000\ndrain_delay = \"2s\"\n"
+
+	for _, tc := range []struct {
+		name  string
+		file  string
+		env   map[string]string
+		args  []string
+		port  int
+		level string
+		drain time.Duration
+	}{
+		{name: "defaults", port: 8080, level: "info", drain: 5 * time.Second},
+		{name: "yaml file", file: "cfg.yaml:" + yamlFile, port: 1000, level: "debug", drain: 2 * time.Second},
+		{name: "toml file", file: "cfg.toml:" + tomlFile, port: 1000, level: "debug", drain: 2 * time.Second},
+		{
+			name:  "env over file",
+			file:  "cfg.yaml:" + yamlFile,
+			env:   map[string]string{"GATEWAY_PORT": "2000"},
+			port:  2000,
+			level: "debug",
+			drain: 2 * time.Second,
+		},
+		{
+			name:  "flags over env",
+			file:  "cfg.yaml:" + yamlFile,
+			env:   map[string]string{"GATEWAY_PORT": "2000", "LOG_LEVEL": "warn"},
+			args:  []string{"-gateway.port=3000"},
+			port:  3000,
+			level: "warn",
+			drain: 2 * time.Second,
+		},
+		{
+			name:  "config file from env",
+			file:  "CONFIG_FILE=cfg.yaml:" + yamlFile,
+			port:  1000,
+			level: "debug",
+			drain: 2 * time.Second,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clearEnv(t)
+			args := tc.args
+			if tc.file != "" {
+				name, content, _ := strings.Cut(tc.file, ":")
+				if env, ok := strings.CutPrefix(name, "CONFIG_FILE="); ok {
+					t.Setenv("CONFIG_FILE", writeFile(t, env, content))
+				} else {
+					args = append([]string{"-config", writeFile(t, name, content)}, args...)
+				}
+			}
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			c, err := Load(GatewayService, args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.Gateway.Port != tc.port || c.Log.Level != tc.level || time.Duration(c.Gateway.DrainDelay) != tc.drain {
+				t.Errorf("port %d, level %s, drain %s; want %d, %s, %s",
+					c.Gateway.Port, c.Log.Level, c.Gateway.DrainDelay, tc.port, tc.level, tc.drain)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	for _, tc := range []struct {
+		name, content string
+	}{
+		{"cfg.yaml", "gateway:\n  prot: 1000\n"},
+		{"cfg.yml", "gatway:\n  port: 1000\n"},
+		{"cfg.toml", "[gateway]\nprot = 1000\n"},
+		{"cfg.toml", "[gatway]\nport = 1000\n"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clearEnv(t)
+			path := writeFile(t, tc.name, tc.content)
+			_, err := Load(GatewayService, []string{"-config", path})
+			if err == nil || !strings.Contains(err.Error(), path) {
+				t.Errorf("err = %v, want the unknown key in %s rejected", err, tc.name)
+			}
+		})
+	}
+
+	clearEnv(t)
+	path := writeFile(t, "cfg.json", "{}")
+	if _, err := Load(GatewayService, []string{"-config", path}); err == nil {
+		t.Error("loaded a .json config file")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		service Service
+		edit    func(*Config)
+		want    []string
+	}{
+		{name: "catalog defaults need a token", service: CatalogService, want: []string{"kodik.token"}},
+		{name: "catalog", service: CatalogService, edit: func(c *Config) { c.Kodik.Token = "t" }},
+		{
+			name:    "catalog replay needs no token",
+			service: CatalogService,
+			edit: func(c *Config) {
+				c.Kodik.CassetteMode = "replay"
+				c.Kodik.CassetteDir = "testdata"
+			},
+		},
+		{
+			name:    "catalog errors are all listed",
+			service: CatalogService,
+			edit: func(c *Config) {
+				c.Kodik.Token = "t"
+				c.Kodik.BaseURL = "kodikapi.com"
+				c.Kodik.CassetteMode = "rewind"
+				c.Catalog.MetricsPort = c.Catalog.Port
+				c.Catalog.IdentityFile = ""
+				c.Catalog.CacheSize = 0
+			},
+			want: []string{"kodik.cassette_mode", "kodik.cassette_dir", "kodik.base_url", "catalog.metrics_port", "catalog.identity_file", "catalog.cache_size"},
+		},
+		{name: "library", service: LibraryService},
+		{
+			name:    "library errors are all listed",
+			service: LibraryService,
+			edit: func(c *Config) {
+				c.Log.Format = "xml"
+				c.Library.CatalogAddr = "catalog"
+				c.Library.MaxImportBytes = 0
+			},
+			want: []string{"log.format", "library.catalog_addr", "library.max_import_bytes"},
+		},
+		{name: "gateway ignores kodik", service: GatewayService, edit: func(c *Config) { c.Kodik.BaseURL = "" }},
+		{
+			name:    "gateway",
+			service: GatewayService,
+			edit: func(c *Config) {
+				c.Gateway.Port = 70000
+				c.Gateway.DrainDelay = -1
+				c.Gateway.ShutdownTimeout = 0
+			},
+			want: []string{"gateway.port", "gateway.drain_delay", "gateway.shutdown_timeout"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Default()
+			if tc.edit != nil {
+				tc.edit(&c)
+			}
+			err := c.Validate(tc.service)
+			if len(tc.want) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected errors:\n%v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("no errors, want %v", tc.want)
+			}
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tc.want) {
+				t.Errorf("got %d errors, want %d:\n%v", len(lines), len(tc.want), err)
+			}
+			for i, key := range tc.want {
+				if i < len(lines) && !strings.HasPrefix(lines[i], key+": ") {
+					t.Errorf("error %d = %q, want one about %s", i, lines[i], key)
+				}
+			}
+		})
+	}
+
+	if err := (&Config{}).Validate("search"); err == nil {
+		t.Error("validated an unknown service")
+	}
+}
+
+func TestLoadExitStatus(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{name: "help", args: []string{"-help"}, code: 0, stderr: "-gateway.port"},
+		{name: "unknown flag", args: []string{"-gateway.prot=1"}, code: 2, stderr: "flag provided but not defined"},
+		{name: "other service's flag", args: []string{"-catalog.port=1"}, code: 2, stderr: "flag provided but not defined"},
+		{name: "bad value", args: []string{"-gateway.port=http"}, code: 2, stderr: "invalid value"},
+		{name: "invalid config", args: []string{"-gateway.port=0"}, code: 2, stderr: "invalid gateway config:\ngateway.port"},
+		{name: "missing file", args: []string{"-config=missing.yaml"}, code: 2, stderr: "gateway: open missing.yaml"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clearEnv(t)
+			var stderr bytes.Buffer
+			c, code := load(GatewayService, tc.args, &stderr)
+			if c != nil || code != tc.code {
+				t.Errorf("got config %v and status %d, want status %d", c != nil, code, tc.code)
+			}
+			if !strings.Contains(stderr.String(), tc.stderr) {
+				t.Errorf("stderr %q does not mention %q", stderr.String(), tc.stderr)
+			}
+		})
+	}
+
+	clearEnv(t)
+	var stderr bytes.Buffer
+	if c, code := load(GatewayService, []string{"-gateway.port=9000"}, &stderr); c == nil || code != 0 || c.Gateway.Port != 9000 {
+		t.Errorf("valid flags: config %v, status %d, stderr %q", c != nil, code, stderr.String())
+	}
+}
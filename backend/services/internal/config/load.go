@@ -0,0 +1,271 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// field binds one setting to its environment variable and flag. The flag
+// is named after the key, e.g. -catalog.port.
+type field struct {
+	key    string
+	env    string
+	usage  string
+	secret bool
+	value  func(c *Config) flag.Value
+}
+
+func (f field) section() string {
+	return f.key[:strings.IndexByte(f.key, '.')]
+}
+
+type stringValue struct{ p *string }
+
+func (v stringValue) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return *v.p
+}
+func (v stringValue) Set(s string) error { *v.p = s; return nil }
+
+type intValue struct{ p *int }
+
+func (v intValue) String() string {
+	if v.p == nil {
+		return "0"
+	}
+	return strconv.Itoa(*v.p)
+}
+func (v intValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("not an integer: %q", s)
+	}
+	*v.p = n
+	return nil
+}
+
+// listValue is a comma-separated list; each use of the flag replaces it.
+type listValue struct{ p *[]string }
+
+func (v listValue) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return strings.Join(*v.p, ",")
+}
+func (v listValue) Set(s string) error {
+	*v.p = nil
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			*v.p = append(*v.p, e)
+		}
+	}
+	return nil
+}
+
+type durationValue struct{ p *Duration }
+
+func (v durationValue) String() string {
+	if v.p == nil {
+		return "0s"
+	}
+	return v.p.String()
+}
+func (v durationValue) Set(s string) error { return v.p.UnmarshalText([]byte(s)) }
+
+// secretValue keeps a value out of -help output.
+type secretValue struct{ flag.Value }
+
+func (secretValue) String() string { return "" }
+
+func str(get func(*Config) *string) func(*Config) flag.Value {
+	return func(c *Config) flag.Value { return stringValue{get(c)} }
+}
+
+func num(get func(*Config) *int) func(*Config) flag.Value {
+	return func(c *Config) flag.Value { return intValue{get(c)} }
+}
+
+func list(get func(*Config) *[]string) func(*Config) flag.Value {
+	return func(c *Config) flag.Value { return listValue{get(c)} }
+}
+
+func dur(get func(*Config) *Duration) func(*Config) flag.Value {
+	return func(c *Config) flag.Value { return durationValue{get(c)} }
+}
+
+// fields lists every setting. Environment variable names predate this
+// package and are kept as they were.
+var fields = []field{
+	{key: "log.level", env: "LOG_LEVEL", usage: "log level: debug, info, warn or error", value: str(func(c *Config) *string { return &c.Log.Level })},
+	{key: "log.format", env: "LOG_FORMAT", usage: "log format: json or text", value: str(func(c *Config) *string { return &c.Log.Format })},
+
+	{key: "kodik.token", env: "KODIK_API_TOKEN", usage: "Kodik API token", secret: true, value: str(func(c *Config) *string { return &c.Kodik.Token })},
+	{key: "kodik.base_url", env: "KODIK_BASE_URL", usage: "Kodik API base URL", value: str(func(c *Config) *string { return &c.Kodik.BaseURL })},
+	{key: "kodik.mirrors", env: "KODIK_MIRRORS", usage: "comma-separated Kodik API mirrors tried after the base URL", value: list(func(c *Config) *[]string { return &c.Kodik.Mirrors })},
+	{key: "kodik.proxy", env: "KODIK_PROXY", usage: "HTTP(S) proxy for Kodik requests", value: str(func(c *Config) *string { return &c.Kodik.Proxy })},
+	{key: "kodik.user_agent", env: "KODIK_USER_AGENT", usage: "User-Agent sent to Kodik", value: str(func(c *Config) *string { return &c.Kodik.UserAgent })},
+	{key: "kodik.timeout", env: "KODIK_TIMEOUT", usage: "timeout per Kodik request", value: dur(func(c *Config) *Duration { return &c.Kodik.Timeout })},
+	{key: "kodik.cassette_mode", env: "KODIK_CASSETTE_MODE", usage: "record Kodik traffic to the cassette directory or replay it offline", value: str(func(c *Config) *string { return &c.Kodik.CassetteMode })},
+	{key: "kodik.cassette_dir", env: "KODIK_CASSETTE_DIR", usage: "directory of recorded Kodik exchanges", value: str(func(c *Config) *string { return &c.Kodik.CassetteDir })},
+	{key: "kodik.ping_interval", env: "KODIK_PING_INTERVAL", usage: "how often Kodik reachability is checked", value: dur(func(c *Config) *Duration { return &c.Kodik.PingInterval })},
+
+	{key: "catalog.port", env: "CATALOG_PORT", usage: "catalog gRPC port", value: num(func(c *Config) *int { return &c.Catalog.Port })},
+	{key: "catalog.metrics_port", env: "CATALOG_METRICS_PORT", usage: "catalog Prometheus port", value: num(func(c *Config) *int { return &c.Catalog.MetricsPort })},
+	{key: "catalog.library_addr", env: "LIBRARY_GRPC_ADDR", usage: "library gRPC address", value: str(func(c *Config) *string { return &c.Catalog.LibraryAddr })},
+	{key: "catalog.index_file", env: "CATALOG_INDEX_FILE", usage: "search index file", value: str(func(c *Config) *string { return &c.Catalog.IndexFile })},
+	{key: "catalog.identity_file", env: "CATALOG_IDENTITY_FILE", usage: "AniFlow identity table file", value: str(func(c *Config) *string { return &c.Catalog.IdentityFile })},
+	{key: "catalog.overrides_file", env: "CATALOG_OVERRIDES_FILE", usage: "merge overrides file", value: str(func(c *Config) *string { return &c.Catalog.OverridesFile })},
+	{key: "catalog.taxonomy_file", env: "CATALOG_TAXONOMY_FILE", usage: "extra genre taxonomy file", value: str(func(c *Config) *string { return &c.Catalog.TaxonomyFile })},
+	{key: "catalog.relations_dump", env: "CATALOG_RELATIONS_DUMP", usage: "franchise relations dump", value: str(func(c *Config) *string { return &c.Catalog.RelationsDump })},
+	{key: "catalog.notify_file", env: "CATALOG_NOTIFY_FILE", usage: "notification store file", value: str(func(c *Config) *string { return &c.Catalog.NotifyFile })},
+	{key: "catalog.history_file", env: "CATALOG_HISTORY_FILE", usage: "episode history file", value: str(func(c *Config) *string { return &c.Catalog.HistoryFile })},
+	{key: "catalog.notify_interval", env: "CATALOG_NOTIFY_INTERVAL", usage: "how often watched titles are re-checked", value: dur(func(c *Config) *Duration { return &c.Catalog.NotifyInterval })},
+	{key: "catalog.sync_interval", env: "CATALOG_SYNC_INTERVAL", usage: "how often ongoing titles are synced", value: dur(func(c *Config) *Duration { return &c.Catalog.SyncInterval })},
+	{key: "catalog.cache_ttl", env: "CATALOG_CACHE_TTL", usage: "anime cache TTL", value: dur(func(c *Config) *Duration { return &c.Catalog.CacheTTL })},
+	{key: "catalog.cache_size", env: "CATALOG_CACHE_SIZE", usage: "anime cache entries", value: num(func(c *Config) *int { return &c.Catalog.CacheSize })},
+	{key: "catalog.shutdown_timeout", env: "CATALOG_SHUTDOWN_TIMEOUT", usage: "how long RPCs may drain on shutdown", value: dur(func(c *Config) *Duration { return &c.Catalog.ShutdownTimeout })},
+
+	{key: "library.port", env: "LIBRARY_PORT", usage: "library gRPC port", value: num(func(c *Config) *int { return &c.Library.Port })},
+	{key: "library.catalog_addr", env: "CATALOG_GRPC_ADDR", usage: "catalog gRPC address", value: str(func(c *Config) *string { return &c.Library.CatalogAddr })},
+	{key: "library.similar_interval", env: "LIBRARY_SIMILAR_INTERVAL", usage: "how often similar titles are recomputed", value: dur(func(c *Config) *Duration { return &c.Library.SimilarInterval })},
+	{key: "library.max_import_bytes", env: "LIBRARY_MAX_IMPORT_BYTES", usage: "largest accepted watchlist export", value: num(func(c *Config) *int { return &c.Library.MaxImportBytes })},
+	{key: "library.shutdown_timeout", env: "LIBRARY_SHUTDOWN_TIMEOUT", usage: "how long RPCs may drain on shutdown", value: dur(func(c *Config) *Duration { return &c.Library.ShutdownTimeout })},
+
+	{key: "gateway.port", env: "GATEWAY_PORT", usage: "gateway HTTP port", value: num(func(c *Config) *int { return &c.Gateway.Port })},
+	{key: "gateway.catalog_addr", env: "CATALOG_GRPC_ADDR", usage: "catalog gRPC address", value: str(func(c *Config) *string { return &c.Gateway.CatalogAddr })},
+	{key: "gateway.library_addr", env: "LIBRARY_GRPC_ADDR", usage: "library gRPC address", value: str(func(c *Config) *string { return &c.Gateway.LibraryAddr })},
+	{key: "gateway.admin_token", env: "GATEWAY_ADMIN_TOKEN", usage: "bearer token for /v1/admin; empty disables it", secret: true, value: str(func(c *Config) *string { return &c.Gateway.AdminToken })},
+	{key: "gateway.max_import_bytes", env: "GATEWAY_MAX_IMPORT_BYTES", usage: "largest accepted watchlist export", value: num(func(c *Config) *int { return &c.Gateway.MaxImportBytes })},
+	{key: "gateway.max_export_bytes", env: "GATEWAY_MAX_EXPORT_BYTES", usage: "largest library export accepted from the library", value: num(func(c *Config) *int { return &c.Gateway.MaxExportBytes })},
+	{key: "gateway.drain_delay", env: "GATEWAY_DRAIN_DELAY", usage: "how long /readyz fails before shutdown", value: dur(func(c *Config) *Duration { return &c.Gateway.DrainDelay })},
+	{key: "gateway.shutdown_timeout", env: "GATEWAY_SHUTDOWN_TIMEOUT", usage: "how long requests may drain on shutdown", value: dur(func(c *Config) *Duration { return &c.Gateway.ShutdownTimeout })},
+
+	{key: "webhooks.file", env: "WEBHOOKS_FILE", usage: "webhook endpoints file", value: str(func(c *Config) *string { return &c.Webhooks.File })},
+	{key: "webhooks.dead_letter_file", env: "WEBHOOK_DEAD_LETTER_FILE", usage: "log of undeliverable webhooks", value: str(func(c *Config) *string { return &c.Webhooks.DeadLetterFile })},
+}
+
+// flagSet offers the flags of service's sections, bound to c.
+func flagSet(service Service, c *Config, configPath *string, output io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(string(service), flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(configPath, "config", *configPath, "YAML or TOML config file (CONFIG_FILE)")
+	uses := make(map[string]bool)
+	for _, s := range sections[service] {
+		uses[s] = true
+	}
+	for _, f := range fields {
+		if !uses[f.section()] {
+			continue
+		}
+		v := f.value(c)
+		if f.secret {
+			v = secretValue{v}
+		}
+		fs.Var(v, f.key, f.usage+" ("+f.env+")")
+	}
+	return fs
+}
+
+// Load builds the configuration of service from defaults, the file named
+// by -config or CONFIG_FILE, the environment and args, and validates it.
+func Load(service Service, args []string) (*Config, error) {
+	return loadArgs(service, args, os.Stderr)
+}
+
+// loadArgs is Load with flag errors and -help written to output.
+func loadArgs(service Service, args []string, output io.Writer) (*Config, error) {
+	if _, ok := sections[service]; !ok {
+		return nil, fmt.Errorf("unknown service %q", service)
+	}
+
+	// The first pass only finds the config file and rejects bad flags
+	// early; the values are applied after the file and env.
+	path := os.Getenv("CONFIG_FILE")
+	var scratch Config
+	if err := flagSet(service, &scratch, &path, output).Parse(args); err != nil {
+		return nil, err
+	}
+
+	c := Default()
+	if path != "" {
+		if err := loadFile(path, &c); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range fields {
+		if v, ok := os.LookupEnv(f.env); ok && v != "" {
+			if err := f.value(&c).Set(v); err != nil {
+				return nil, fmt.Errorf("%s: %w", f.env, err)
+			}
+		}
+	}
+	if err := flagSet(service, &c, &path, output).Parse(args); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(service); err != nil {
+		return nil, fmt.Errorf("invalid %s config:\n%w", service, err)
+	}
+	return &c, nil
+}
+
+// MustLoad loads the configuration of service from os.Args. Like a flag
+// set with ExitOnError, it exits with status 2 on bad input and 0 after
+// printing -help.
+func MustLoad(service Service) *Config {
+	c, code := load(service, os.Args[1:], os.Stderr)
+	if c == nil {
+		os.Exit(code)
+	}
+	return c
+}
+
+// load is MustLoad without exiting: when it returns no config, the returned
+// status is what the process should exit with.
+func load(service Service, args []string, stderr io.Writer) (*Config, int) {
+	c, err := loadArgs(service, args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil, 0
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", service, err)
+		return nil, 2
+	}
+	return c, 0
+}
+
+// loadFile decodes a .yaml, .yml or .toml file over c. Unknown keys are
+// errors so typos do not go unnoticed.
+func loadFile(path string, c *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalWithOptions(data, c, yaml.Strict())
+	case ".toml":
+		dec := toml.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(c)
+	default:
+		return fmt.Errorf("config file %s: want .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Package logging builds the services' slog loggers. Records carry the
+// request ID and trace ID found in their context, and the Kodik API token
+// is redacted from anything that looks like a URL or a token attribute.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Options select the level and output format. Level is one of debug,
+// info, warn or error; Format is json or text.
+type Options struct {
+	Level  string
+	Format string
+}
+
+// New returns a logger writing to w.
+func New(w io.Writer, opts Options) (*slog.Logger, error) {
+	var level slog.Level
+	if opts.Level != "" {
+		if err := level.UnmarshalText([]byte(opts.Level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q", opts.Level)
+		}
+	}
+	ho := &slog.HandlerOptions{Level: level, ReplaceAttr: redactAttr}
+	var h slog.Handler
+	switch strings.ToLower(opts.Format) {
+	case "", "json":
+		h = slog.NewJSONHandler(w, ho)
+	case "text":
+		h = slog.NewTextHandler(w, ho)
+	default:
+		return nil, fmt.Errorf("invalid log format %q", opts.Format)
+	}
+	return slog.New(contextHandler{h}), nil
+}
+
+// Setup builds a logger for service on stderr and makes it the default,
+// so the standard log package goes through it too.
+func Setup(service string, opts Options) (*slog.Logger, error) {
+	l, err := New(os.Stderr, opts)
+	if err != nil {
+		return nil, err
+	}
+	l = l.With("service", service)
+	slog.SetDefault(l)
+	return l, nil
+}
+
+// Fatal logs msg at error level and exits.
+func Fatal(l *slog.Logger, msg string, args ...any) {
+	l.Error(msg, args...)
+	os.Exit(1)
+}
+
+// tokenParam matches the value of a token query parameter, or of a bare
+// token=value pair.
+var tokenParam = regexp.MustCompile(`(\btoken=)[^&\s"]*`)
+
+// Redact masks token values in s.
+func Redact(s string) string {
+	if !strings.Contains(s, "token=") {
+		return s
+	}
+	return tokenParam.ReplaceAllString(s, "${1}REDACTED")
+}
+
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if strings.EqualFold(a.Key, "token") {
+		return slog.String(a.Key, "REDACTED")
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		if s := a.Value.String(); strings.Contains(s, "token=") {
+			return slog.String(a.Key, Redact(s))
+		}
+	case slog.KindAny:
+		switch v := a.Value.Any().(type) {
+		case *url.URL:
+			return slog.String(a.Key, Redact(v.String()))
+		case error:
+			if s := v.Error(); strings.Contains(s, "token=") {
+				return slog.String(a.Key, Redact(s))
+			}
+		}
+	}
+	return a
+}
+
+type requestIDKey struct{}
+
+// NewRequestID returns a random request ID.
+func NewRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithRequestID returns ctx carrying id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID in ctx, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// contextHandler adds request_id and trace_id from the record's context
+// and redacts the message.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if strings.Contains(r.Message, "token=") {
+		nr := slog.NewRecord(r.Time, r.Level, Redact(r.Message), r.PC)
+		r.Attrs(func(a slog.Attr) bool {
+			nr.AddAttrs(a)
+			return true
+		})
+		r = nr
+	}
+	if ctx != nil {
+		if id := RequestID(ctx); id != "" {
+			r.AddAttrs(slog.String("request_id", id))
+		}
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			r.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
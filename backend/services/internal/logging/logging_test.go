@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const secret = "s3cr3t-kodik-token"
+
+func TestRedact(t *testing.T) {
+	for in, want := range map[string]string{
+		"https://kodikapi.com/list?token=" + secret + "&limit=100": "https://kodikapi.com/list?token=REDACTED&limit=100",
+		"/search?title=x&token=" + secret:                          "/search?title=x&token=REDACTED",
+		`"url":"https://kodikapi.com/list?token=` + secret + `"`:   `"url":"https://kodikapi.com/list?token=REDACTED"`,
+		"token=" + secret + " rejected":                            "token=REDACTED rejected",
+		"no token here":                                            "no token here",
+		"tokens=1":                                                 "tokens=1",
+		"csrf_token=1":                                             "csrf_token=1",
+	} {
+		if got := Redact(in); got != want {
+			t.Errorf("Redact(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// logLine logs with a JSON logger and returns the decoded record.
+func logLine(t *testing.T, log func(*slog.Logger)) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	l, err := New(&buf, Options{Level: "debug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log(l)
+	if strings.Contains(buf.String(), secret) {
+		t.Errorf("token leaked: %s", buf.String())
+	}
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("%v: %s", err, buf.String())
+	}
+	return rec
+}
+
+func TestLoggerRedacts(t *testing.T) {
+	u, err := url.Parse("https://kodikapi.com/list?token=" + secret + "&limit=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	urlErr := &url.Error{Op: "Get", URL: u.String(), Err: fmt.Errorf("connection refused")}
+
+	for _, tc := range []struct {
+		name string
+		log  func(*slog.Logger)
+		key  string
+		want string
+	}{
+		{
+			name: "message",
+			log:  func(l *slog.Logger) { l.Info("GET /list?token=" + secret + " failed") },
+			key:  "msg",
+			want: "GET /list?token=REDACTED failed",
+		},
+		{
+			name: "string attr",
+			log:  func(l *slog.Logger) { l.Info("request", "url", u.String()) },
+			key:  "url",
+			want: "https://kodikapi.com/list?token=REDACTED&limit=1",
+		},
+		{
+			name: "url attr",
+			log:  func(l *slog.Logger) { l.Info("request", "url", u) },
+			key:  "url",
+			want: "https://kodikapi.com/list?token=REDACTED&limit=1",
+		},
+		{
+			name: "wrapped url error",
+			log:  func(l *slog.Logger) { l.Warn("request failed", "error", fmt.Errorf("list: %w", urlErr)) },
+			key:  "error",
+			want: `list: Get "https://kodikapi.com/list?token=REDACTED&limit=1": connection refused`,
+		},
+		{
+			name: "token key",
+			log:  func(l *slog.Logger) { l.Info("configured", "token", secret) },
+			key:  "token",
+			want: "REDACTED",
+		},
+		{
+			name: "token key via With",
+			log:  func(l *slog.Logger) { l.With("TOKEN", secret).Info("configured") },
+			key:  "TOKEN",
+			want: "REDACTED",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := logLine(t, tc.log)
+			if got := rec[tc.key]; got != tc.want {
+				t.Errorf("%s = %q, want %q", tc.key, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoggerAddsContextIDs(t *testing.T) {
+	traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	ctx := trace.ContextWithSpanContext(WithRequestID(context.Background(), "req-1"),
+		trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{1}}))
+
+	rec := logLine(t, func(l *slog.Logger) {
+		l.With("service", "catalog").InfoContext(ctx, "token="+secret)
+	})
+	if rec["request_id"] != "req-1" || rec["trace_id"] != traceID.String() || rec["service"] != "catalog" {
+		t.Errorf("record = %v", rec)
+	}
+
+	rec = logLine(t, func(l *slog.Logger) { l.Info("no context") })
+	if _, ok := rec["request_id"]; ok {
+		t.Errorf("request_id without a context: %v", rec)
+	}
+}
+
+func TestNewRejectsBadOptions(t *testing.T) {
+	for _, opts := range []Options{{Level: "loud"}, {Format: "xml"}} {
+		if _, err := New(&bytes.Buffer{}, opts); err == nil {
+			t.Errorf("New accepted %+v", opts)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Package titles looks stored titles up in the catalog in batches.
+package titles
+
+import (
+	"context"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+)
+
+// BatchSize matches the catalog's BatchGetAnime limit.
+const BatchSize = 100
+
+// Ref is a title as the library stores it.
+type Ref struct {
+	KodikID   string
+	AniflowID string
+}
+
+// Lookup resolves refs with batched BatchGetAnime calls, by AniFlow ID where
+// a ref has one so re-issued Kodik materials still resolve. Refs whose
+// AniFlow ID the catalog does not know are retried by their Kodik ID.
+//
+// The result for refs[i] is out[i]; it is nil if the catalog returned
+// nothing for it. On an RPC error out holds what was resolved before it.
+func Lookup(ctx context.Context, client pb.CatalogClient, refs []Ref) ([]*pb.BatchGetAnimeResult, error) {
+	out := make([]*pb.BatchGetAnimeResult, len(refs))
+	all := make([]int, len(refs))
+	for i := range refs {
+		all[i] = i
+	}
+	missed, err := lookup(ctx, client, refs, all, true, out)
+	if err != nil || len(missed) == 0 {
+		return out, err
+	}
+	_, err = lookup(ctx, client, refs, missed, false, out)
+	return out, err
+}
+
+// lookup resolves refs[i] for every i in idx, by AniFlow ID if byAniflow is
+// set and the ref has one, by Kodik ID otherwise. It returns the indexes
+// whose AniFlow lookup failed and that have a Kodik ID to fall back to.
+func lookup(ctx context.Context, client pb.CatalogClient, refs []Ref, idx []int, byAniflow bool, out []*pb.BatchGetAnimeResult) ([]int, error) {
+	byID := make(map[string][]int, len(idx))
+	var kodikIDs, aniflowIDs []string
+	for _, i := range idx {
+		ref := refs[i]
+		key := "k:" + ref.KodikID
+		if byAniflow && ref.AniflowID != "" {
+			key = "a:" + ref.AniflowID
+		}
+		if _, ok := byID[key]; !ok {
+			if key[0] == 'a' {
+				aniflowIDs = append(aniflowIDs, ref.AniflowID)
+			} else {
+				kodikIDs = append(kodikIDs, ref.KodikID)
+			}
+		}
+		byID[key] = append(byID[key], i)
+	}
+
+	var reqs []*pb.BatchGetAnimeRequest
+	for start := 0; start < len(kodikIDs); start += BatchSize {
+		end := min(start+BatchSize, len(kodikIDs))
+		reqs = append(reqs, &pb.BatchGetAnimeRequest{KodikIds: kodikIDs[start:end]})
+	}
+	for start := 0; start < len(aniflowIDs); start += BatchSize {
+		end := min(start+BatchSize, len(aniflowIDs))
+		reqs = append(reqs, &pb.BatchGetAnimeRequest{AniflowIds: aniflowIDs[start:end]})
+	}
+
+	var missed []int
+	for _, req := range reqs {
+		resp, err := client.BatchGetAnime(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range resp.Results {
+			key := "k:" + res.KodikId
+			if res.AniflowId != "" {
+				key = "a:" + res.AniflowId
+			}
+			for _, i := range byID[key] {
+				out[i] = res
+				if res.Anime == nil && res.AniflowId != "" && refs[i].KodikID != "" {
+					missed = append(missed, i)
+				}
+			}
+		}
+	}
+	return missed, nil
+}
@@ -0,0 +1,105 @@
+package titles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	pb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	"google.golang.org/grpc"
+)
+
+// fakeCatalog knows the anime in byKodik and byAniflow and fails every call
+// after failAfter of them if it is set.
+type fakeCatalog struct {
+	pb.CatalogClient
+	byKodik, byAniflow map[string]*pb.Anime
+	calls, failAfter   int
+}
+
+func (f *fakeCatalog) BatchGetAnime(ctx context.Context, req *pb.BatchGetAnimeRequest, _ ...grpc.CallOption) (*pb.BatchGetAnimeResponse, error) {
+	f.calls++
+	if f.failAfter > 0 && f.calls > f.failAfter {
+		return nil, errors.New("catalog unavailable")
+	}
+	if len(req.KodikIds) > BatchSize || len(req.AniflowIds) > BatchSize {
+		return nil, fmt.Errorf("batch of %d", max(len(req.KodikIds), len(req.AniflowIds)))
+	}
+	resp := &pb.BatchGetAnimeResponse{}
+	for _, id := range req.KodikIds {
+		res := &pb.BatchGetAnimeResult{KodikId: id, Anime: f.byKodik[id]}
+		if res.Anime == nil {
+			res.Error = "not found"
+		}
+		resp.Results = append(resp.Results, res)
+	}
+	for _, id := range req.AniflowIds {
+		res := &pb.BatchGetAnimeResult{AniflowId: id, Anime: f.byAniflow[id]}
+		if res.Anime == nil {
+			res.Error = "not found"
+		}
+		resp.Results = append(resp.Results, res)
+	}
+	return resp, nil
+}
+
+func TestLookupFallsBackToKodik(t *testing.T) {
+	frieren := &pb.Anime{Title: "Frieren"}
+	reissue := &pb.Anime{Title: "Frieren (reissue)"}
+	client := &fakeCatalog{
+		byKodik:   map[string]*pb.Anime{"serial-1": frieren, "serial-2": reissue},
+		byAniflow: map[string]*pb.Anime{"af-1": frieren},
+	}
+	refs := []Ref{
+		{KodikID: "serial-9", AniflowID: "af-1"},
+		{KodikID: "serial-2", AniflowID: "af-gone"},
+		{KodikID: "serial-1"},
+		{KodikID: "serial-3"},
+		{AniflowID: "af-gone"},
+	}
+	out, err := Lookup(context.Background(), client, refs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*pb.Anime{frieren, reissue, frieren, nil, nil}
+	for i, res := range out {
+		if res == nil || res.Anime != want[i] {
+			t.Errorf("ref %d resolved to %v, want %v", i, res, want[i])
+		}
+	}
+	if out[3].Error == "" || out[4].Error == "" {
+		t.Error("unresolved refs carry no error")
+	}
+}
+
+func TestLookupBatches(t *testing.T) {
+	client := &fakeCatalog{byKodik: map[string]*pb.Anime{}}
+	refs := make([]Ref, 2*BatchSize+1)
+	for i := range refs {
+		id := fmt.Sprintf("serial-%d", i)
+		refs[i].KodikID = id
+		client.byKodik[id] = &pb.Anime{Title: id}
+	}
+	out, err := Lookup(context.Background(), client, refs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.calls != 3 {
+		t.Errorf("made %d calls, want 3", client.calls)
+	}
+	for i, res := range out {
+		if res.GetAnime().GetTitle() != refs[i].KodikID {
+			t.Errorf("ref %d resolved to %v", i, res)
+		}
+	}
+
+	client.calls, client.failAfter = 0, 1
+	out, err = Lookup(context.Background(), client, refs)
+	if err == nil {
+		t.Fatal("lookup succeeded with the catalog down")
+	}
+	if out[0] == nil || out[BatchSize-1] == nil || out[BatchSize] != nil {
+		t.Error("results from the first batch not kept")
+	}
+}
@@ -0,0 +1,277 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type Options struct {
+	// Client is used for deliveries; defaults to a client with a 10s timeout.
+	Client *http.Client
+	// MaxAttempts per endpoint before an event is dead-lettered.
+	MaxAttempts int
+	// Backoff before retry n is BaseBackoff*2^(n-1), capped at MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// DeadLetterPath is a JSON-lines file; when empty failures are only logged.
+	DeadLetterPath string
+	Workers        int
+	QueueSize      int
+	// Logger reports dropped and failed deliveries; defaults to slog.Default.
+	Logger *slog.Logger
+}
+
+func (o *Options) setDefaults() {
+	if o.Client == nil {
+		o.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 5
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = time.Minute
+	}
+	if o.Workers <= 0 {
+		o.Workers = 4
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = 256
+	}
+	if o.Logger == nil {
+		o.Logger = slog.Default()
+	}
+}
+
+type delivery struct {
+	endpoint Endpoint
+	event    Event
+	body     []byte
+}
+
+// DeadLetter is one line of the dead-letter log.
+type DeadLetter struct {
+	EndpointID string    `json:"endpoint_id"`
+	URL        string    `json:"url"`
+	Event      Event     `json:"event"`
+	Attempts   int       `json:"attempts"`
+	LastError  string    `json:"last_error"`
+	FailedAt   time.Time `json:"failed_at"`
+}
+
+// Dispatcher queues events and delivers them in the background. A nil
+// *Dispatcher is valid and drops everything, so callers need not check
+// whether webhooks are configured.
+type Dispatcher struct {
+	endpoints []Endpoint
+	opts      Options
+	queue     chan delivery
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	dlMu      sync.Mutex
+
+	// mu guards closed so no Publish enqueues after Close has drained.
+	mu     sync.RWMutex
+	closed bool
+}
+
+func NewDispatcher(endpoints []Endpoint, opts Options) *Dispatcher {
+	opts.setDefaults()
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Dispatcher{
+		endpoints: endpoints,
+		opts:      opts,
+		queue:     make(chan delivery, opts.QueueSize),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	for i := 0; i < opts.Workers; i++ {
+		d.wg.Add(1)
+		go d.worker()
+	}
+	return d
+}
+
+// Publish sends an event of eventType with data to every endpoint that
+// subscribed to it. Events published after Close are dropped.
+func (d *Dispatcher) Publish(eventType string, data any) {
+	if d == nil {
+		return
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		d.opts.Logger.Warn("webhook dispatcher closed, dropping event", "event", eventType)
+		return
+	}
+	ev := Event{ID: newID(), Type: eventType, CreatedAt: time.Now().UTC(), Data: data}
+	body, err := json.Marshal(ev)
+	if err != nil {
+		d.opts.Logger.Error("marshal webhook event", "event", eventType, "error", err)
+		return
+	}
+	for _, ep := range d.endpoints {
+		if !ep.wants(eventType) {
+			continue
+		}
+		dl := delivery{endpoint: ep, event: ev, body: body}
+		select {
+		case d.queue <- dl:
+		default:
+			d.deadLetter(dl, 0, fmt.Errorf("queue full"))
+		}
+	}
+}
+
+// Close stops accepting events, interrupts in-flight deliveries and waits
+// for them to return. Interrupted deliveries are dropped, not dead-lettered,
+// since their endpoints never failed them; events still queued are
+// dead-lettered so they can be replayed.
+func (d *Dispatcher) Close() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return
+	}
+	d.closed = true
+	d.mu.Unlock()
+
+	d.cancel()
+	d.wg.Wait()
+	for {
+		select {
+		case dl := <-d.queue:
+			d.deadLetter(dl, 0, fmt.Errorf("dispatcher closed"))
+		default:
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) worker() {
+	defer d.wg.Done()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case dl := <-d.queue:
+			// Both cases may be ready once Close cancels; leave the
+			// event queued for Close to dead-letter.
+			if d.ctx.Err() != nil {
+				d.queue <- dl
+				return
+			}
+			d.deliver(dl)
+		}
+	}
+}
+
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	b := d.opts.BaseBackoff << (attempt - 1)
+	if b <= 0 || b > d.opts.MaxBackoff {
+		return d.opts.MaxBackoff
+	}
+	return b
+}
+
+func (d *Dispatcher) deliver(dl delivery) {
+	var err error
+	for attempt := 1; attempt <= d.opts.MaxAttempts; attempt++ {
+		if err = d.send(dl); err == nil {
+			return
+		}
+		if d.ctx.Err() != nil {
+			d.dropClosed(dl, attempt)
+			return
+		}
+		if attempt == d.opts.MaxAttempts {
+			break
+		}
+		select {
+		case <-d.ctx.Done():
+			d.dropClosed(dl, attempt)
+			return
+		case <-time.After(d.backoff(attempt)):
+		}
+	}
+	d.deadLetter(dl, d.opts.MaxAttempts, err)
+}
+
+func (d *Dispatcher) dropClosed(dl delivery, attempts int) {
+	d.opts.Logger.Warn("webhook dispatcher closed, dropping delivery",
+		"event", dl.event.Type, "endpoint", dl.endpoint.ID, "attempts", attempts)
+}
+
+func (d *Dispatcher) send(dl delivery) error {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, dl.endpoint.URL, bytes.NewReader(dl.body))
+	if err != nil {
+		return err
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, dl.event.Type)
+	req.Header.Set(HeaderDelivery, dl.event.ID)
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderSignature, Sign(dl.endpoint.Secret, ts, dl.body))
+
+	resp, err := d.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (d *Dispatcher) deadLetter(dl delivery, attempts int, cause error) {
+	d.opts.Logger.Error("webhook delivery failed, giving up",
+		"event", dl.event.Type, "endpoint", dl.endpoint.ID, "attempts", attempts, "error", cause)
+	if d.opts.DeadLetterPath == "" {
+		return
+	}
+	b, err := json.Marshal(DeadLetter{
+		EndpointID: dl.endpoint.ID,
+		URL:        dl.endpoint.URL,
+		Event:      dl.event,
+		Attempts:   attempts,
+		LastError:  cause.Error(),
+		FailedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		d.opts.Logger.Error("marshal webhook dead letter", "error", err)
+		return
+	}
+
+	d.dlMu.Lock()
+	defer d.dlMu.Unlock()
+	f, err := os.OpenFile(d.opts.DeadLetterPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		d.opts.Logger.Error("open webhook dead-letter log", "path", d.opts.DeadLetterPath, "error", err)
+		return
+	}
+	_, err = f.Write(append(b, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		d.opts.Logger.Error("write webhook dead-letter log", "path", d.opts.DeadLetterPath, "error", err)
+	}
+}
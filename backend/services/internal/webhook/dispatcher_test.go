@@ -0,0 +1,292 @@
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// receiver records what an endpoint was sent and answers with the next
+// status in statuses, then 200 once they run out.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	got      []*http.Request
+	bodies   [][]byte
+	at       []time.Time
+	hits     chan struct{}
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	t.Helper()
+	r := &receiver{statuses: statuses, hits: make(chan struct{}, 16)}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.got = append(r.got, req)
+		r.bodies = append(r.bodies, body)
+		r.at = append(r.at, time.Now())
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		r.mu.Unlock()
+		w.WriteHeader(status)
+		r.hits <- struct{}{}
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) wait(t *testing.T, n int) {
+	t.Helper()
+	for range n {
+		select {
+		case <-r.hits:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("endpoint got %d of %d deliveries", len(r.got), n)
+		}
+	}
+}
+
+// logBuffer collects log output written from delivery workers.
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func readDeadLetters(t *testing.T, path string) []DeadLetter {
+	t.Helper()
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var out []DeadLetter
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var dl DeadLetter
+		if err := json.Unmarshal(sc.Bytes(), &dl); err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, dl)
+	}
+	return out
+}
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	sig := Sign("s3cret", "1700000000", body)
+	if !Verify("s3cret", "1700000000", body, sig) {
+		t.Fatal("signature does not verify")
+	}
+	for name, ok := range map[string]bool{
+		"secret":    Verify("other", "1700000000", body, sig),
+		"timestamp": Verify("s3cret", "1700000001", body, sig),
+		"body":      Verify("s3cret", "1700000000", []byte(`{"id":"2"}`), sig),
+	} {
+		if ok {
+			t.Errorf("signature verifies with a different %s", name)
+		}
+	}
+}
+
+func TestDeliverySigned(t *testing.T) {
+	rcv := newReceiver(t)
+	d := NewDispatcher([]Endpoint{{ID: "ep", URL: rcv.URL, Secret: "s3cret"}}, Options{})
+	defer d.Close()
+
+	d.Publish("watchlist.added", map[string]string{"kodik_id": "serial-1"})
+	rcv.wait(t, 1)
+
+	req, body := rcv.got[0], rcv.bodies[0]
+	if req.Header.Get(HeaderEvent) != "watchlist.added" || req.Header.Get(HeaderDelivery) == "" {
+		t.Errorf("headers = %v", req.Header)
+	}
+	if !Verify("s3cret", req.Header.Get(HeaderTimestamp), body, req.Header.Get(HeaderSignature)) {
+		t.Error("receiver cannot verify the signature")
+	}
+	var ev Event
+	if err := json.Unmarshal(body, &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.Type != "watchlist.added" || ev.ID != req.Header.Get(HeaderDelivery) {
+		t.Errorf("event = %+v", ev)
+	}
+}
+
+func TestDeliveryFiltersEvents(t *testing.T) {
+	all := newReceiver(t)
+	catalog := newReceiver(t)
+	d := NewDispatcher([]Endpoint{
+		{ID: "all", URL: all.URL},
+		{ID: "catalog", URL: catalog.URL, Events: []string{"catalog.*"}},
+	}, Options{})
+	defer d.Close()
+
+	d.Publish("watchlist.added", nil)
+	d.Publish("catalog.episode", nil)
+	all.wait(t, 2)
+	catalog.wait(t, 1)
+	if got := catalog.got[0].Header.Get(HeaderEvent); got != "catalog.episode" {
+		t.Errorf("catalog endpoint got %s", got)
+	}
+}
+
+func TestDeliveryRetriesWithBackoff(t *testing.T) {
+	rcv := newReceiver(t, http.StatusInternalServerError, http.StatusBadGateway)
+	dlPath := filepath.Join(t.TempDir(), "dead.jsonl")
+	d := NewDispatcher([]Endpoint{{ID: "ep", URL: rcv.URL}}, Options{
+		MaxAttempts:    3,
+		BaseBackoff:    20 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		DeadLetterPath: dlPath,
+	})
+	defer d.Close()
+
+	d.Publish("catalog.episode", nil)
+	rcv.wait(t, 3)
+
+	if id := rcv.got[2].Header.Get(HeaderDelivery); id != rcv.got[0].Header.Get(HeaderDelivery) {
+		t.Errorf("retry sent as a new delivery %s", id)
+	}
+	// Backoff doubles: 20ms before the second attempt, 40ms before the third.
+	if gap := rcv.at[1].Sub(rcv.at[0]); gap < 20*time.Millisecond {
+		t.Errorf("first retry after %s", gap)
+	}
+	if gap := rcv.at[2].Sub(rcv.at[1]); gap < 40*time.Millisecond {
+		t.Errorf("second retry after %s", gap)
+	}
+	if dls := readDeadLetters(t, dlPath); len(dls) != 0 {
+		t.Errorf("delivered event dead-lettered: %+v", dls)
+	}
+}
+
+func TestDeliveryDeadLetters(t *testing.T) {
+	rcv := newReceiver(t, 500, 500, 500)
+	dlPath := filepath.Join(t.TempDir(), "dead.jsonl")
+	var logs logBuffer
+	d := NewDispatcher([]Endpoint{{ID: "ep", URL: rcv.URL}}, Options{
+		MaxAttempts:    2,
+		BaseBackoff:    time.Millisecond,
+		DeadLetterPath: dlPath,
+		Logger:         slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	defer d.Close()
+
+	d.Publish("catalog.episode", map[string]int{"episode": 3})
+	rcv.wait(t, 2)
+
+	var dls []DeadLetter
+	for deadline := time.Now().Add(5 * time.Second); len(dls) == 0 && time.Now().Before(deadline); {
+		time.Sleep(5 * time.Millisecond)
+		dls = readDeadLetters(t, dlPath)
+	}
+	if len(dls) != 1 {
+		t.Fatalf("got %d dead letters, want 1", len(dls))
+	}
+	dl := dls[0]
+	if dl.EndpointID != "ep" || dl.URL != rcv.URL || dl.Attempts != 2 || dl.Event.Type != "catalog.episode" {
+		t.Errorf("dead letter = %+v", dl)
+	}
+	if dl.LastError != "unexpected status 500 Internal Server Error" {
+		t.Errorf("last error = %q", dl.LastError)
+	}
+	if got := logs.String(); !strings.Contains(got, `level=ERROR msg="webhook delivery failed, giving up" event=catalog.episode endpoint=ep attempts=2`) {
+		t.Errorf("logged %q", got)
+	}
+}
+
+func TestCloseDropsInterruptedDeliveries(t *testing.T) {
+	rcv := newReceiver(t, 500)
+	dlPath := filepath.Join(t.TempDir(), "dead.jsonl")
+	d := NewDispatcher([]Endpoint{{ID: "ep", URL: rcv.URL}}, Options{
+		MaxAttempts:    5,
+		BaseBackoff:    time.Hour,
+		DeadLetterPath: dlPath,
+	})
+
+	d.Publish("catalog.episode", nil)
+	rcv.wait(t, 1)
+	// The delivery is now waiting out its backoff.
+	d.Close()
+	if dls := readDeadLetters(t, dlPath); len(dls) != 0 {
+		t.Errorf("interrupted delivery dead-lettered: %+v", dls)
+	}
+
+	d.Publish("catalog.episode", nil)
+	select {
+	case <-rcv.hits:
+		t.Error("event published after Close was delivered")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if dls := readDeadLetters(t, dlPath); len(dls) != 0 {
+		t.Errorf("event published after Close dead-lettered: %+v", dls)
+	}
+}
+
+func TestCloseDeadLettersQueued(t *testing.T) {
+	var calls atomic.Int32
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(block)
+	dlPath := filepath.Join(t.TempDir(), "dead.jsonl")
+	d := NewDispatcher([]Endpoint{{ID: "ep", URL: srv.URL}}, Options{
+		Workers:        1,
+		DeadLetterPath: dlPath,
+	})
+
+	d.Publish("catalog.episode", nil)
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// The only worker is busy, so these stay queued.
+	d.Publish("catalog.translation", nil)
+	d.Publish("catalog.translation", nil)
+	d.Close()
+
+	dls := readDeadLetters(t, dlPath)
+	if len(dls) != 2 {
+		t.Fatalf("got %d dead letters, want the 2 queued events", len(dls))
+	}
+	for _, dl := range dls {
+		if dl.Event.Type != "catalog.translation" || dl.Attempts != 0 || dl.LastError != "dispatcher closed" {
+			t.Errorf("dead letter = %+v", dl)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package webhook delivers service events to registered HTTP endpoints as
+// HMAC-signed JSON, retrying with exponential backoff and recording
+// deliveries that never succeed in a dead-letter log.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-AniFlow-Event"
+	HeaderDelivery  = "X-AniFlow-Delivery"
+	HeaderTimestamp = "X-AniFlow-Timestamp"
+	HeaderSignature = "X-AniFlow-Signature"
+)
+
+// Endpoint is a registered receiver. Events lists the event types it wants;
+// a trailing ".*" matches a whole namespace and an empty list matches all.
+type Endpoint struct {
+	ID     string   `json:"id"`
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+}
+
+func (e Endpoint) wants(eventType string) bool {
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, p := range e.Events {
+		if p == eventType || p == "*" {
+			return true
+		}
+		if ns, ok := strings.CutSuffix(p, ".*"); ok && strings.HasPrefix(eventType, ns+".") {
+			return true
+		}
+	}
+	return false
+}
+
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// LoadEndpoints reads endpoint registrations from a JSON file of the form
+// {"endpoints": [{"id": ..., "url": ..., "secret": ..., "events": [...]}]}.
+func LoadEndpoints(path string) ([]Endpoint, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f struct {
+		Endpoints []Endpoint `json:"endpoints"`
+	}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, err
+	}
+	return f.Endpoints, nil
+}
+
+// Sign returns the signature header value for body sent at timestamp:
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>".
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is valid for body, for use by receivers.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/greg5320/AniFlow/backend/services/library"
+	"github.com/greg5320/AniFlow/backend/services/library/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var visibilities = map[store.Visibility]pb.Visibility{
+	store.Private:  pb.Visibility_VISIBILITY_PRIVATE,
+	store.Unlisted: pb.Visibility_VISIBILITY_UNLISTED,
+	store.Public:   pb.Visibility_VISIBILITY_PUBLIC,
+}
+
+// visibilityFromProto returns nil for VISIBILITY_UNSPECIFIED.
+func visibilityFromProto(v pb.Visibility) (*store.Visibility, error) {
+	if v == pb.Visibility_VISIBILITY_UNSPECIFIED {
+		return nil, nil
+	}
+	for vis, p := range visibilities {
+		if p == v {
+			return &vis, nil
+		}
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "unknown visibility %v", v)
+}
+
+// collectionStatus gives a store error the gRPC code callers can act on.
+func collectionStatus(err error) error {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, store.ErrInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, store.ErrFull):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func collectionToProto(c store.Collection) *pb.Collection {
+	out := &pb.Collection{
+		Id:          c.ID,
+		UserId:      c.UserID,
+		Slug:        c.Slug,
+		Name:        c.Name,
+		Description: c.Description,
+		Visibility:  visibilities[c.Visibility],
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+		UpdatedAt:   timestamppb.New(c.UpdatedAt),
+	}
+	for _, it := range c.Items {
+		out.Items = append(out.Items, &pb.CollectionItem{
+			KodikId:   it.KodikID,
+			AniflowId: it.AniflowID,
+			Note:      it.Note,
+			AddedAt:   timestamppb.New(it.AddedAt),
+		})
+	}
+	return out
+}
+
+func (s *server) CreateCollection(ctx context.Context, req *pb.CreateCollectionRequest) (*pb.Collection, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	vis, err := visibilityFromProto(req.Visibility)
+	if err != nil {
+		return nil, err
+	}
+	v := store.Private
+	if vis != nil {
+		v = *vis
+	}
+	c, err := s.store.CreateCollection(req.UserId, req.Name, req.Description, v)
+	if err != nil {
+		return nil, collectionStatus(err)
+	}
+	return collectionToProto(c), nil
+}
+
+func (s *server) UpdateCollection(ctx context.Context, req *pb.UpdateCollectionRequest) (*pb.Collection, error) {
+	if req.GetUserId() == "" || req.GetCollectionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and collection_id required")
+	}
+	vis, err := visibilityFromProto(req.Visibility)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.store.UpdateCollection(req.UserId, req.CollectionId, store.CollectionUpdate{
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  vis,
+	})
+	if err != nil {
+		return nil, collectionStatus(err)
+	}
+	return collectionToProto(c), nil
+}
+
+func (s *server) DeleteCollection(ctx context.Context, req *pb.DeleteCollectionRequest) (*pb.DeleteCollectionResponse, error) {
+	if err := s.store.DeleteCollection(req.GetUserId(), req.GetCollectionId()); err != nil {
+		return nil, collectionStatus(err)
+	}
+	return &pb.DeleteCollectionResponse{}, nil
+}
+
+func (s *server) GetCollection(ctx context.Context, req *pb.GetCollectionRequest) (*pb.Collection, error) {
+	id := req.GetCollectionId()
+	if id == "" {
+		id = req.GetSlug()
+	}
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "collection_id or slug required")
+	}
+	c, err := s.store.Collection(id, req.GetViewerId())
+	if err != nil {
+		return nil, collectionStatus(err)
+	}
+	return collectionToProto(c), nil
+}
+
+func (s *server) ListCollections(ctx context.Context, req *pb.ListCollectionsRequest) (*pb.ListCollectionsResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id required")
+	}
+	resp := &pb.ListCollectionsResponse{}
+	for _, c := range s.store.Collections(req.UserId, req.ViewerId) {
+		resp.Collections = append(resp.Collections, collectionToProto(c))
+	}
+	return resp, nil
+}
+
+func (s *server) AddCollectionItem(ctx context.Context, req *pb.AddCollectionItemRequest) (*pb.Collection, error) {
+	position := -1
+	if req.Position != nil {
+		position = int(*req.Position)
+	}
+	c, err := s.store.AddCollectionItem(req.GetUserId(), req.GetCollectionId(), store.CollectionItem{
+		KodikID:   req.KodikId,
+		AniflowID: req.AniflowId,
+		Note:      req.Note,
+	}, position)
+	if err != nil {
+		return nil, collectionStatus(err)
+	}
+	return collectionToProto(c), nil
+}
+
+func (s *server) RemoveCollectionItem(ctx context.Context, req *pb.RemoveCollectionItemRequest) (*pb.Collection, error) {
+	c, err := s.store.RemoveCollectionItem(req.GetUserId(), req.GetCollectionId(), req.GetKodikId())
+	if err != nil {
+		return nil, collectionStatus(err)
+	}
+	return collectionToProto(c), nil
+}
+
+func (s *server) ReorderCollection(ctx context.Context, req *pb.ReorderCollectionRequest) (*pb.Collection, error) {
+	c, err := s.store.ReorderCollection(req.GetUserId(), req.GetCollectionId(), req.GetKodikIds())
+	if err != nil {
+		return nil, collectionStatus(err)
+	}
+	return collectionToProto(c), nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb "github.com/greg5320/AniFlow/backend/services/library"
+	"github.com/greg5320/AniFlow/backend/services/library/internal/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCollectionErrorCodes(t *testing.T) {
+	s := &server{store: store.NewMemory()}
+	ctx := context.Background()
+	col, err := s.CreateCollection(ctx, &pb.CreateCollectionRequest{UserId: "alice", Name: "Favourites"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AddCollectionItem(ctx, &pb.AddCollectionItemRequest{UserId: "alice", CollectionId: col.Id, KodikId: "serial-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"missing user", func() error {
+			_, err := s.CreateCollection(ctx, &pb.CreateCollectionRequest{Name: "x"})
+			return err
+		}, codes.InvalidArgument},
+		{"missing name", func() error {
+			_, err := s.CreateCollection(ctx, &pb.CreateCollectionRequest{UserId: "alice"})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown visibility", func() error {
+			_, err := s.UpdateCollection(ctx, &pb.UpdateCollectionRequest{UserId: "alice", CollectionId: col.Id, Visibility: 42})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown collection", func() error {
+			_, err := s.GetCollection(ctx, &pb.GetCollectionRequest{CollectionId: "nope", ViewerId: "alice"})
+			return err
+		}, codes.NotFound},
+		{"private collection", func() error {
+			_, err := s.GetCollection(ctx, &pb.GetCollectionRequest{Slug: col.Slug, ViewerId: "bob"})
+			return err
+		}, codes.NotFound},
+		{"someone else's collection", func() error {
+			_, err := s.DeleteCollection(ctx, &pb.DeleteCollectionRequest{UserId: "bob", CollectionId: col.Id})
+			return err
+		}, codes.NotFound},
+		{"missing item", func() error {
+			_, err := s.RemoveCollectionItem(ctx, &pb.RemoveCollectionItemRequest{UserId: "alice", CollectionId: col.Id, KodikId: "serial-2"})
+			return err
+		}, codes.NotFound},
+		{"bad order", func() error {
+			_, err := s.ReorderCollection(ctx, &pb.ReorderCollectionRequest{UserId: "alice", CollectionId: col.Id, KodikIds: []string{"serial-1", "serial-1"}})
+			return err
+		}, codes.InvalidArgument},
+	} {
+		if got := status.Code(tc.call()); got != tc.want {
+			t.Errorf("%s: code %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	for i := 2; i <= 500; i++ {
+		if _, err := s.AddCollectionItem(ctx, &pb.AddCollectionItemRequest{UserId: "alice", CollectionId: col.Id, KodikId: fmt.Sprintf("serial-%d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = s.AddCollectionItem(ctx, &pb.AddCollectionItemRequest{UserId: "alice", CollectionId: col.Id, KodikId: "serial-full"})
+	if got := status.Code(err); got != codes.FailedPrecondition {
+		t.Errorf("full collection: code %v, want %v", got, codes.FailedPrecondition)
+	}
+}
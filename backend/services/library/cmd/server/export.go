@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/greg5320/AniFlow/backend/services/internal/titles"
+	pb "github.com/greg5320/AniFlow/backend/services/library"
+	"github.com/greg5320/AniFlow/backend/services/library/internal/exporter"
+	"github.com/greg5320/AniFlow/backend/services/library/internal/store"
+)
+
+var exportFormats = map[pb.ExportFormat]exporter.Format{
+	pb.ExportFormat_EXPORT_FORMAT_MAL_XML: exporter.FormatMAL,
+	pb.ExportFormat_EXPORT_FORMAT_CSV:     exporter.FormatCSV,
+	pb.ExportFormat_EXPORT_FORMAT_JSON:    exporter.FormatJSON,
+}
+
+// exportTitles looks every item up in the catalog. Titles the catalog
+// cannot resolve, or all of them if it is unreachable, are exported without
+// names.
+func (s *server) exportTitles(ctx context.Context, items []store.Item) []exporter.Item {
+	out := make([]exporter.Item, len(items))
+	refs := make([]titles.Ref, len(items))
+	for i, it := range items {
+		out[i].Item = it
+		refs[i] = titles.Ref{KodikID: it.KodikID, AniflowID: it.AniflowID}
+	}
+	results, err := titles.Lookup(ctx, s.catalog, refs)
+	if err != nil {
+		log.Printf("export: look up titles: %v", err)
+	}
+	for i, res := range results {
+		if res == nil || res.Anime == nil {
+			continue
+		}
+		out[i].Title = exporter.Title{
+			MalID:    res.Anime.ShikimoriId,
+			Name:     res.Anime.Title,
+			Episodes: int(res.Anime.EpisodesCount),
+		}
+	}
+	return out
+}
+
+func (s *server) ExportLibrary(ctx context.Context, req *pb.ExportLibraryRequest) (*pb.ExportLibraryResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	format, ok := exportFormats[req.Format]
+	if !ok {
+		return nil, fmt.Errorf("unknown export format %v", req.Format)
+	}
+	items := s.exportTitles(ctx, s.store.List(req.UserId))
+	now := time.Now().UTC()
+	lib := exporter.Library{
+		UserID:     req.UserId,
+		ExportedAt: now,
+		Items:      items,
+	}
+	if format == exporter.FormatJSON {
+		lib.Collections = s.store.Collections(req.UserId, req.UserId)
+	}
+
+	var buf bytes.Buffer
+	if err := exporter.Write(&buf, format, lib); err != nil {
+		return nil, err
+	}
+	return &pb.ExportLibraryResponse{
+		Filename:    fmt.Sprintf("aniflow-%s-%s.%s", req.UserId, now.Format("20060102"), format.Ext()),
+		ContentType: format.ContentType(),
+		Data:        buf.Bytes(),
+	}, nil
+}
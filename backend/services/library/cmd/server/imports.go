@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	catalogpb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	pb "github.com/greg5320/AniFlow/backend/services/library"
+	"github.com/greg5320/AniFlow/backend/services/library/internal/importer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var importFormats = map[pb.ImportFormat]importer.Format{
+	pb.ImportFormat_IMPORT_FORMAT_MAL_XML:        importer.FormatMAL,
+	pb.ImportFormat_IMPORT_FORMAT_SHIKIMORI_JSON: importer.FormatShikimori,
+}
+
+var importStates = map[importer.State]pb.ImportJob_State{
+	importer.StateRunning: pb.ImportJob_STATE_RUNNING,
+	importer.StateDone:    pb.ImportJob_STATE_DONE,
+}
+
+// catalogResolver maps MAL/Shikimori ids to catalog titles.
+func catalogResolver(catalog catalogpb.CatalogClient) importer.Resolver {
+	return func(ctx context.Context, externalID string) (string, string, error) {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		resp, err := catalog.ResolveAnime(ctx, &catalogpb.ResolveAnimeRequest{MalId: externalID})
+		if err != nil {
+			return "", "", err
+		}
+		if resp.GetAnime().GetKodikId() == "" {
+			return "", "", fmt.Errorf("no Kodik material for MAL id %s", externalID)
+		}
+		return resp.Anime.KodikId, resp.Anime.AniflowId, nil
+	}
+}
+
+func jobToProto(j importer.Job) *pb.ImportJob {
+	out := &pb.ImportJob{
+		Id:        j.ID,
+		UserId:    j.UserID,
+		State:     importStates[j.State],
+		Total:     int32(j.Total),
+		Imported:  int32(j.Imported),
+		Skipped:   int32(j.Skipped),
+		CreatedAt: timestamppb.New(j.CreatedAt),
+	}
+	for p, f := range importFormats {
+		if f == j.Format {
+			out.Format = p
+		}
+	}
+	if !j.FinishedAt.IsZero() {
+		out.FinishedAt = timestamppb.New(j.FinishedAt)
+	}
+	for _, u := range j.Unmatched {
+		out.Unmatched = append(out.Unmatched, &pb.UnmatchedEntry{
+			ExternalId: u.ExternalID,
+			Title:      u.Title,
+			Status:     protoStatus(u.Status),
+			Score:      int32(u.Score),
+			Progress:   int32(u.Progress),
+			Reason:     u.Reason,
+		})
+	}
+	return out
+}
+
+func (s *server) StartImport(ctx context.Context, req *pb.StartImportRequest) (*pb.ImportJob, error) {
+	if req.GetUserId() == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	format, ok := importFormats[req.Format]
+	if !ok {
+		return nil, fmt.Errorf("unknown import format %v", req.Format)
+	}
+	if len(req.Data) == 0 {
+		return nil, fmt.Errorf("data required")
+	}
+	entries, err := importer.Parse(format, bytes.NewReader(req.Data), s.maxImportBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s export: %w", format, err)
+	}
+	return jobToProto(s.imports.Start(req.UserId, format, entries, req.Overwrite)), nil
+}
+
+func (s *server) GetImportJob(ctx context.Context, req *pb.GetImportJobRequest) (*pb.ImportJob, error) {
+	if req.GetUserId() == "" || req.GetJobId() == "" {
+		return nil, fmt.Errorf("user_id and job_id required")
+	}
+	j, err := s.imports.Get(req.UserId, req.JobId)
+	if err != nil {
+		return nil, err
+	}
+	return jobToProto(j), nil
+}
+
+func (s *server) ResolveImportEntry(ctx context.Context, req *pb.ResolveImportEntryRequest) (*pb.ImportJob, error) {
+	if req.GetUserId() == "" || req.GetJobId() == "" || req.GetExternalId() == "" {
+		return nil, fmt.Errorf("user_id, job_id and external_id required")
+	}
+	j, err := s.imports.Resolve(req.UserId, req.JobId, req.ExternalId, req.KodikId, req.AniflowId)
+	if err != nil {
+		return nil, err
+	}
+	return jobToProto(j), nil
+}
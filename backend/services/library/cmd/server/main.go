@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"time"
+
+	catalogpb "github.com/greg5320/AniFlow/backend/services/catalog/gen"
+	"github.com/greg5320/AniFlow/backend/services/internal/config"
+	"github.com/greg5320/AniFlow/backend/services/internal/logging"
+	"github.com/greg5320/AniFlow/backend/services/internal/shutdown"
+	"github.com/greg5320/AniFlow/backend/services/internal/tracing"
+	"github.com/greg5320/AniFlow/backend/services/internal/webhook"
+	pb "github.com/greg5320/AniFlow/backend/services/library"
+	"github.com/greg5320/AniFlow/backend/services/library/internal/activity"
+	"github.com/greg5320/AniFlow/backend/services/library/internal/importer"
+	"github.com/greg5320/AniFlow/backend/services/library/internal/similar"
+	"github.com/greg5320/AniFlow/backend/services/library/internal/store"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type server struct {
+	pb.UnimplementedLibraryServer
+	store    *store.Memory
+	hooks    *webhook.Dispatcher
+	similar  *similar.Job
+	activity *activity.Log
+	imports  *importer.Manager
+	catalog  catalogpb.CatalogClient
+
+	maxImportBytes int
+}
+
+// protoStatus maps a stored status to the API one; store statuses use the
+// same names.
+func protoStatus(st store.Status) pb.Status {
+	p, _ := pb.ParseStatus(string(st))
+	return p
+}
+
+func toProto(it store.Item) *pb.WatchlistItem {
+	return &pb.WatchlistItem{
+		Id:        it.ID,
+		UserId:    it.UserID,
+		KodikId:   it.KodikID,
+		AniflowId: it.AniflowID,
+		AddedAt:   timestamppb.New(it.AddedAt),
+		Status:    protoStatus(it.Status),
+		Score:     int32(it.Score),
+		Progress:  int32(it.Progress),
+		UpdatedAt: timestamppb.New(it.UpdatedAt),
+		Notes:     it.Notes,
+	}
+}
+
+func (s *server) AddToWatchlist(ctx context.Context, req *pb.AddRequest) (*pb.AddResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	if req.KodikId == "" {
+		return nil, fmt.Errorf("kodik_id required")
+	}
+	it, created := s.store.Add(req.UserId, req.KodikId, req.AniflowId)
+	if created {
+		s.activity.Record(activity.Event{
+			Kind:      activity.Added,
+			UserID:    it.UserID,
+			KodikID:   it.KodikID,
+			AniflowID: it.AniflowID,
+			At:        it.AddedAt,
+		})
+		s.hooks.Publish(eventWatchlistAdded, watchlistEvent{
+			ItemID:    it.ID,
+			UserID:    it.UserID,
+			KodikID:   it.KodikID,
+			AniflowID: it.AniflowID,
+			AddedAt:   it.AddedAt,
+		})
+	}
+	return &pb.AddResponse{Item: toProto(it)}, nil
+}
+
+func (s *server) GetWatchlist(ctx context.Context, req *pb.GetWatchlistRequest) (*pb.GetWatchlistResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	resp := &pb.GetWatchlistResponse{}
+	for _, it := range s.store.List(req.UserId) {
+		resp.Items = append(resp.Items, toProto(it))
+	}
+	return resp, nil
+}
+
+func (s *server) UpdateItem(ctx context.Context, req *pb.UpdateItemRequest) (*pb.UpdateItemResponse, error) {
+	if req == nil || req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	if req.KodikId == "" {
+		return nil, fmt.Errorf("kodik_id required")
+	}
+	var u store.Update
+	if req.Status != pb.Status_STATUS_UNSPECIFIED {
+		name := req.Status.Name()
+		if name == "" {
+			return nil, fmt.Errorf("unknown status %v", req.Status)
+		}
+		st := store.Status(name)
+		u.Status = &st
+	}
+	if req.Score != nil {
+		score := int(*req.Score)
+		u.Score = &score
+	}
+	if req.Progress != nil {
+		progress := int(*req.Progress)
+		u.Progress = &progress
+	}
+	u.Notes = req.Notes
+	it, err := s.store.Update(req.UserId, req.KodikId, u)
+	if err != nil {
+		return nil, err
+	}
+	if u.Progress != nil || it.Status == store.StatusWatching || it.Status == store.StatusCompleted {
+		s.activity.Record(activity.Event{
+			Kind:      activity.Progress,
+			UserID:    it.UserID,
+			KodikID:   it.KodikID,
+			AniflowID: it.AniflowID,
+			At:        it.UpdatedAt,
+		})
+	}
+	s.hooks.Publish(eventItemUpdated, itemUpdatedEvent{
+		ItemID:    it.ID,
+		UserID:    it.UserID,
+		KodikID:   it.KodikID,
+		AniflowID: it.AniflowID,
+		Status:    string(it.Status),
+		Score:     it.Score,
+		Progress:  it.Progress,
+		UpdatedAt: it.UpdatedAt,
+	})
+	return &pb.UpdateItemResponse{Item: toProto(it)}, nil
+}
+
+func (s *server) GetSimilar(ctx context.Context, req *pb.GetSimilarRequest) (*pb.GetSimilarResponse, error) {
+	if req.GetKodikId() == "" && req.GetAniflowId() == "" {
+		return nil, fmt.Errorf("kodik_id or aniflow_id required")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 20
+	}
+	es, at := s.similar.Similar(req.KodikId, req.AniflowId, limit)
+	resp := &pb.GetSimilarResponse{ComputedAt: timestamppb.New(at)}
+	for _, e := range es {
+		resp.Items = append(resp.Items, &pb.SimilarItem{
+			KodikId:   e.KodikID,
+			AniflowId: e.AniflowID,
+			Score:     e.Score,
+			Users:     int32(e.Users),
+		})
+	}
+	return resp, nil
+}
+
+func (s *server) GetTrending(ctx context.Context, req *pb.GetTrendingRequest) (*pb.GetTrendingResponse, error) {
+	window := 7 * 24 * time.Hour
+	if req.GetWindow() != nil {
+		window = req.Window.AsDuration()
+	}
+	if window <= 0 || window > activity.Retention {
+		return nil, fmt.Errorf("window must be between 0 and %s", activity.Retention)
+	}
+	counts := s.activity.Trending(window, time.Now())
+	if req.GetLimit() > 0 && len(counts) > int(req.Limit) {
+		counts = counts[:req.Limit]
+	}
+	resp := &pb.GetTrendingResponse{}
+	for _, c := range counts {
+		resp.Items = append(resp.Items, &pb.TrendingItem{
+			KodikId:   c.KodikID,
+			AniflowId: c.AniflowID,
+			Adds:      int32(c.Adds),
+			Progress:  int32(c.Progress),
+			Score:     c.Score,
+		})
+	}
+	return resp, nil
+}
+
+func (s *server) ListWatchers(ctx context.Context, req *pb.ListWatchersRequest) (*pb.ListWatchersResponse, error) {
+	watchers := s.store.Watchers(req.GetKodikIds())
+	ids := make([]string, 0, len(watchers))
+	for id := range watchers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	resp := &pb.ListWatchersResponse{}
+	for _, id := range ids {
+		resp.Watchers = append(resp.Watchers, &pb.Watchers{KodikId: id, UserIds: watchers[id]})
+	}
+	return resp, nil
+}
+
+func main() {
+	cfg := config.MustLoad(config.LibraryService)
+	// Setup also routes the log package through the structured logger.
+	if _, err := logging.Setup("library", logging.Options{Level: cfg.Log.Level, Format: cfg.Log.Format}); err != nil {
+		log.Fatalf("logging: %v", err)
+	}
+	ctx, stop := shutdown.Context()
+	defer stop()
+
+	shutdownTracing, err := tracing.Init(context.Background(), "library")
+	if err != nil {
+		log.Fatalf("tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	hooks := newWebhooks(cfg.Webhooks)
+	defer hooks.Close()
+
+	cc, err := grpc.NewClient(cfg.Library.CatalogAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		log.Fatalf("failed to create catalog client: %v", err)
+	}
+	defer cc.Close()
+	catalog := catalogpb.NewCatalogClient(cc)
+
+	st := store.NewMemory()
+	srv := &server{
+		store:    st,
+		hooks:    hooks,
+		similar:  similar.NewJob(st),
+		activity: activity.NewLog(),
+		imports:  importer.NewManager(st, catalogResolver(catalog)),
+		catalog:  catalog,
+
+		maxImportBytes: cfg.Library.MaxImportBytes,
+	}
+	go srv.similar.Run(ctx, time.Duration(cfg.Library.SimilarInterval))
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Library.Port))
+	if err != nil {
+		log.Fatalf("listen error: %v", err)
+	}
+	grpcServer := grpc.NewServer(
+		// Room for the request's other fields on top of the export.
+		grpc.MaxRecvMsgSize(cfg.Library.MaxImportBytes+1<<10),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+	)
+	pb.RegisterLibraryServer(grpcServer, srv)
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(pb.Library_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, hs)
+
+	log.Printf("library gRPC server listening on :%d", cfg.Library.Port)
+	go func() {
+		<-ctx.Done()
+		log.Printf("shutting down")
+		hs.Shutdown()
+	}()
+	if err := shutdown.GRPC(ctx, grpcServer, lis, time.Duration(cfg.Library.ShutdownTimeout)); err != nil {
+		log.Fatalf("serve error: %v", err)
+	}
+	log.Printf("library stopped")
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/greg5320/AniFlow/backend/services/internal/config"
+	"github.com/greg5320/AniFlow/backend/services/internal/webhook"
+)
+
+const (
+	eventWatchlistAdded = "library.watchlist_added"
+	eventItemUpdated    = "library.item_updated"
+)
+
+type watchlistEvent struct {
+	ItemID    string    `json:"item_id"`
+	UserID    string    `json:"user_id"`
+	KodikID   string    `json:"kodik_id"`
+	AniflowID string    `json:"aniflow_id,omitempty"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+type itemUpdatedEvent struct {
+	ItemID    string    `json:"item_id"`
+	UserID    string    `json:"user_id"`
+	KodikID   string    `json:"kodik_id"`
+	AniflowID string    `json:"aniflow_id,omitempty"`
+	Status    string    `json:"status"`
+	Score     int       `json:"score,omitempty"`
+	Progress  int       `json:"progress"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// newWebhooks returns nil, which publishes nothing, unless cfg.File
+// registers endpoints.
+func newWebhooks(cfg config.Webhooks) *webhook.Dispatcher {
+	if cfg.File == "" {
+		return nil
+	}
+	endpoints, err := webhook.LoadEndpoints(cfg.File)
+	if err != nil {
+		log.Fatalf("load webhooks: %v", err)
+	}
+	return webhook.NewDispatcher(endpoints, webhook.Options{
+		DeadLetterPath: cfg.DeadLetterFile,
+	})
+}
@@ -0,0 +1,124 @@
+// Package activity keeps a rolling log of what users do with titles and
+// scores titles by recent activity.
+package activity
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Retention is how far back the log goes; longer windows see nothing more.
+const Retention = 30 * 24 * time.Hour
+
+type Kind string
+
+const (
+	Added    Kind = "added"
+	Progress Kind = "progress"
+)
+
+// weights make an add count for more than an episode marked watched.
+var weights = map[Kind]float64{
+	Added:    3,
+	Progress: 1,
+}
+
+type Event struct {
+	Kind      Kind
+	UserID    string
+	KodikID   string
+	AniflowID string
+	At        time.Time
+}
+
+type Count struct {
+	KodikID   string
+	AniflowID string
+	Adds      int
+	Progress  int
+	Score     float64
+}
+
+// Log is an in-memory, time-ordered event log.
+type Log struct {
+	mu     sync.RWMutex
+	events []Event
+}
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+func (l *Log) Record(e Event) {
+	if e.At.IsZero() {
+		e.At = time.Now().UTC()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+	cut := sort.Search(len(l.events), func(i int) bool {
+		return e.At.Sub(l.events[i].At) <= Retention
+	})
+	if cut > 0 {
+		l.events = append(l.events[:0], l.events[cut:]...)
+	}
+}
+
+// Trending scores titles by events in the window ending at now. Newer
+// events weigh more, falling linearly to zero at the window's start, and a
+// user counts at most once per title and kind. Titles come out best first.
+func (l *Log) Trending(window time.Duration, now time.Time) []Count {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	type userKey struct{ user, title string }
+	counted := make(map[userKey]map[Kind]bool)
+	byTitle := make(map[string]*Count)
+	start := now.Add(-window)
+	i := sort.Search(len(l.events), func(i int) bool { return !l.events[i].At.Before(start) })
+	for ; i < len(l.events); i++ {
+		e := l.events[i]
+		if e.At.After(now) {
+			break
+		}
+		title := "k:" + e.KodikID
+		if e.AniflowID != "" {
+			title = "af:" + e.AniflowID
+		}
+		uk := userKey{e.UserID, title}
+		if counted[uk] == nil {
+			counted[uk] = make(map[Kind]bool)
+		}
+		if counted[uk][e.Kind] {
+			continue
+		}
+		counted[uk][e.Kind] = true
+
+		c, ok := byTitle[title]
+		if !ok {
+			c = &Count{KodikID: e.KodikID, AniflowID: e.AniflowID}
+			byTitle[title] = c
+		}
+		switch e.Kind {
+		case Added:
+			c.Adds++
+		case Progress:
+			c.Progress++
+		}
+		freshness := 1 - float64(now.Sub(e.At))/float64(window)
+		c.Score += weights[e.Kind] * freshness
+	}
+
+	out := make([]Count, 0, len(byTitle))
+	for _, c := range byTitle {
+		out = append(out, *c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].KodikID < out[j].KodikID
+	})
+	return out
+}
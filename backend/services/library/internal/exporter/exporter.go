@@ -0,0 +1,344 @@
+// Package exporter writes a user's library out as a MyAnimeList-compatible
+// XML list, a CSV sheet or a JSON document that keeps everything the
+// library stores.
+package exporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/greg5320/AniFlow/backend/services/library/internal/store"
+)
+
+type Format string
+
+const (
+	FormatMAL  Format = "mal_xml"
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// ContentType and Ext describe the file a format produces.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatMAL:
+		return "application/xml"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json"
+}
+
+func (f Format) Ext() string {
+	switch f {
+	case FormatMAL:
+		return "xml"
+	case FormatCSV:
+		return "csv"
+	}
+	return "json"
+}
+
+// Title is the catalog data exported next to an item.
+type Title struct {
+	// MalID equals the Shikimori id.
+	MalID    string
+	Name     string
+	Episodes int
+}
+
+type Item struct {
+	store.Item
+	Title Title
+}
+
+// Library is everything exported for one user.
+type Library struct {
+	UserID      string
+	ExportedAt  time.Time
+	Items       []Item
+	Collections []store.Collection
+}
+
+// Write encodes lib in the given format. The MAL list only has room for
+// titles with a MAL id; the others are left out of it.
+func Write(w io.Writer, format Format, lib Library) error {
+	switch format {
+	case FormatMAL:
+		return writeMAL(w, lib)
+	case FormatCSV:
+		return writeCSV(w, lib)
+	case FormatJSON:
+		return writeJSON(w, lib)
+	}
+	return fmt.Errorf("unknown export format %q", format)
+}
+
+var malStatuses = map[store.Status]string{
+	store.StatusPlanned:   "Plan to Watch",
+	store.StatusWatching:  "Watching",
+	store.StatusCompleted: "Completed",
+	store.StatusOnHold:    "On-Hold",
+	store.StatusDropped:   "Dropped",
+}
+
+type cdata struct {
+	Text string `xml:",cdata"`
+}
+
+type malAnime struct {
+	ID             string `xml:"series_animedb_id"`
+	Title          cdata  `xml:"series_title"`
+	Episodes       int    `xml:"series_episodes"`
+	MyID           int    `xml:"my_id"`
+	Watched        int    `xml:"my_watched_episodes"`
+	StartDate      string `xml:"my_start_date"`
+	FinishDate     string `xml:"my_finish_date"`
+	Score          int    `xml:"my_score"`
+	Status         string `xml:"my_status"`
+	Comments       cdata  `xml:"my_comments"`
+	TimesWatched   int    `xml:"my_times_watched"`
+	UpdateOnImport int    `xml:"update_on_import"`
+}
+
+type malList struct {
+	XMLName xml.Name `xml:"myanimelist"`
+	MyInfo  struct {
+		ExportType int `xml:"user_export_type"`
+		Total      int `xml:"user_total_anime"`
+		Watching   int `xml:"user_total_watching"`
+		Completed  int `xml:"user_total_completed"`
+		OnHold     int `xml:"user_total_onhold"`
+		Dropped    int `xml:"user_total_dropped"`
+		Planned    int `xml:"user_total_plantowatch"`
+	} `xml:"myinfo"`
+	Anime []malAnime `xml:"anime"`
+}
+
+// malDate formats the date MAL expects, with zeros for unknown ones.
+func malDate(t time.Time) string {
+	if t.IsZero() {
+		return "0000-00-00"
+	}
+	return t.Format(time.DateOnly)
+}
+
+// startedAt and finishedAt read the watch dates off an item's history.
+func startedAt(it store.Item) time.Time {
+	for _, ch := range it.History {
+		if ch.Progress > 0 || ch.Status == store.StatusWatching || ch.Status == store.StatusCompleted {
+			return ch.At
+		}
+	}
+	return time.Time{}
+}
+
+func finishedAt(it store.Item) time.Time {
+	if it.Status != store.StatusCompleted {
+		return time.Time{}
+	}
+	for i := len(it.History) - 1; i > 0; i-- {
+		if it.History[i-1].Status != store.StatusCompleted {
+			return it.History[i].At
+		}
+	}
+	if len(it.History) > 0 {
+		return it.History[0].At
+	}
+	return it.UpdatedAt
+}
+
+func writeMAL(w io.Writer, lib Library) error {
+	var doc malList
+	doc.MyInfo.ExportType = 1
+	for _, it := range lib.Items {
+		if it.Title.MalID == "" {
+			continue
+		}
+		doc.Anime = append(doc.Anime, malAnime{
+			ID:             it.Title.MalID,
+			Title:          cdata{it.Title.Name},
+			Episodes:       it.Title.Episodes,
+			Watched:        it.Progress,
+			StartDate:      malDate(startedAt(it.Item)),
+			FinishDate:     malDate(finishedAt(it.Item)),
+			Score:          it.Score,
+			Status:         malStatuses[it.Status],
+			Comments:       cdata{it.Notes},
+			UpdateOnImport: 1,
+		})
+		switch it.Status {
+		case store.StatusWatching:
+			doc.MyInfo.Watching++
+		case store.StatusCompleted:
+			doc.MyInfo.Completed++
+		case store.StatusOnHold:
+			doc.MyInfo.OnHold++
+		case store.StatusDropped:
+			doc.MyInfo.Dropped++
+		default:
+			doc.MyInfo.Planned++
+		}
+	}
+	doc.MyInfo.Total = len(doc.Anime)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+var csvHeader = []string{
+	"kodik_id", "aniflow_id", "mal_id", "title", "status", "score",
+	"progress", "episodes", "notes", "added_at", "updated_at",
+}
+
+// csvText keeps spreadsheets from running a cell as a formula.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func writeCSV(w io.Writer, lib Library) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, it := range lib.Items {
+		err := cw.Write([]string{
+			csvText(it.KodikID),
+			csvText(it.AniflowID),
+			csvText(it.Title.MalID),
+			csvText(it.Title.Name),
+			string(it.Status),
+			strconv.Itoa(it.Score),
+			strconv.Itoa(it.Progress),
+			strconv.Itoa(it.Title.Episodes),
+			csvText(it.Notes),
+			it.AddedAt.Format(time.RFC3339),
+			it.UpdatedAt.Format(time.RFC3339),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// jsonVersion is bumped whenever the JSON layout changes incompatibly.
+const jsonVersion = 1
+
+type jsonChange struct {
+	At       time.Time    `json:"at"`
+	Status   store.Status `json:"status"`
+	Score    int          `json:"score"`
+	Progress int          `json:"progress"`
+}
+
+type jsonItem struct {
+	ID        string       `json:"id"`
+	KodikID   string       `json:"kodik_id"`
+	AniflowID string       `json:"aniflow_id,omitempty"`
+	MalID     string       `json:"mal_id,omitempty"`
+	Title     string       `json:"title,omitempty"`
+	Episodes  int          `json:"episodes,omitempty"`
+	Status    store.Status `json:"status"`
+	Score     int          `json:"score"`
+	Progress  int          `json:"progress"`
+	Notes     string       `json:"notes,omitempty"`
+	AddedAt   time.Time    `json:"added_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	History   []jsonChange `json:"history"`
+}
+
+type jsonCollectionItem struct {
+	KodikID   string    `json:"kodik_id"`
+	AniflowID string    `json:"aniflow_id,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+type jsonCollection struct {
+	ID          string               `json:"id"`
+	Slug        string               `json:"slug"`
+	Name        string               `json:"name"`
+	Description string               `json:"description,omitempty"`
+	Visibility  store.Visibility     `json:"visibility"`
+	Items       []jsonCollectionItem `json:"items"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+type jsonLibrary struct {
+	Version     int              `json:"version"`
+	UserID      string           `json:"user_id"`
+	ExportedAt  time.Time        `json:"exported_at"`
+	Items       []jsonItem       `json:"items"`
+	Collections []jsonCollection `json:"collections"`
+}
+
+func writeJSON(w io.Writer, lib Library) error {
+	doc := jsonLibrary{
+		Version:     jsonVersion,
+		UserID:      lib.UserID,
+		ExportedAt:  lib.ExportedAt,
+		Items:       make([]jsonItem, 0, len(lib.Items)),
+		Collections: make([]jsonCollection, 0, len(lib.Collections)),
+	}
+	for _, it := range lib.Items {
+		ji := jsonItem{
+			ID:        it.ID,
+			KodikID:   it.KodikID,
+			AniflowID: it.AniflowID,
+			MalID:     it.Title.MalID,
+			Title:     it.Title.Name,
+			Episodes:  it.Title.Episodes,
+			Status:    it.Status,
+			Score:     it.Score,
+			Progress:  it.Progress,
+			Notes:     it.Notes,
+			AddedAt:   it.AddedAt,
+			UpdatedAt: it.UpdatedAt,
+			History:   make([]jsonChange, 0, len(it.History)),
+		}
+		for _, ch := range it.History {
+			ji.History = append(ji.History, jsonChange(ch))
+		}
+		doc.Items = append(doc.Items, ji)
+	}
+	for _, c := range lib.Collections {
+		jc := jsonCollection{
+			ID:          c.ID,
+			Slug:        c.Slug,
+			Name:        c.Name,
+			Description: c.Description,
+			Visibility:  c.Visibility,
+			Items:       make([]jsonCollectionItem, 0, len(c.Items)),
+			CreatedAt:   c.CreatedAt,
+			UpdatedAt:   c.UpdatedAt,
+		}
+		for _, ci := range c.Items {
+			jc.Items = append(jc.Items, jsonCollectionItem(ci))
+		}
+		doc.Collections = append(doc.Collections, jc)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(doc)
+}
@@ -0,0 +1,217 @@
+package exporter
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/greg5320/AniFlow/backend/services/library/internal/importer"
+	"github.com/greg5320/AniFlow/backend/services/library/internal/store"
+)
+
+var (
+	day1 = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	day2 = day1.Add(24 * time.Hour)
+	day3 = day2.Add(24 * time.Hour)
+)
+
+func testLibrary() Library {
+	return Library{
+		UserID:     "alice",
+		ExportedAt: day3,
+		Items: []Item{
+			{
+				Item: store.Item{
+					ID: "it-1", UserID: "alice", KodikID: "serial-1", AniflowID: "af_1",
+					Status: store.StatusCompleted, Score: 9, Progress: 28,
+					Notes:   "=HYPERLINK(\"http://evil\")",
+					AddedAt: day1, UpdatedAt: day3,
+					History: []store.Change{
+						{At: day1, Status: store.StatusWatching, Progress: 1},
+						{At: day2, Status: store.StatusCompleted, Score: 9, Progress: 28},
+					},
+				},
+				Title: Title{MalID: "52991", Name: "Frieren <Beyond> & Journey's End", Episodes: 28},
+			},
+			{
+				Item: store.Item{
+					ID: "it-2", UserID: "alice", KodikID: "serial-2",
+					Status: store.StatusPlanned, Notes: "-1 episodes, +fun @home",
+					AddedAt: day2, UpdatedAt: day2,
+					History: []store.Change{{At: day2, Status: store.StatusPlanned}},
+				},
+				Title: Title{MalID: "5114", Name: "Fullmetal Alchemist: Brotherhood", Episodes: 64},
+			},
+			{
+				Item: store.Item{
+					ID: "it-3", UserID: "alice", KodikID: "movie-3",
+					Status: store.StatusOnHold, Progress: 0,
+					AddedAt: day1, UpdatedAt: day1,
+				},
+			},
+		},
+		Collections: []store.Collection{{
+			ID: "col-1", Slug: "favourites", Name: "Favourites", Visibility: store.Public,
+			Items:     []store.CollectionItem{{KodikID: "serial-1", AniflowID: "af_1", Note: "best", AddedAt: day2}},
+			CreatedAt: day1, UpdatedAt: day2,
+		}},
+	}
+}
+
+func TestMALRoundTrip(t *testing.T) {
+	lib := testLibrary()
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatMAL, lib); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := importer.Parse(importer.FormatMAL, &buf, 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []importer.Entry{
+		{ExternalID: "52991", Title: lib.Items[0].Title.Name, Status: store.StatusCompleted, Score: 9, Progress: 28, Notes: lib.Items[0].Notes},
+		{ExternalID: "5114", Title: lib.Items[1].Title.Name, Status: store.StatusPlanned, Notes: lib.Items[1].Notes},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("parsed %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, e := range entries {
+		if e != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, e, want[i])
+		}
+	}
+}
+
+const wantCSV = `kodik_id,aniflow_id,mal_id,title,status,score,progress,episodes,notes,added_at,updated_at
+serial-1,af_1,52991,Frieren <Beyond> & Journey's End,completed,9,28,28,"'=HYPERLINK(""http://evil"")",2024-03-01T12:00:00Z,2024-03-03T12:00:00Z
+serial-2,,5114,Fullmetal Alchemist: Brotherhood,planned,0,0,64,"'-1 episodes, +fun @home",2024-03-02T12:00:00Z,2024-03-02T12:00:00Z
+movie-3,,,,on_hold,0,0,0,,2024-03-01T12:00:00Z,2024-03-01T12:00:00Z
+`
+
+const wantJSON = `{
+  "version": 1,
+  "user_id": "alice",
+  "exported_at": "2024-03-03T12:00:00Z",
+  "items": [
+    {
+      "id": "it-1",
+      "kodik_id": "serial-1",
+      "aniflow_id": "af_1",
+      "mal_id": "52991",
+      "title": "Frieren <Beyond> & Journey's End",
+      "episodes": 28,
+      "status": "completed",
+      "score": 9,
+      "progress": 28,
+      "notes": "=HYPERLINK(\"http://evil\")",
+      "added_at": "2024-03-01T12:00:00Z",
+      "updated_at": "2024-03-03T12:00:00Z",
+      "history": [
+        {
+          "at": "2024-03-01T12:00:00Z",
+          "status": "watching",
+          "score": 0,
+          "progress": 1
+        },
+        {
+          "at": "2024-03-02T12:00:00Z",
+          "status": "completed",
+          "score": 9,
+          "progress": 28
+        }
+      ]
+    },
+    {
+      "id": "it-2",
+      "kodik_id": "serial-2",
+      "mal_id": "5114",
+      "title": "Fullmetal Alchemist: Brotherhood",
+      "episodes": 64,
+      "status": "planned",
+      "score": 0,
+      "progress": 0,
+      "notes": "-1 episodes, +fun @home",
+      "added_at": "2024-03-02T12:00:00Z",
+      "updated_at": "2024-03-02T12:00:00Z",
+      "history": [
+        {
+          "at": "2024-03-02T12:00:00Z",
+          "status": "planned",
+          "score": 0,
+          "progress": 0
+        }
+      ]
+    },
+    {
+      "id": "it-3",
+      "kodik_id": "movie-3",
+      "status": "on_hold",
+      "score": 0,
+      "progress": 0,
+      "added_at": "2024-03-01T12:00:00Z",
+      "updated_at": "2024-03-01T12:00:00Z",
+      "history": []
+    }
+  ],
+  "collections": [
+    {
+      "id": "col-1",
+      "slug": "favourites",
+      "name": "Favourites",
+      "visibility": "public",
+      "items": [
+        {
+          "kodik_id": "serial-1",
+          "aniflow_id": "af_1",
+          "note": "best",
+          "added_at": "2024-03-02T12:00:00Z"
+        }
+      ],
+      "created_at": "2024-03-01T12:00:00Z",
+      "updated_at": "2024-03-02T12:00:00Z"
+    }
+  ]
+}
+`
+
+func TestWriteGolden(t *testing.T) {
+	for _, tc := range []struct {
+		format Format
+		want   string
+	}{
+		{FormatCSV, wantCSV},
+		{FormatJSON, wantJSON},
+	} {
+		var buf bytes.Buffer
+		if err := Write(&buf, tc.format, testLibrary()); err != nil {
+			t.Fatalf("%s: %v", tc.format, err)
+		}
+		if got := buf.String(); got != tc.want {
+			t.Errorf("%s output:\n%s\nwant:\n%s", tc.format, got, tc.want)
+		}
+	}
+}
+
+func TestFinishedAt(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		it   store.Item
+		want time.Time
+	}{
+		{"not completed", store.Item{Status: store.StatusWatching, UpdatedAt: day3}, time.Time{}},
+		{"completed last", store.Item{Status: store.StatusCompleted, UpdatedAt: day3, History: []store.Change{
+			{At: day1, Status: store.StatusWatching},
+			{At: day2, Status: store.StatusCompleted},
+			{At: day3, Status: store.StatusCompleted, Score: 8},
+		}}, day2},
+		{"completed throughout", store.Item{Status: store.StatusCompleted, UpdatedAt: day3, History: []store.Change{
+			{At: day1, Status: store.StatusCompleted},
+			{At: day2, Status: store.StatusCompleted, Score: 8},
+		}}, day1},
+		{"no history", store.Item{Status: store.StatusCompleted, UpdatedAt: day3}, day3},
+	} {
+		if got := finishedAt(tc.it); !got.Equal(tc.want) {
+			t.Errorf("%s: finishedAt = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+// Package importer brings watchlists exported from MyAnimeList or
+// Shikimori into the library. Imports run in the background as jobs that
+// keep a report of entries no AniFlow title was found for, so they can be
+// resolved by hand later.
+package importer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/greg5320/AniFlow/backend/services/library/internal/store"
+)
+
+// workers bounds concurrent title lookups per job.
+const workers = 8
+
+// jobRetention is how long a finished job, and its report of unmatched
+// entries, stays available.
+const jobRetention = 24 * time.Hour
+
+// Resolver finds the Kodik material and AniFlow title for a MAL/Shikimori
+// anime ID.
+type Resolver func(ctx context.Context, externalID string) (kodikID, aniflowID string, err error)
+
+type State string
+
+const (
+	StateRunning State = "running"
+	StateDone    State = "done"
+)
+
+type Unmatched struct {
+	Entry
+	Reason string
+}
+
+type Job struct {
+	ID         string
+	UserID     string
+	Format     Format
+	State      State
+	Overwrite  bool
+	Total      int
+	Imported   int
+	Skipped    int
+	Unmatched  []Unmatched
+	CreatedAt  time.Time
+	FinishedAt time.Time
+}
+
+func (j *Job) clone() Job {
+	out := *j
+	out.Unmatched = append([]Unmatched(nil), j.Unmatched...)
+	return out
+}
+
+// Manager runs import jobs against a store.
+type Manager struct {
+	store   *store.Memory
+	resolve Resolver
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+func NewManager(st *store.Memory, resolve Resolver) *Manager {
+	return &Manager{store: st, resolve: resolve, jobs: make(map[string]*Job)}
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Start queues entries for userID and returns the job right away. Titles
+// already on the watchlist keep their status, score and progress unless
+// overwrite is set.
+func (m *Manager) Start(userID string, format Format, entries []Entry, overwrite bool) Job {
+	j := &Job{
+		ID:        newID(),
+		UserID:    userID,
+		Format:    format,
+		State:     StateRunning,
+		Overwrite: overwrite,
+		Total:     len(entries),
+		CreatedAt: time.Now().UTC(),
+	}
+	m.mu.Lock()
+	m.evict(j.CreatedAt)
+	m.jobs[j.ID] = j
+	out := j.clone()
+	m.mu.Unlock()
+
+	go m.run(j, entries)
+	return out
+}
+
+// evict drops jobs finished more than jobRetention before now. m.mu must
+// be held.
+func (m *Manager) evict(now time.Time) {
+	for id, j := range m.jobs {
+		if j.State == StateDone && now.Sub(j.FinishedAt) > jobRetention {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+func (m *Manager) run(j *Job, entries []Entry) {
+	ctx := context.Background()
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for _, e := range entries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			m.importEntry(ctx, j, e)
+		}()
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	j.State = StateDone
+	j.FinishedAt = time.Now().UTC()
+	log.Printf("[import] job %s for %s: %d imported, %d skipped, %d unmatched",
+		j.ID, j.UserID, j.Imported, j.Skipped, len(j.Unmatched))
+	m.mu.Unlock()
+}
+
+func (m *Manager) importEntry(ctx context.Context, j *Job, e Entry) {
+	if e.ExternalID == "" {
+		m.unmatched(j, e, "entry has no anime id")
+		return
+	}
+	kodikID, aniflowID, err := m.resolve(ctx, e.ExternalID)
+	if err != nil {
+		m.unmatched(j, e, err.Error())
+		return
+	}
+	added, err := m.apply(j.UserID, e, kodikID, aniflowID, j.Overwrite)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch {
+	case err != nil:
+		j.Unmatched = append(j.Unmatched, Unmatched{Entry: e, Reason: err.Error()})
+	case added:
+		j.Imported++
+	default:
+		j.Skipped++
+	}
+}
+
+func (m *Manager) unmatched(j *Job, e Entry, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j.Unmatched = append(j.Unmatched, Unmatched{Entry: e, Reason: reason})
+}
+
+// apply puts e on the watchlist and reports whether it changed anything.
+// Entries the store would reject are not added at all.
+func (m *Manager) apply(userID string, e Entry, kodikID, aniflowID string, overwrite bool) (bool, error) {
+	if kodikID == "" {
+		return false, fmt.Errorf("no kodik_id for %s", e.ExternalID)
+	}
+	score := min(max(e.Score, 0), store.MaxScore)
+	progress := max(e.Progress, 0)
+	u := store.Update{
+		Status:   &e.Status,
+		Score:    &score,
+		Progress: &progress,
+	}
+	if e.Notes != "" {
+		u.Notes = &e.Notes
+	}
+	if err := u.Validate(); err != nil {
+		return false, err
+	}
+	it, created := m.store.Add(userID, kodikID, aniflowID)
+	if !created && !overwrite {
+		return false, nil
+	}
+	_, err := m.store.Update(userID, it.KodikID, u)
+	return err == nil, err
+}
+
+// Get returns a job if it belongs to userID.
+func (m *Manager) Get(userID, jobID string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[jobID]
+	if !ok || j.UserID != userID {
+		return Job{}, fmt.Errorf("import job %s not found", jobID)
+	}
+	return j.clone(), nil
+}
+
+// Resolve imports an unmatched entry as the given title, chosen by the
+// user, and drops it from the report.
+func (m *Manager) Resolve(userID, jobID, externalID, kodikID, aniflowID string) (Job, error) {
+	if kodikID == "" {
+		return Job{}, fmt.Errorf("kodik_id required")
+	}
+	m.mu.Lock()
+	j, ok := m.jobs[jobID]
+	if !ok || j.UserID != userID {
+		m.mu.Unlock()
+		return Job{}, fmt.Errorf("import job %s not found", jobID)
+	}
+	if j.State == StateRunning {
+		m.mu.Unlock()
+		return Job{}, fmt.Errorf("import job %s is still running", jobID)
+	}
+	idx := -1
+	for i, u := range j.Unmatched {
+		if u.ExternalID == externalID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		m.mu.Unlock()
+		return Job{}, fmt.Errorf("no unmatched entry %s in job %s", externalID, jobID)
+	}
+	e := j.Unmatched[idx].Entry
+	m.mu.Unlock()
+
+	if _, err := m.apply(userID, e, kodikID, aniflowID, true); err != nil {
+		return Job{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, u := range j.Unmatched {
+		if u.ExternalID == externalID {
+			j.Unmatched = append(j.Unmatched[:i], j.Unmatched[i+1:]...)
+			j.Imported++
+			break
+		}
+	}
+	return j.clone(), nil
+}
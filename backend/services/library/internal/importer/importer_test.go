@@ -0,0 +1,82 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greg5320/AniFlow/backend/services/library/internal/store"
+)
+
+func resolveByID(ctx context.Context, externalID string) (string, string, error) {
+	return "serial-" + externalID, "", nil
+}
+
+func wait(t *testing.T, m *Manager, j Job) Job {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		got, err := m.Get(j.UserID, j.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.State == StateDone {
+			return got
+		}
+	}
+	t.Fatalf("job %s still running", j.ID)
+	return Job{}
+}
+
+func TestStartEvictsFinishedJobs(t *testing.T) {
+	m := NewManager(store.NewMemory(), resolveByID)
+	old := wait(t, m, m.Start("alice", FormatMAL, malEntries, false))
+	if old.Imported != len(malEntries) {
+		t.Fatalf("imported %d of %d", old.Imported, len(malEntries))
+	}
+	recent := wait(t, m, m.Start("alice", FormatMAL, nil, false))
+	running := m.Start("bob", FormatMAL, nil, false)
+
+	m.mu.Lock()
+	m.jobs[old.ID].FinishedAt = time.Now().Add(-jobRetention - time.Minute)
+	m.jobs[running.ID].State = StateRunning
+	m.mu.Unlock()
+
+	m.Start("carol", FormatShikimori, nil, false)
+	if _, err := m.Get("alice", old.ID); err == nil {
+		t.Error("expired job kept")
+	}
+	if _, err := m.Get("alice", recent.ID); err != nil {
+		t.Errorf("recent job evicted: %v", err)
+	}
+	if _, err := m.Get("bob", running.ID); err != nil {
+		t.Errorf("running job evicted: %v", err)
+	}
+}
+
+func TestRejectedEntriesStayOffTheWatchlist(t *testing.T) {
+	st := store.NewMemory()
+	m := NewManager(st, func(ctx context.Context, externalID string) (string, string, error) {
+		if externalID == "404" {
+			return "", "", nil
+		}
+		return resolveByID(ctx, externalID)
+	})
+	j := wait(t, m, m.Start("alice", FormatMAL, []Entry{
+		{ExternalID: "1", Status: store.StatusWatching, Notes: strings.Repeat("x", 5000)},
+		{ExternalID: "2", Status: "rewatching"},
+		{ExternalID: "404", Status: store.StatusCompleted},
+		{ExternalID: "3", Status: store.StatusCompleted, Score: 11, Progress: -1},
+	}, false))
+
+	if j.Imported != 1 || len(j.Unmatched) != 3 {
+		t.Fatalf("imported %d, unmatched %+v", j.Imported, j.Unmatched)
+	}
+	items := st.List("alice")
+	if len(items) != 1 || items[0].KodikID != "serial-3" {
+		t.Fatalf("watchlist = %+v, want only the valid entry", items)
+	}
+	if it := items[0]; it.Score != store.MaxScore || it.Progress != 0 {
+		t.Errorf("score %d, progress %d, want them clamped", it.Score, it.Progress)
+	}
+}
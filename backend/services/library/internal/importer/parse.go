@@ -0,0 +1,165 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/greg5320/AniFlow/backend/services/library/internal/store"
+)
+
+type Format string
+
+const (
+	FormatMAL       Format = "mal_xml"
+	FormatShikimori Format = "shikimori_json"
+)
+
+// Entry is one title from an export. MAL and Shikimori share anime IDs, so
+// ExternalID works for both.
+type Entry struct {
+	ExternalID string
+	Title      string
+	Status     store.Status
+	Score      int
+	Progress   int
+	Notes      string
+}
+
+// Parse reads an export in the given format. Gzipped input, as MAL serves
+// its exports, is decompressed first. Exports larger than maxBytes, counted
+// after decompression, are rejected.
+func Parse(format Format, r io.Reader, maxBytes int) ([]Entry, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	} else {
+		r = br
+	}
+	data, err := io.ReadAll(io.LimitReader(r, int64(maxBytes)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBytes {
+		return nil, fmt.Errorf("export larger than %d bytes", maxBytes)
+	}
+	r = bytes.NewReader(data)
+	switch format {
+	case FormatMAL:
+		return parseMAL(r)
+	case FormatShikimori:
+		return parseShikimori(r)
+	}
+	return nil, fmt.Errorf("unknown import format %q", format)
+}
+
+// malStatuses covers both the names in current exports and the numeric
+// codes older ones use.
+var malStatuses = map[string]store.Status{
+	"watching":      store.StatusWatching,
+	"completed":     store.StatusCompleted,
+	"on-hold":       store.StatusOnHold,
+	"dropped":       store.StatusDropped,
+	"plan to watch": store.StatusPlanned,
+	"1":             store.StatusWatching,
+	"2":             store.StatusCompleted,
+	"3":             store.StatusOnHold,
+	"4":             store.StatusDropped,
+	"6":             store.StatusPlanned,
+}
+
+func parseMAL(r io.Reader) ([]Entry, error) {
+	var doc struct {
+		Anime []struct {
+			ID      string `xml:"series_animedb_id"`
+			Title   string `xml:"series_title"`
+			Watched int    `xml:"my_watched_episodes"`
+			Score   int    `xml:"my_score"`
+			Status  string `xml:"my_status"`
+			Notes   string `xml:"my_comments"`
+		} `xml:"anime"`
+	}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse MAL export: %w", err)
+	}
+	out := make([]Entry, 0, len(doc.Anime))
+	for _, a := range doc.Anime {
+		st, ok := malStatuses[strings.ToLower(strings.TrimSpace(a.Status))]
+		if !ok {
+			st = store.StatusPlanned
+		}
+		out = append(out, Entry{
+			ExternalID: strings.TrimSpace(a.ID),
+			Title:      strings.TrimSpace(a.Title),
+			Status:     st,
+			Score:      a.Score,
+			Progress:   a.Watched,
+			Notes:      strings.TrimSpace(a.Notes),
+		})
+	}
+	return out, nil
+}
+
+var shikimoriStatuses = map[string]store.Status{
+	"planned":    store.StatusPlanned,
+	"watching":   store.StatusWatching,
+	"rewatching": store.StatusWatching,
+	"completed":  store.StatusCompleted,
+	"on_hold":    store.StatusOnHold,
+	"dropped":    store.StatusDropped,
+}
+
+func parseShikimori(r io.Reader) ([]Entry, error) {
+	var rates []struct {
+		TargetID   json.Number `json:"target_id"`
+		TargetType string      `json:"target_type"`
+		Title      string      `json:"target_title"`
+		TitleRu    string      `json:"target_title_ru"`
+		Score      int         `json:"score"`
+		Status     string      `json:"status"`
+		Episodes   int         `json:"episodes"`
+		Text       string      `json:"text"`
+	}
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(&rates); err != nil {
+		return nil, fmt.Errorf("parse Shikimori export: %w", err)
+	}
+	out := make([]Entry, 0, len(rates))
+	for _, rt := range rates {
+		if rt.TargetType != "" && rt.TargetType != "Anime" {
+			continue
+		}
+		st, ok := shikimoriStatuses[rt.Status]
+		if !ok {
+			st = store.StatusPlanned
+		}
+		title := rt.Title
+		if title == "" {
+			title = rt.TitleRu
+		}
+		id := rt.TargetID.String()
+		if id == "" {
+			continue
+		}
+		out = append(out, Entry{
+			ExternalID: id,
+			Title:      title,
+			Status:     st,
+			Score:      rt.Score,
+			Progress:   rt.Episodes,
+			Notes:      strings.TrimSpace(rt.Text),
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greg5320/AniFlow/backend/services/library/internal/store"
+)
+
+const malExport = `<?xml version="1.0" encoding="UTF-8" ?>
+<myanimelist>
+	<myinfo><user_name>alice</user_name></myinfo>
+	<anime>
+		<series_animedb_id>52991</series_animedb_id>
+		<series_title><![CDATA[Sousou no Frieren]]></series_title>
+		<my_watched_episodes>28</my_watched_episodes>
+		<my_score>10</my_score>
+		<my_status>Completed</my_status>
+		<my_comments><![CDATA[ rewatch later ]]></my_comments>
+	</anime>
+	<anime>
+		<series_animedb_id>21</series_animedb_id>
+		<series_title>One Piece</series_title>
+		<my_watched_episodes>400</my_watched_episodes>
+		<my_score>0</my_score>
+		<my_status>3</my_status>
+	</anime>
+	<anime>
+		<series_animedb_id>1</series_animedb_id>
+		<series_title>Cowboy Bebop</series_title>
+		<my_status>Rewatching</my_status>
+	</anime>
+</myanimelist>`
+
+var malEntries = []Entry{
+	{ExternalID: "52991", Title: "Sousou no Frieren", Status: store.StatusCompleted, Score: 10, Progress: 28, Notes: "rewatch later"},
+	{ExternalID: "21", Title: "One Piece", Status: store.StatusOnHold, Progress: 400},
+	{ExternalID: "1", Title: "Cowboy Bebop", Status: store.StatusPlanned},
+}
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseMAL(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"plain":   []byte(malExport),
+		"gzipped": gzipped(t, malExport),
+	} {
+		got, err := Parse(FormatMAL, bytes.NewReader(data), 1<<20)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(got, malEntries) {
+			t.Errorf("%s: got %+v", name, got)
+		}
+	}
+}
+
+func TestParseShikimori(t *testing.T) {
+	data := `[
+		{"target_id": 52991, "target_type": "Anime", "target_title": "Sousou no Frieren", "score": 9, "status": "rewatching", "episodes": 3, "text": "again "},
+		{"target_id": 5114, "target_type": "Anime", "target_title": "", "target_title_ru": "Стальной алхимик", "status": "on_hold", "episodes": 20},
+		{"target_id": 2, "target_type": "Manga", "target_title": "Berserk", "status": "watching"},
+		{"target_id": 30, "target_title": "Neon Genesis Evangelion", "status": "unknown"},
+		{"target_type": "Anime", "target_title": "No ID", "status": "completed"}
+	]`
+	got, err := Parse(FormatShikimori, strings.NewReader(data), 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{
+		{ExternalID: "52991", Title: "Sousou no Frieren", Status: store.StatusWatching, Score: 9, Progress: 3, Notes: "again"},
+		{ExternalID: "5114", Title: "Стальной алхимик", Status: store.StatusOnHold, Progress: 20},
+		{ExternalID: "30", Title: "Neon Genesis Evangelion", Status: store.StatusPlanned},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	// Compresses far below the limit but inflates past it.
+	bomb := gzipped(t, "<myanimelist>"+strings.Repeat(" ", 1<<20)+"</myanimelist>")
+	for name, tc := range map[string]struct {
+		format Format
+		data   []byte
+	}{
+		"gzip over the limit":  {FormatMAL, bomb},
+		"plain over the limit": {FormatMAL, []byte(malExport + strings.Repeat(" ", 1<<20))},
+		"broken gzip":          {FormatMAL, []byte{0x1f, 0x8b, 0}},
+		"malformed xml":        {FormatMAL, []byte("<myanimelist><anime>")},
+		"malformed json":       {FormatShikimori, []byte(`[{"target_id": }]`)},
+		"unknown format":       {"anilist", []byte("{}")},
+	} {
+		if _, err := Parse(tc.format, bytes.NewReader(tc.data), 1<<20); err == nil {
+			t.Errorf("%s: parsed", name)
+		}
+	}
+	if len(bomb) >= 1<<20 {
+		t.Fatalf("compressed input is %d bytes", len(bomb))
+	}
+}
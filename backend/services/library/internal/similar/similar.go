@@ -0,0 +1,160 @@
+// Package similar computes "users also watched" neighbours from every
+// user's library: two titles are similar when the same users keep both.
+package similar
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/greg5320/AniFlow/backend/services/library/internal/store"
+)
+
+const (
+	// minUsers is how many users must share two titles before they count as
+	// similar, so one person's list does not make recommendations.
+	minUsers = 2
+	// maxNeighbours bounds what is kept per title.
+	maxNeighbours = 50
+)
+
+type Entry struct {
+	KodikID   string
+	AniflowID string
+	// Score is the cosine similarity of the two titles' user sets.
+	Score float64
+	// Users is how many users have both titles.
+	Users int
+}
+
+// key identifies a title across re-issued Kodik materials.
+func key(kodikID, aniflowID string) string {
+	if aniflowID != "" {
+		return "af:" + aniflowID
+	}
+	return "k:" + kodikID
+}
+
+// liked reports whether an item says the user enjoyed the title.
+func liked(it store.Item) bool {
+	if it.Status == store.StatusDropped {
+		return false
+	}
+	return it.Score == 0 || it.Score >= 6
+}
+
+// Build computes neighbours for every title from a snapshot of all items.
+func Build(items []store.Item) map[string][]Entry {
+	titles := make(map[string]Entry)
+	byUser := make(map[string][]string)
+	for _, it := range items {
+		if !liked(it) {
+			continue
+		}
+		k := key(it.KodikID, it.AniflowID)
+		titles[k] = Entry{KodikID: it.KodikID, AniflowID: it.AniflowID}
+		byUser[it.UserID] = append(byUser[it.UserID], k)
+	}
+
+	users := make(map[string]int, len(titles))
+	pairs := make(map[[2]string]int)
+	for _, ks := range byUser {
+		sort.Strings(ks)
+		ks = compact(ks)
+		for i, a := range ks {
+			users[a]++
+			for _, b := range ks[i+1:] {
+				pairs[[2]string{a, b}]++
+			}
+		}
+	}
+
+	out := make(map[string][]Entry)
+	for p, n := range pairs {
+		if n < minUsers {
+			continue
+		}
+		score := float64(n) / math.Sqrt(float64(users[p[0]]*users[p[1]]))
+		a, b := titles[p[0]], titles[p[1]]
+		b.Score, b.Users = score, n
+		a.Score, a.Users = score, n
+		out[p[0]] = append(out[p[0]], b)
+		out[p[1]] = append(out[p[1]], a)
+	}
+	for k, es := range out {
+		sort.Slice(es, func(i, j int) bool {
+			if es[i].Score != es[j].Score {
+				return es[i].Score > es[j].Score
+			}
+			return es[i].Users > es[j].Users
+		})
+		if len(es) > maxNeighbours {
+			out[k] = es[:maxNeighbours]
+		}
+	}
+	return out
+}
+
+func compact(sorted []string) []string {
+	out := sorted[:0]
+	for i, s := range sorted {
+		if i == 0 || s != sorted[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Job keeps the latest neighbours computed from a store.
+type Job struct {
+	store *store.Memory
+
+	mu        sync.RWMutex
+	table     map[string][]Entry
+	updatedAt time.Time
+}
+
+func NewJob(st *store.Memory) *Job {
+	return &Job{store: st, table: make(map[string][]Entry)}
+}
+
+func (j *Job) Refresh() {
+	table := Build(j.store.All())
+	j.mu.Lock()
+	j.table, j.updatedAt = table, time.Now().UTC()
+	j.mu.Unlock()
+}
+
+// Run refreshes the table every interval until ctx is done.
+func (j *Job) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		start := time.Now()
+		j.Refresh()
+		log.Printf("[similar] refreshed in %s", time.Since(start))
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Similar returns up to limit neighbours of a title, looked up by AniFlow
+// ID first, and when the table was computed.
+func (j *Job) Similar(kodikID, aniflowID string, limit int) ([]Entry, time.Time) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	es := j.table[key(kodikID, aniflowID)]
+	if len(es) == 0 && aniflowID != "" && kodikID != "" {
+		es = j.table[key(kodikID, "")]
+	}
+	if len(es) > limit {
+		es = es[:limit]
+	}
+	return append([]Entry(nil), es...), j.updatedAt
+}
@@ -0,0 +1,304 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type Visibility string
+
+const (
+	// Private collections are visible to their owner only.
+	Private Visibility = "private"
+	// Unlisted collections are readable by anyone with the slug.
+	Unlisted Visibility = "unlisted"
+	// Public collections are also listed on the owner's profile.
+	Public Visibility = "public"
+)
+
+const maxCollectionItems = 500
+
+// Errors from collection methods wrap one of these.
+var (
+	ErrNotFound = errors.New("not found")
+	ErrInvalid  = errors.New("invalid argument")
+	ErrFull     = errors.New("collection full")
+)
+
+// kindError is an error message that wraps its kind.
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string { return e.msg }
+func (e *kindError) Unwrap() error { return e.kind }
+
+func errorf(kind error, format string, args ...any) error {
+	return &kindError{kind: kind, msg: fmt.Sprintf(format, args...)}
+}
+
+type CollectionItem struct {
+	KodikID   string
+	AniflowID string
+	Note      string
+	AddedAt   time.Time
+}
+
+type Collection struct {
+	ID          string
+	UserID      string
+	Slug        string
+	Name        string
+	Description string
+	Visibility  Visibility
+	// Items in the owner's order.
+	Items     []CollectionItem
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CollectionUpdate lists the fields to change; nil fields are kept.
+type CollectionUpdate struct {
+	Name        *string
+	Description *string
+	Visibility  *Visibility
+}
+
+// ReadableBy reports whether viewerID may read c; an empty viewer is
+// anonymous.
+func (c Collection) ReadableBy(viewerID string) bool {
+	return c.Visibility != Private || viewerID == c.UserID
+}
+
+func (c *Collection) clone() Collection {
+	out := *c
+	out.Items = append([]CollectionItem(nil), c.Items...)
+	return out
+}
+
+// slugify keeps ASCII letters and digits from name and adds a random
+// suffix so slugs stay unique and unguessable.
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	base := strings.Trim(b.String(), "-")
+	if len(base) > 48 {
+		base = strings.Trim(base[:48], "-")
+	}
+	if base == "" {
+		base = "list"
+	}
+	return base + "-" + newID()[:8]
+}
+
+func validVisibility(v Visibility) bool {
+	return v == Private || v == Unlisted || v == Public
+}
+
+func (m *Memory) CreateCollection(userID, name, description string, vis Visibility) (Collection, error) {
+	if strings.TrimSpace(name) == "" {
+		return Collection{}, errorf(ErrInvalid, "collection name required")
+	}
+	if vis == "" {
+		vis = Private
+	}
+	if !validVisibility(vis) {
+		return Collection{}, errorf(ErrInvalid, "unknown visibility %q", vis)
+	}
+	now := time.Now().UTC()
+	c := &Collection{
+		ID:          newID(),
+		UserID:      userID,
+		Slug:        slugify(name),
+		Name:        strings.TrimSpace(name),
+		Description: description,
+		Visibility:  vis,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.collections[c.ID] = c
+	m.slugs[c.Slug] = c.ID
+	return c.clone(), nil
+}
+
+// owned must be called with mu held.
+func (m *Memory) owned(userID, id string) (*Collection, error) {
+	c, ok := m.collections[id]
+	if !ok || c.UserID != userID {
+		return nil, errorf(ErrNotFound, "collection %s not found", id)
+	}
+	return c, nil
+}
+
+func (m *Memory) UpdateCollection(userID, id string, u CollectionUpdate) (Collection, error) {
+	if u.Name != nil && strings.TrimSpace(*u.Name) == "" {
+		return Collection{}, errorf(ErrInvalid, "collection name required")
+	}
+	if u.Visibility != nil && !validVisibility(*u.Visibility) {
+		return Collection{}, errorf(ErrInvalid, "unknown visibility %q", *u.Visibility)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, err := m.owned(userID, id)
+	if err != nil {
+		return Collection{}, err
+	}
+	if u.Name != nil {
+		c.Name = strings.TrimSpace(*u.Name)
+	}
+	if u.Description != nil {
+		c.Description = *u.Description
+	}
+	if u.Visibility != nil {
+		c.Visibility = *u.Visibility
+	}
+	c.UpdatedAt = time.Now().UTC()
+	return c.clone(), nil
+}
+
+func (m *Memory) DeleteCollection(userID, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, err := m.owned(userID, id)
+	if err != nil {
+		return err
+	}
+	delete(m.collections, id)
+	delete(m.slugs, c.Slug)
+	return nil
+}
+
+// Collection returns a collection by ID or slug if viewerID may read it.
+func (m *Memory) Collection(idOrSlug, viewerID string) (Collection, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.collections[idOrSlug]
+	if !ok {
+		c, ok = m.collections[m.slugs[idOrSlug]]
+	}
+	if !ok || !c.ReadableBy(viewerID) {
+		return Collection{}, errorf(ErrNotFound, "collection %s not found", idOrSlug)
+	}
+	return c.clone(), nil
+}
+
+// Collections lists a user's collections, newest first. Other viewers only
+// see public ones.
+func (m *Memory) Collections(userID, viewerID string) []Collection {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []Collection
+	for _, c := range m.collections {
+		if c.UserID != userID {
+			continue
+		}
+		if viewerID != userID && c.Visibility != Public {
+			continue
+		}
+		out = append(out, c.clone())
+	}
+	sortCollections(out)
+	return out
+}
+
+// AddCollectionItem inserts a title at position, or appends it when
+// position is negative or past the end. A title already in the collection
+// is moved instead and keeps its note unless a new one is given.
+func (m *Memory) AddCollectionItem(userID, id string, item CollectionItem, position int) (Collection, error) {
+	if item.KodikID == "" {
+		return Collection{}, errorf(ErrInvalid, "kodik_id required")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, err := m.owned(userID, id)
+	if err != nil {
+		return Collection{}, err
+	}
+	item.AddedAt = time.Now().UTC()
+	if i := c.indexOf(item.KodikID, item.AniflowID); i >= 0 {
+		old := c.Items[i]
+		item.AddedAt = old.AddedAt
+		if item.Note == "" {
+			item.Note = old.Note
+		}
+		c.Items = append(c.Items[:i], c.Items[i+1:]...)
+	} else if len(c.Items) >= maxCollectionItems {
+		return Collection{}, errorf(ErrFull, "collection is full (%d titles)", maxCollectionItems)
+	}
+	if position < 0 || position > len(c.Items) {
+		position = len(c.Items)
+	}
+	c.Items = append(c.Items, CollectionItem{})
+	copy(c.Items[position+1:], c.Items[position:])
+	c.Items[position] = item
+	c.UpdatedAt = time.Now().UTC()
+	return c.clone(), nil
+}
+
+func (m *Memory) RemoveCollectionItem(userID, id, kodikID string) (Collection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, err := m.owned(userID, id)
+	if err != nil {
+		return Collection{}, err
+	}
+	i := c.indexOf(kodikID, "")
+	if i < 0 {
+		return Collection{}, errorf(ErrNotFound, "%s is not in collection %s", kodikID, id)
+	}
+	c.Items = append(c.Items[:i], c.Items[i+1:]...)
+	c.UpdatedAt = time.Now().UTC()
+	return c.clone(), nil
+}
+
+// ReorderCollection puts the items in the order of kodikIDs, which must
+// name every item exactly once.
+func (m *Memory) ReorderCollection(userID, id string, kodikIDs []string) (Collection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, err := m.owned(userID, id)
+	if err != nil {
+		return Collection{}, err
+	}
+	if len(kodikIDs) != len(c.Items) {
+		return Collection{}, errorf(ErrInvalid, "order must list all %d titles", len(c.Items))
+	}
+	items := make([]CollectionItem, 0, len(c.Items))
+	used := make(map[int]bool, len(c.Items))
+	for _, kid := range kodikIDs {
+		i := c.indexOf(kid, "")
+		if i < 0 || used[i] {
+			return Collection{}, errorf(ErrInvalid, "order must list every title once, got %s", kid)
+		}
+		used[i] = true
+		items = append(items, c.Items[i])
+	}
+	c.Items = items
+	c.UpdatedAt = time.Now().UTC()
+	return c.clone(), nil
+}
+
+// indexOf finds a title by Kodik ID or, when given, AniFlow ID.
+func (c *Collection) indexOf(kodikID, aniflowID string) int {
+	for i, it := range c.Items {
+		if it.KodikID == kodikID || (aniflowID != "" && it.AniflowID == aniflowID) {
+			return i
+		}
+	}
+	return -1
+}
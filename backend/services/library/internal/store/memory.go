@@ -0,0 +1,249 @@
+package store
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPlanned   Status = "planned"
+	StatusWatching  Status = "watching"
+	StatusCompleted Status = "completed"
+	StatusOnHold    Status = "on_hold"
+	StatusDropped   Status = "dropped"
+)
+
+// MaxScore is the top of the 1-10 rating scale; 0 means not rated.
+const MaxScore = 10
+
+const (
+	maxNotesLen = 4000
+	// maxHistory caps the changes kept per item; the oldest are dropped.
+	maxHistory = 500
+)
+
+type Item struct {
+	ID        string
+	UserID    string
+	KodikID   string
+	AniflowID string
+	AddedAt   time.Time
+	Status    Status
+	Score     int
+	Progress  int
+	Notes     string
+	UpdatedAt time.Time
+	// History holds the item's status, score and progress after every
+	// change, oldest first, starting with the state it was added in.
+	History []Change
+}
+
+// Change is a snapshot of an item after an update.
+type Change struct {
+	At       time.Time
+	Status   Status
+	Score    int
+	Progress int
+}
+
+func (it *Item) record() {
+	it.History = append(it.History, Change{
+		At:       it.UpdatedAt,
+		Status:   it.Status,
+		Score:    it.Score,
+		Progress: it.Progress,
+	})
+	if n := len(it.History); n > maxHistory {
+		it.History = append([]Change(nil), it.History[n-maxHistory:]...)
+	}
+}
+
+// Update lists the fields to change on an item; nil fields are kept.
+type Update struct {
+	Status   *Status
+	Score    *int
+	Progress *int
+	Notes    *string
+}
+
+// Memory keeps watchlists and collections in process memory.
+type Memory struct {
+	mu    sync.RWMutex
+	items map[string]map[string]*Item
+
+	collections map[string]*Collection
+	slugs       map[string]string
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		items:       make(map[string]map[string]*Item),
+		collections: make(map[string]*Collection),
+		slugs:       make(map[string]string),
+	}
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Add puts a title on the user's watchlist. Titles are the same when their
+// AniFlow IDs match or, lacking one, their Kodik IDs do. Adding a title
+// twice returns the existing item and false.
+func (m *Memory) Add(userID, kodikID, aniflowID string) (Item, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	byKodik, ok := m.items[userID]
+	if !ok {
+		byKodik = make(map[string]*Item)
+		m.items[userID] = byKodik
+	}
+	if it, ok := byKodik[kodikID]; ok {
+		if it.AniflowID == "" && aniflowID != "" {
+			it.AniflowID = aniflowID
+		}
+		return *it, false
+	}
+	if aniflowID != "" {
+		for _, it := range byKodik {
+			if it.AniflowID == aniflowID {
+				return *it, false
+			}
+		}
+	}
+	now := time.Now().UTC()
+	it := &Item{
+		ID:        newID(),
+		UserID:    userID,
+		KodikID:   kodikID,
+		AniflowID: aniflowID,
+		AddedAt:   now,
+		Status:    StatusPlanned,
+		UpdatedAt: now,
+	}
+	it.record()
+	byKodik[kodikID] = it
+	return *it, true
+}
+
+// Validate reports whether Update would accept u.
+func (u Update) Validate() error {
+	if u.Status != nil {
+		switch *u.Status {
+		case StatusPlanned, StatusWatching, StatusCompleted, StatusOnHold, StatusDropped:
+		default:
+			return fmt.Errorf("unknown status %q", *u.Status)
+		}
+	}
+	if u.Score != nil && (*u.Score < 0 || *u.Score > MaxScore) {
+		return fmt.Errorf("score must be between 0 and %d", MaxScore)
+	}
+	if u.Progress != nil && *u.Progress < 0 {
+		return fmt.Errorf("progress must not be negative")
+	}
+	if u.Notes != nil && len(*u.Notes) > maxNotesLen {
+		return fmt.Errorf("notes must not exceed %d bytes", maxNotesLen)
+	}
+	return nil
+}
+
+// Update changes an item on the user's watchlist.
+func (m *Memory) Update(userID, kodikID string, u Update) (Item, error) {
+	if err := u.Validate(); err != nil {
+		return Item{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	it, ok := m.items[userID][kodikID]
+	if !ok {
+		return Item{}, fmt.Errorf("%s is not on the watchlist of %s", kodikID, userID)
+	}
+	prev := *it
+	if u.Status != nil {
+		it.Status = *u.Status
+	}
+	if u.Score != nil {
+		it.Score = *u.Score
+	}
+	if u.Progress != nil {
+		it.Progress = *u.Progress
+	}
+	if u.Notes != nil {
+		it.Notes = *u.Notes
+	}
+	it.UpdatedAt = time.Now().UTC()
+	if it.Status != prev.Status || it.Score != prev.Score || it.Progress != prev.Progress {
+		it.record()
+	}
+	return *it, nil
+}
+
+// List returns the user's watchlist, oldest first.
+func (m *Memory) List(userID string) []Item {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]Item, 0, len(m.items[userID]))
+	for _, it := range m.items[userID] {
+		out = append(out, *it)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].AddedAt.Before(out[j].AddedAt)
+	})
+	return out
+}
+
+func sortCollections(cs []Collection) {
+	sort.Slice(cs, func(i, j int) bool {
+		return cs[i].CreatedAt.After(cs[j].CreatedAt)
+	})
+}
+
+// All returns every item of every user, in no particular order.
+func (m *Memory) All() []Item {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []Item
+	for _, byKodik := range m.items {
+		for _, it := range byKodik {
+			out = append(out, *it)
+		}
+	}
+	return out
+}
+
+// Watchers maps each of kodikIDs to the users watching it. With no ids it
+// covers every title on any watchlist.
+func (m *Memory) Watchers(kodikIDs []string) map[string][]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	want := make(map[string]bool, len(kodikIDs))
+	for _, id := range kodikIDs {
+		want[id] = true
+	}
+	out := make(map[string][]string)
+	for userID, byKodik := range m.items {
+		for kodikID := range byKodik {
+			if len(want) > 0 && !want[kodikID] {
+				continue
+			}
+			out[kodikID] = append(out[kodikID], userID)
+		}
+	}
+	for _, users := range out {
+		sort.Strings(users)
+	}
+	return out
+}
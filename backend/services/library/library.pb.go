@@ -9,6 +9,7 @@ package librarypb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -22,12 +23,287 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_PLANNED     Status = 1
+	Status_STATUS_WATCHING    Status = 2
+	Status_STATUS_COMPLETED   Status = 3
+	Status_STATUS_ON_HOLD     Status = 4
+	Status_STATUS_DROPPED     Status = 5
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_PLANNED",
+		2: "STATUS_WATCHING",
+		3: "STATUS_COMPLETED",
+		4: "STATUS_ON_HOLD",
+		5: "STATUS_DROPPED",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_PLANNED":     1,
+		"STATUS_WATCHING":    2,
+		"STATUS_COMPLETED":   3,
+		"STATUS_ON_HOLD":     4,
+		"STATUS_DROPPED":     5,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_library_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_library_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{0}
+}
+
+type Visibility int32
+
+const (
+	Visibility_VISIBILITY_UNSPECIFIED Visibility = 0
+	// only the owner can see it
+	Visibility_VISIBILITY_PRIVATE Visibility = 1
+	// anyone with the slug can read it
+	Visibility_VISIBILITY_UNLISTED Visibility = 2
+	// also listed on the owner's profile
+	Visibility_VISIBILITY_PUBLIC Visibility = 3
+)
+
+// Enum value maps for Visibility.
+var (
+	Visibility_name = map[int32]string{
+		0: "VISIBILITY_UNSPECIFIED",
+		1: "VISIBILITY_PRIVATE",
+		2: "VISIBILITY_UNLISTED",
+		3: "VISIBILITY_PUBLIC",
+	}
+	Visibility_value = map[string]int32{
+		"VISIBILITY_UNSPECIFIED": 0,
+		"VISIBILITY_PRIVATE":     1,
+		"VISIBILITY_UNLISTED":    2,
+		"VISIBILITY_PUBLIC":      3,
+	}
+)
+
+func (x Visibility) Enum() *Visibility {
+	p := new(Visibility)
+	*p = x
+	return p
+}
+
+func (x Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_library_proto_enumTypes[1].Descriptor()
+}
+
+func (Visibility) Type() protoreflect.EnumType {
+	return &file_library_proto_enumTypes[1]
+}
+
+func (x Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Visibility.Descriptor instead.
+func (Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{1}
+}
+
+type ImportFormat int32
+
+const (
+	ImportFormat_IMPORT_FORMAT_UNSPECIFIED ImportFormat = 0
+	// animelist export from MyAnimeList, optionally gzipped
+	ImportFormat_IMPORT_FORMAT_MAL_XML ImportFormat = 1
+	// JSON export of a Shikimori anime list
+	ImportFormat_IMPORT_FORMAT_SHIKIMORI_JSON ImportFormat = 2
+)
+
+// Enum value maps for ImportFormat.
+var (
+	ImportFormat_name = map[int32]string{
+		0: "IMPORT_FORMAT_UNSPECIFIED",
+		1: "IMPORT_FORMAT_MAL_XML",
+		2: "IMPORT_FORMAT_SHIKIMORI_JSON",
+	}
+	ImportFormat_value = map[string]int32{
+		"IMPORT_FORMAT_UNSPECIFIED":    0,
+		"IMPORT_FORMAT_MAL_XML":        1,
+		"IMPORT_FORMAT_SHIKIMORI_JSON": 2,
+	}
+)
+
+func (x ImportFormat) Enum() *ImportFormat {
+	p := new(ImportFormat)
+	*p = x
+	return p
+}
+
+func (x ImportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_library_proto_enumTypes[2].Descriptor()
+}
+
+func (ImportFormat) Type() protoreflect.EnumType {
+	return &file_library_proto_enumTypes[2]
+}
+
+func (x ImportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportFormat.Descriptor instead.
+func (ImportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{2}
+}
+
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0
+	// MyAnimeList import format; titles without a MAL id are left out
+	ExportFormat_EXPORT_FORMAT_MAL_XML ExportFormat = 1
+	ExportFormat_EXPORT_FORMAT_CSV     ExportFormat = 2
+	// everything the library keeps, including change history and collections
+	ExportFormat_EXPORT_FORMAT_JSON ExportFormat = 3
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_MAL_XML",
+		2: "EXPORT_FORMAT_CSV",
+		3: "EXPORT_FORMAT_JSON",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_MAL_XML":     1,
+		"EXPORT_FORMAT_CSV":         2,
+		"EXPORT_FORMAT_JSON":        3,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_library_proto_enumTypes[3].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_library_proto_enumTypes[3]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{3}
+}
+
+type ImportJob_State int32
+
+const (
+	ImportJob_STATE_UNSPECIFIED ImportJob_State = 0
+	ImportJob_STATE_RUNNING     ImportJob_State = 1
+	ImportJob_STATE_DONE        ImportJob_State = 2
+)
+
+// Enum value maps for ImportJob_State.
+var (
+	ImportJob_State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "STATE_RUNNING",
+		2: "STATE_DONE",
+	}
+	ImportJob_State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"STATE_RUNNING":     1,
+		"STATE_DONE":        2,
+	}
+)
+
+func (x ImportJob_State) Enum() *ImportJob_State {
+	p := new(ImportJob_State)
+	*p = x
+	return p
+}
+
+func (x ImportJob_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportJob_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_library_proto_enumTypes[4].Descriptor()
+}
+
+func (ImportJob_State) Type() protoreflect.EnumType {
+	return &file_library_proto_enumTypes[4]
+}
+
+func (x ImportJob_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportJob_State.Descriptor instead.
+func (ImportJob_State) EnumDescriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{30, 0}
+}
+
 type WatchlistItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	KodikId       string                 `protobuf:"bytes,3,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
-	AddedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	KodikId   string                 `protobuf:"bytes,3,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	AddedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	AniflowId string                 `protobuf:"bytes,5,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
+	Status    Status                 `protobuf:"varint,6,opt,name=status,proto3,enum=aniflow.library.v1.Status" json:"status,omitempty"`
+	// 1-10, 0 when not rated
+	Score int32 `protobuf:"varint,7,opt,name=score,proto3" json:"score,omitempty"`
+	// episodes watched
+	Progress      int32                  `protobuf:"varint,8,opt,name=progress,proto3" json:"progress,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Notes         string                 `protobuf:"bytes,10,opt,name=notes,proto3" json:"notes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -90,10 +366,54 @@ func (x *WatchlistItem) GetAddedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *WatchlistItem) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
+func (x *WatchlistItem) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *WatchlistItem) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *WatchlistItem) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *WatchlistItem) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *WatchlistItem) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
 type AddRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	KodikId       string                 `protobuf:"bytes,2,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UserId  string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	KodikId string                 `protobuf:"bytes,2,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	// when set, the title is deduplicated by aniflow_id instead of kodik_id
+	AniflowId     string `protobuf:"bytes,3,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -142,6 +462,13 @@ func (x *AddRequest) GetKodikId() string {
 	return ""
 }
 
+func (x *AddRequest) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
 type AddResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Item          *WatchlistItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
@@ -274,29 +601,2162 @@ func (x *GetWatchlistResponse) GetItems() []*WatchlistItem {
 	return nil
 }
 
-var File_library_proto protoreflect.FileDescriptor
+type ListWatchersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// empty means every title on any watchlist
+	KodikIds      []string `protobuf:"bytes,1,rep,name=kodik_ids,json=kodikIds,proto3" json:"kodik_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_library_proto_rawDesc = "" +
-	"\n" +
-	"\rlibrary.proto\x12\x12aniflow.library.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8a\x01\n" +
-	"\rWatchlistItem\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
-	"\bkodik_id\x18\x03 \x01(\tR\akodikId\x125\n" +
-	"\badded_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\"@\n" +
-	"\n" +
-	"AddRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
-	"\bkodik_id\x18\x02 \x01(\tR\akodikId\"D\n" +
-	"\vAddResponse\x125\n" +
-	"\x04item\x18\x01 \x01(\v2!.aniflow.library.v1.WatchlistItemR\x04item\".\n" +
-	"\x13GetWatchlistRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"O\n" +
-	"\x14GetWatchlistResponse\x127\n" +
-	"\x05items\x18\x01 \x03(\v2!.aniflow.library.v1.WatchlistItemR\x05items2\xbf\x01\n" +
-	"\aLibrary\x12Q\n" +
-	"\x0eAddToWatchlist\x12\x1e.aniflow.library.v1.AddRequest\x1a\x1f.aniflow.library.v1.AddResponse\x12a\n" +
-	"\fGetWatchlist\x12'.aniflow.library.v1.GetWatchlistRequest\x1a(.aniflow.library.v1.GetWatchlistResponseB<Z:github.com/greg5320/aniflow/services/library/gen;librarypbb\x06proto3"
+func (x *ListWatchersRequest) Reset() {
+	*x = ListWatchersRequest{}
+	mi := &file_library_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWatchersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWatchersRequest) ProtoMessage() {}
+
+func (x *ListWatchersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWatchersRequest.ProtoReflect.Descriptor instead.
+func (*ListWatchersRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListWatchersRequest) GetKodikIds() []string {
+	if x != nil {
+		return x.KodikIds
+	}
+	return nil
+}
+
+type Watchers struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KodikId       string                 `protobuf:"bytes,1,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Watchers) Reset() {
+	*x = Watchers{}
+	mi := &file_library_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Watchers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Watchers) ProtoMessage() {}
+
+func (x *Watchers) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Watchers.ProtoReflect.Descriptor instead.
+func (*Watchers) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Watchers) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+func (x *Watchers) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type ListWatchersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Watchers      []*Watchers            `protobuf:"bytes,1,rep,name=watchers,proto3" json:"watchers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWatchersResponse) Reset() {
+	*x = ListWatchersResponse{}
+	mi := &file_library_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWatchersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWatchersResponse) ProtoMessage() {}
+
+func (x *ListWatchersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWatchersResponse.ProtoReflect.Descriptor instead.
+func (*ListWatchersResponse) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListWatchersResponse) GetWatchers() []*Watchers {
+	if x != nil {
+		return x.Watchers
+	}
+	return nil
+}
+
+// UpdateItemRequest changes a watchlist item; unset fields are kept.
+type UpdateItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	KodikId       string                 `protobuf:"bytes,2,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	Status        Status                 `protobuf:"varint,3,opt,name=status,proto3,enum=aniflow.library.v1.Status" json:"status,omitempty"`
+	Score         *int32                 `protobuf:"varint,4,opt,name=score,proto3,oneof" json:"score,omitempty"`
+	Progress      *int32                 `protobuf:"varint,5,opt,name=progress,proto3,oneof" json:"progress,omitempty"`
+	Notes         *string                `protobuf:"bytes,6,opt,name=notes,proto3,oneof" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateItemRequest) Reset() {
+	*x = UpdateItemRequest{}
+	mi := &file_library_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateItemRequest) ProtoMessage() {}
+
+func (x *UpdateItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateItemRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateItemRequest) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+func (x *UpdateItemRequest) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateItemRequest) GetScore() int32 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+func (x *UpdateItemRequest) GetProgress() int32 {
+	if x != nil && x.Progress != nil {
+		return *x.Progress
+	}
+	return 0
+}
+
+func (x *UpdateItemRequest) GetNotes() string {
+	if x != nil && x.Notes != nil {
+		return *x.Notes
+	}
+	return ""
+}
+
+type UpdateItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *WatchlistItem         `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateItemResponse) Reset() {
+	*x = UpdateItemResponse{}
+	mi := &file_library_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateItemResponse) ProtoMessage() {}
+
+func (x *UpdateItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateItemResponse.ProtoReflect.Descriptor instead.
+func (*UpdateItemResponse) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateItemResponse) GetItem() *WatchlistItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// GetSimilarRequest names a title by aniflow_id, kodik_id or both.
+type GetSimilarRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	KodikId   string                 `protobuf:"bytes,1,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	AniflowId string                 `protobuf:"bytes,2,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
+	// defaults to 20
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSimilarRequest) Reset() {
+	*x = GetSimilarRequest{}
+	mi := &file_library_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSimilarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSimilarRequest) ProtoMessage() {}
+
+func (x *GetSimilarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSimilarRequest.ProtoReflect.Descriptor instead.
+func (*GetSimilarRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetSimilarRequest) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+func (x *GetSimilarRequest) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
+func (x *GetSimilarRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SimilarItem struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	KodikId   string                 `protobuf:"bytes,1,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	AniflowId string                 `protobuf:"bytes,2,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
+	Score     float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	// users who have both titles
+	Users         int32 `protobuf:"varint,4,opt,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarItem) Reset() {
+	*x = SimilarItem{}
+	mi := &file_library_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarItem) ProtoMessage() {}
+
+func (x *SimilarItem) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarItem.ProtoReflect.Descriptor instead.
+func (*SimilarItem) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SimilarItem) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+func (x *SimilarItem) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
+func (x *SimilarItem) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SimilarItem) GetUsers() int32 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+type GetSimilarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*SimilarItem         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	ComputedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSimilarResponse) Reset() {
+	*x = GetSimilarResponse{}
+	mi := &file_library_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSimilarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSimilarResponse) ProtoMessage() {}
+
+func (x *GetSimilarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSimilarResponse.ProtoReflect.Descriptor instead.
+func (*GetSimilarResponse) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetSimilarResponse) GetItems() []*SimilarItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetSimilarResponse) GetComputedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ComputedAt
+	}
+	return nil
+}
+
+type GetTrendingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// how far back activity counts, 30 days at most
+	Window        *durationpb.Duration `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	Limit         int32                `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendingRequest) Reset() {
+	*x = GetTrendingRequest{}
+	mi := &file_library_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendingRequest) ProtoMessage() {}
+
+func (x *GetTrendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendingRequest.ProtoReflect.Descriptor instead.
+func (*GetTrendingRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTrendingRequest) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *GetTrendingRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TrendingItem struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	KodikId   string                 `protobuf:"bytes,1,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	AniflowId string                 `protobuf:"bytes,2,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
+	// users who added the title in the window
+	Adds int32 `protobuf:"varint,3,opt,name=adds,proto3" json:"adds,omitempty"`
+	// users who reported progress in the window
+	Progress      int32   `protobuf:"varint,4,opt,name=progress,proto3" json:"progress,omitempty"`
+	Score         float64 `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendingItem) Reset() {
+	*x = TrendingItem{}
+	mi := &file_library_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendingItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingItem) ProtoMessage() {}
+
+func (x *TrendingItem) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingItem.ProtoReflect.Descriptor instead.
+func (*TrendingItem) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TrendingItem) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+func (x *TrendingItem) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
+func (x *TrendingItem) GetAdds() int32 {
+	if x != nil {
+		return x.Adds
+	}
+	return 0
+}
+
+func (x *TrendingItem) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *TrendingItem) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetTrendingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*TrendingItem        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendingResponse) Reset() {
+	*x = GetTrendingResponse{}
+	mi := &file_library_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendingResponse) ProtoMessage() {}
+
+func (x *GetTrendingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendingResponse.ProtoReflect.Descriptor instead.
+func (*GetTrendingResponse) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetTrendingResponse) GetItems() []*TrendingItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CollectionItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KodikId       string                 `protobuf:"bytes,1,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	AniflowId     string                 `protobuf:"bytes,2,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	AddedAt       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionItem) Reset() {
+	*x = CollectionItem{}
+	mi := &file_library_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionItem) ProtoMessage() {}
+
+func (x *CollectionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionItem.ProtoReflect.Descriptor instead.
+func (*CollectionItem) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CollectionItem) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+func (x *CollectionItem) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
+func (x *CollectionItem) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *CollectionItem) GetAddedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+type Collection struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId      string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Slug        string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	Name        string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Visibility  Visibility             `protobuf:"varint,6,opt,name=visibility,proto3,enum=aniflow.library.v1.Visibility" json:"visibility,omitempty"`
+	// in the owner's order
+	Items         []*CollectionItem      `protobuf:"bytes,7,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collection) Reset() {
+	*x = Collection{}
+	mi := &file_library_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection) ProtoMessage() {}
+
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Collection) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Collection) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Collection) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Collection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Collection) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Collection) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+func (x *Collection) GetItems() []*CollectionItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Collection) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Collection) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateCollectionRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// private when unspecified
+	Visibility    Visibility `protobuf:"varint,4,opt,name=visibility,proto3,enum=aniflow.library.v1.Visibility" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCollectionRequest) Reset() {
+	*x = CreateCollectionRequest{}
+	mi := &file_library_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionRequest) ProtoMessage() {}
+
+func (x *CreateCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionRequest.ProtoReflect.Descriptor instead.
+func (*CreateCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateCollectionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+// UpdateCollectionRequest changes a collection; unset fields are kept.
+type UpdateCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Name          *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Visibility    Visibility             `protobuf:"varint,5,opt,name=visibility,proto3,enum=aniflow.library.v1.Visibility" json:"visibility,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCollectionRequest) Reset() {
+	*x = UpdateCollectionRequest{}
+	mi := &file_library_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCollectionRequest) ProtoMessage() {}
+
+func (x *UpdateCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCollectionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateCollectionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateCollectionRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *UpdateCollectionRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCollectionRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateCollectionRequest) GetVisibility() Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return Visibility_VISIBILITY_UNSPECIFIED
+}
+
+type DeleteCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCollectionRequest) Reset() {
+	*x = DeleteCollectionRequest{}
+	mi := &file_library_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionRequest) ProtoMessage() {}
+
+func (x *DeleteCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteCollectionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteCollectionRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+type DeleteCollectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCollectionResponse) Reset() {
+	*x = DeleteCollectionResponse{}
+	mi := &file_library_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionResponse) ProtoMessage() {}
+
+func (x *DeleteCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{21}
+}
+
+// GetCollectionRequest reads a collection by id or slug as viewer_id, who
+// is anonymous when empty.
+type GetCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId  string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,3,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionRequest) Reset() {
+	*x = GetCollectionRequest{}
+	mi := &file_library_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionRequest) ProtoMessage() {}
+
+func (x *GetCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionRequest.ProtoReflect.Descriptor instead.
+func (*GetCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetCollectionRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *GetCollectionRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *GetCollectionRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+type ListCollectionsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// viewers other than the owner only see public collections
+	ViewerId      string `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectionsRequest) Reset() {
+	*x = ListCollectionsRequest{}
+	mi := &file_library_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsRequest) ProtoMessage() {}
+
+func (x *ListCollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCollectionsRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListCollectionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListCollectionsRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+type ListCollectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collections   []*Collection          `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectionsResponse) Reset() {
+	*x = ListCollectionsResponse{}
+	mi := &file_library_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectionsResponse) ProtoMessage() {}
+
+func (x *ListCollectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollectionsResponse) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListCollectionsResponse) GetCollections() []*Collection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+type AddCollectionItemRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	UserId       string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CollectionId string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	KodikId      string                 `protobuf:"bytes,3,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	AniflowId    string                 `protobuf:"bytes,4,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
+	Note         string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	// 0-based; appended when unset
+	Position      *int32 `protobuf:"varint,6,opt,name=position,proto3,oneof" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCollectionItemRequest) Reset() {
+	*x = AddCollectionItemRequest{}
+	mi := &file_library_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCollectionItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCollectionItemRequest) ProtoMessage() {}
+
+func (x *AddCollectionItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCollectionItemRequest.ProtoReflect.Descriptor instead.
+func (*AddCollectionItemRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AddCollectionItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddCollectionItemRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *AddCollectionItemRequest) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+func (x *AddCollectionItemRequest) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
+func (x *AddCollectionItemRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *AddCollectionItemRequest) GetPosition() int32 {
+	if x != nil && x.Position != nil {
+		return *x.Position
+	}
+	return 0
+}
+
+type RemoveCollectionItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	KodikId       string                 `protobuf:"bytes,3,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCollectionItemRequest) Reset() {
+	*x = RemoveCollectionItemRequest{}
+	mi := &file_library_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCollectionItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCollectionItemRequest) ProtoMessage() {}
+
+func (x *RemoveCollectionItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCollectionItemRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCollectionItemRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RemoveCollectionItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveCollectionItemRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *RemoveCollectionItemRequest) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+type ReorderCollectionRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	UserId       string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CollectionId string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	// every kodik_id in the collection, in the new order
+	KodikIds      []string `protobuf:"bytes,3,rep,name=kodik_ids,json=kodikIds,proto3" json:"kodik_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderCollectionRequest) Reset() {
+	*x = ReorderCollectionRequest{}
+	mi := &file_library_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderCollectionRequest) ProtoMessage() {}
+
+func (x *ReorderCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderCollectionRequest.ProtoReflect.Descriptor instead.
+func (*ReorderCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReorderCollectionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReorderCollectionRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *ReorderCollectionRequest) GetKodikIds() []string {
+	if x != nil {
+		return x.KodikIds
+	}
+	return nil
+}
+
+type StartImportRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Format ImportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=aniflow.library.v1.ImportFormat" json:"format,omitempty"`
+	Data   []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// replace status, score and progress of titles already on the watchlist
+	Overwrite     bool `protobuf:"varint,4,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartImportRequest) Reset() {
+	*x = StartImportRequest{}
+	mi := &file_library_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartImportRequest) ProtoMessage() {}
+
+func (x *StartImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartImportRequest.ProtoReflect.Descriptor instead.
+func (*StartImportRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *StartImportRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StartImportRequest) GetFormat() ImportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ImportFormat_IMPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *StartImportRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *StartImportRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+// UnmatchedEntry is an exported title the catalog could not map.
+type UnmatchedEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// MyAnimeList / Shikimori anime id
+	ExternalId    string `protobuf:"bytes,1,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	Title         string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Status        Status `protobuf:"varint,3,opt,name=status,proto3,enum=aniflow.library.v1.Status" json:"status,omitempty"`
+	Score         int32  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	Progress      int32  `protobuf:"varint,5,opt,name=progress,proto3" json:"progress,omitempty"`
+	Reason        string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmatchedEntry) Reset() {
+	*x = UnmatchedEntry{}
+	mi := &file_library_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmatchedEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmatchedEntry) ProtoMessage() {}
+
+func (x *UnmatchedEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmatchedEntry.ProtoReflect.Descriptor instead.
+func (*UnmatchedEntry) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UnmatchedEntry) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *UnmatchedEntry) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UnmatchedEntry) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *UnmatchedEntry) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *UnmatchedEntry) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *UnmatchedEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ImportJob struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Format   ImportFormat           `protobuf:"varint,3,opt,name=format,proto3,enum=aniflow.library.v1.ImportFormat" json:"format,omitempty"`
+	State    ImportJob_State        `protobuf:"varint,4,opt,name=state,proto3,enum=aniflow.library.v1.ImportJob_State" json:"state,omitempty"`
+	Total    int32                  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Imported int32                  `protobuf:"varint,6,opt,name=imported,proto3" json:"imported,omitempty"`
+	// already on the watchlist and left as they were
+	Skipped       int32                  `protobuf:"varint,7,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Unmatched     []*UnmatchedEntry      `protobuf:"bytes,8,rep,name=unmatched,proto3" json:"unmatched,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportJob) Reset() {
+	*x = ImportJob{}
+	mi := &file_library_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJob) ProtoMessage() {}
+
+func (x *ImportJob) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJob.ProtoReflect.Descriptor instead.
+func (*ImportJob) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportJob) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ImportJob) GetFormat() ImportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ImportFormat_IMPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportJob) GetState() ImportJob_State {
+	if x != nil {
+		return x.State
+	}
+	return ImportJob_STATE_UNSPECIFIED
+}
+
+func (x *ImportJob) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportJob) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportJob) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportJob) GetUnmatched() []*UnmatchedEntry {
+	if x != nil {
+		return x.Unmatched
+	}
+	return nil
+}
+
+func (x *ImportJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ImportJob) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type GetImportJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportJobRequest) Reset() {
+	*x = GetImportJobRequest{}
+	mi := &file_library_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportJobRequest) ProtoMessage() {}
+
+func (x *GetImportJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportJobRequest.ProtoReflect.Descriptor instead.
+func (*GetImportJobRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetImportJobRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetImportJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// ResolveImportEntryRequest imports an unmatched entry as a title picked by
+// the user.
+type ResolveImportEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	ExternalId    string                 `protobuf:"bytes,3,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	KodikId       string                 `protobuf:"bytes,4,opt,name=kodik_id,json=kodikId,proto3" json:"kodik_id,omitempty"`
+	AniflowId     string                 `protobuf:"bytes,5,opt,name=aniflow_id,json=aniflowId,proto3" json:"aniflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveImportEntryRequest) Reset() {
+	*x = ResolveImportEntryRequest{}
+	mi := &file_library_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveImportEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveImportEntryRequest) ProtoMessage() {}
+
+func (x *ResolveImportEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveImportEntryRequest.ProtoReflect.Descriptor instead.
+func (*ResolveImportEntryRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ResolveImportEntryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ResolveImportEntryRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ResolveImportEntryRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *ResolveImportEntryRequest) GetKodikId() string {
+	if x != nil {
+		return x.KodikId
+	}
+	return ""
+}
+
+func (x *ResolveImportEntryRequest) GetAniflowId() string {
+	if x != nil {
+		return x.AniflowId
+	}
+	return ""
+}
+
+type ExportLibraryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Format        ExportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=aniflow.library.v1.ExportFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportLibraryRequest) Reset() {
+	*x = ExportLibraryRequest{}
+	mi := &file_library_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportLibraryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportLibraryRequest) ProtoMessage() {}
+
+func (x *ExportLibraryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportLibraryRequest.ProtoReflect.Descriptor instead.
+func (*ExportLibraryRequest) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExportLibraryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportLibraryRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+type ExportLibraryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportLibraryResponse) Reset() {
+	*x = ExportLibraryResponse{}
+	mi := &file_library_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportLibraryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportLibraryResponse) ProtoMessage() {}
+
+func (x *ExportLibraryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportLibraryResponse.ProtoReflect.Descriptor instead.
+func (*ExportLibraryResponse) Descriptor() ([]byte, []int) {
+	return file_library_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ExportLibraryResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportLibraryResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportLibraryResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_library_proto protoreflect.FileDescriptor
+
+const file_library_proto_rawDesc = "" +
+	"\n" +
+	"\rlibrary.proto\x12\x12aniflow.library.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe0\x02\n" +
+	"\rWatchlistItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bkodik_id\x18\x03 \x01(\tR\akodikId\x125\n" +
+	"\badded_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x05 \x01(\tR\taniflowId\x122\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x1a.aniflow.library.v1.StatusR\x06status\x12\x14\n" +
+	"\x05score\x18\a \x01(\x05R\x05score\x12\x1a\n" +
+	"\bprogress\x18\b \x01(\x05R\bprogress\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05notes\x18\n" +
+	" \x01(\tR\x05notes\"_\n" +
+	"\n" +
+	"AddRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bkodik_id\x18\x02 \x01(\tR\akodikId\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x03 \x01(\tR\taniflowId\"D\n" +
+	"\vAddResponse\x125\n" +
+	"\x04item\x18\x01 \x01(\v2!.aniflow.library.v1.WatchlistItemR\x04item\".\n" +
+	"\x13GetWatchlistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"O\n" +
+	"\x14GetWatchlistResponse\x127\n" +
+	"\x05items\x18\x01 \x03(\v2!.aniflow.library.v1.WatchlistItemR\x05items\"2\n" +
+	"\x13ListWatchersRequest\x12\x1b\n" +
+	"\tkodik_ids\x18\x01 \x03(\tR\bkodikIds\"@\n" +
+	"\bWatchers\x12\x19\n" +
+	"\bkodik_id\x18\x01 \x01(\tR\akodikId\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"P\n" +
+	"\x14ListWatchersResponse\x128\n" +
+	"\bwatchers\x18\x01 \x03(\v2\x1c.aniflow.library.v1.WatchersR\bwatchers\"\xf3\x01\n" +
+	"\x11UpdateItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bkodik_id\x18\x02 \x01(\tR\akodikId\x122\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1a.aniflow.library.v1.StatusR\x06status\x12\x19\n" +
+	"\x05score\x18\x04 \x01(\x05H\x00R\x05score\x88\x01\x01\x12\x1f\n" +
+	"\bprogress\x18\x05 \x01(\x05H\x01R\bprogress\x88\x01\x01\x12\x19\n" +
+	"\x05notes\x18\x06 \x01(\tH\x02R\x05notes\x88\x01\x01B\b\n" +
+	"\x06_scoreB\v\n" +
+	"\t_progressB\b\n" +
+	"\x06_notes\"K\n" +
+	"\x12UpdateItemResponse\x125\n" +
+	"\x04item\x18\x01 \x01(\v2!.aniflow.library.v1.WatchlistItemR\x04item\"c\n" +
+	"\x11GetSimilarRequest\x12\x19\n" +
+	"\bkodik_id\x18\x01 \x01(\tR\akodikId\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x02 \x01(\tR\taniflowId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"s\n" +
+	"\vSimilarItem\x12\x19\n" +
+	"\bkodik_id\x18\x01 \x01(\tR\akodikId\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x02 \x01(\tR\taniflowId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x14\n" +
+	"\x05users\x18\x04 \x01(\x05R\x05users\"\x88\x01\n" +
+	"\x12GetSimilarResponse\x125\n" +
+	"\x05items\x18\x01 \x03(\v2\x1f.aniflow.library.v1.SimilarItemR\x05items\x12;\n" +
+	"\vcomputed_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"computedAt\"]\n" +
+	"\x12GetTrendingRequest\x121\n" +
+	"\x06window\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x8e\x01\n" +
+	"\fTrendingItem\x12\x19\n" +
+	"\bkodik_id\x18\x01 \x01(\tR\akodikId\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x02 \x01(\tR\taniflowId\x12\x12\n" +
+	"\x04adds\x18\x03 \x01(\x05R\x04adds\x12\x1a\n" +
+	"\bprogress\x18\x04 \x01(\x05R\bprogress\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x01R\x05score\"M\n" +
+	"\x13GetTrendingResponse\x126\n" +
+	"\x05items\x18\x01 \x03(\v2 .aniflow.library.v1.TrendingItemR\x05items\"\x95\x01\n" +
+	"\x0eCollectionItem\x12\x19\n" +
+	"\bkodik_id\x18\x01 \x01(\tR\akodikId\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x02 \x01(\tR\taniflowId\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\x125\n" +
+	"\badded_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\"\xef\x02\n" +
+	"\n" +
+	"Collection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12>\n" +
+	"\n" +
+	"visibility\x18\x06 \x01(\x0e2\x1e.aniflow.library.v1.VisibilityR\n" +
+	"visibility\x128\n" +
+	"\x05items\x18\a \x03(\v2\".aniflow.library.v1.CollectionItemR\x05items\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa8\x01\n" +
+	"\x17CreateCollectionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12>\n" +
+	"\n" +
+	"visibility\x18\x04 \x01(\x0e2\x1e.aniflow.library.v1.VisibilityR\n" +
+	"visibility\"\xf0\x01\n" +
+	"\x17UpdateCollectionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01\x12>\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\x0e2\x1e.aniflow.library.v1.VisibilityR\n" +
+	"visibilityB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_description\"W\n" +
+	"\x17DeleteCollectionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\"\x1a\n" +
+	"\x18DeleteCollectionResponse\"l\n" +
+	"\x14GetCollectionRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x1b\n" +
+	"\tviewer_id\x18\x03 \x01(\tR\bviewerId\"N\n" +
+	"\x16ListCollectionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\"[\n" +
+	"\x17ListCollectionsResponse\x12@\n" +
+	"\vcollections\x18\x01 \x03(\v2\x1e.aniflow.library.v1.CollectionR\vcollections\"\xd4\x01\n" +
+	"\x18AddCollectionItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\x12\x19\n" +
+	"\bkodik_id\x18\x03 \x01(\tR\akodikId\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x04 \x01(\tR\taniflowId\x12\x12\n" +
+	"\x04note\x18\x05 \x01(\tR\x04note\x12\x1f\n" +
+	"\bposition\x18\x06 \x01(\x05H\x00R\bposition\x88\x01\x01B\v\n" +
+	"\t_position\"v\n" +
+	"\x1bRemoveCollectionItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\x12\x19\n" +
+	"\bkodik_id\x18\x03 \x01(\tR\akodikId\"u\n" +
+	"\x18ReorderCollectionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\x12\x1b\n" +
+	"\tkodik_ids\x18\x03 \x03(\tR\bkodikIds\"\x99\x01\n" +
+	"\x12StartImportRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x128\n" +
+	"\x06format\x18\x02 \x01(\x0e2 .aniflow.library.v1.ImportFormatR\x06format\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x1c\n" +
+	"\toverwrite\x18\x04 \x01(\bR\toverwrite\"\xc5\x01\n" +
+	"\x0eUnmatchedEntry\x12\x1f\n" +
+	"\vexternal_id\x18\x01 \x01(\tR\n" +
+	"externalId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x122\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1a.aniflow.library.v1.StatusR\x06status\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x05R\x05score\x12\x1a\n" +
+	"\bprogress\x18\x05 \x01(\x05R\bprogress\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\"\xf2\x03\n" +
+	"\tImportJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x128\n" +
+	"\x06format\x18\x03 \x01(\x0e2 .aniflow.library.v1.ImportFormatR\x06format\x129\n" +
+	"\x05state\x18\x04 \x01(\x0e2#.aniflow.library.v1.ImportJob.StateR\x05state\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x05R\x05total\x12\x1a\n" +
+	"\bimported\x18\x06 \x01(\x05R\bimported\x12\x18\n" +
+	"\askipped\x18\a \x01(\x05R\askipped\x12@\n" +
+	"\tunmatched\x18\b \x03(\v2\".aniflow.library.v1.UnmatchedEntryR\tunmatched\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vfinished_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"A\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATE_RUNNING\x10\x01\x12\x0e\n" +
+	"\n" +
+	"STATE_DONE\x10\x02\"E\n" +
+	"\x13GetImportJobRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\"\xa6\x01\n" +
+	"\x19ResolveImportEntryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x1f\n" +
+	"\vexternal_id\x18\x03 \x01(\tR\n" +
+	"externalId\x12\x19\n" +
+	"\bkodik_id\x18\x04 \x01(\tR\akodikId\x12\x1d\n" +
+	"\n" +
+	"aniflow_id\x18\x05 \x01(\tR\taniflowId\"i\n" +
+	"\x14ExportLibraryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x128\n" +
+	"\x06format\x18\x02 \x01(\x0e2 .aniflow.library.v1.ExportFormatR\x06format\"j\n" +
+	"\x15ExportLibraryResponse\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data*\x87\x01\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSTATUS_PLANNED\x10\x01\x12\x13\n" +
+	"\x0fSTATUS_WATCHING\x10\x02\x12\x14\n" +
+	"\x10STATUS_COMPLETED\x10\x03\x12\x12\n" +
+	"\x0eSTATUS_ON_HOLD\x10\x04\x12\x12\n" +
+	"\x0eSTATUS_DROPPED\x10\x05*p\n" +
+	"\n" +
+	"Visibility\x12\x1a\n" +
+	"\x16VISIBILITY_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12VISIBILITY_PRIVATE\x10\x01\x12\x17\n" +
+	"\x13VISIBILITY_UNLISTED\x10\x02\x12\x15\n" +
+	"\x11VISIBILITY_PUBLIC\x10\x03*j\n" +
+	"\fImportFormat\x12\x1d\n" +
+	"\x19IMPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15IMPORT_FORMAT_MAL_XML\x10\x01\x12 \n" +
+	"\x1cIMPORT_FORMAT_SHIKIMORI_JSON\x10\x02*w\n" +
+	"\fExportFormat\x12\x1d\n" +
+	"\x19EXPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15EXPORT_FORMAT_MAL_XML\x10\x01\x12\x15\n" +
+	"\x11EXPORT_FORMAT_CSV\x10\x02\x12\x16\n" +
+	"\x12EXPORT_FORMAT_JSON\x10\x032\xdb\r\n" +
+	"\aLibrary\x12Q\n" +
+	"\x0eAddToWatchlist\x12\x1e.aniflow.library.v1.AddRequest\x1a\x1f.aniflow.library.v1.AddResponse\x12a\n" +
+	"\fGetWatchlist\x12'.aniflow.library.v1.GetWatchlistRequest\x1a(.aniflow.library.v1.GetWatchlistResponse\x12a\n" +
+	"\fListWatchers\x12'.aniflow.library.v1.ListWatchersRequest\x1a(.aniflow.library.v1.ListWatchersResponse\x12[\n" +
+	"\n" +
+	"UpdateItem\x12%.aniflow.library.v1.UpdateItemRequest\x1a&.aniflow.library.v1.UpdateItemResponse\x12[\n" +
+	"\n" +
+	"GetSimilar\x12%.aniflow.library.v1.GetSimilarRequest\x1a&.aniflow.library.v1.GetSimilarResponse\x12^\n" +
+	"\vGetTrending\x12&.aniflow.library.v1.GetTrendingRequest\x1a'.aniflow.library.v1.GetTrendingResponse\x12_\n" +
+	"\x10CreateCollection\x12+.aniflow.library.v1.CreateCollectionRequest\x1a\x1e.aniflow.library.v1.Collection\x12_\n" +
+	"\x10UpdateCollection\x12+.aniflow.library.v1.UpdateCollectionRequest\x1a\x1e.aniflow.library.v1.Collection\x12m\n" +
+	"\x10DeleteCollection\x12+.aniflow.library.v1.DeleteCollectionRequest\x1a,.aniflow.library.v1.DeleteCollectionResponse\x12Y\n" +
+	"\rGetCollection\x12(.aniflow.library.v1.GetCollectionRequest\x1a\x1e.aniflow.library.v1.Collection\x12j\n" +
+	"\x0fListCollections\x12*.aniflow.library.v1.ListCollectionsRequest\x1a+.aniflow.library.v1.ListCollectionsResponse\x12a\n" +
+	"\x11AddCollectionItem\x12,.aniflow.library.v1.AddCollectionItemRequest\x1a\x1e.aniflow.library.v1.Collection\x12g\n" +
+	"\x14RemoveCollectionItem\x12/.aniflow.library.v1.RemoveCollectionItemRequest\x1a\x1e.aniflow.library.v1.Collection\x12a\n" +
+	"\x11ReorderCollection\x12,.aniflow.library.v1.ReorderCollectionRequest\x1a\x1e.aniflow.library.v1.Collection\x12T\n" +
+	"\vStartImport\x12&.aniflow.library.v1.StartImportRequest\x1a\x1d.aniflow.library.v1.ImportJob\x12V\n" +
+	"\fGetImportJob\x12'.aniflow.library.v1.GetImportJobRequest\x1a\x1d.aniflow.library.v1.ImportJob\x12b\n" +
+	"\x12ResolveImportEntry\x12-.aniflow.library.v1.ResolveImportEntryRequest\x1a\x1d.aniflow.library.v1.ImportJob\x12d\n" +
+	"\rExportLibrary\x12(.aniflow.library.v1.ExportLibraryRequest\x1a).aniflow.library.v1.ExportLibraryResponseB<Z:github.com/greg5320/aniflow/services/library/gen;librarypbb\x06proto3"
 
 var (
 	file_library_proto_rawDescOnce sync.Once
@@ -310,28 +2770,122 @@ func file_library_proto_rawDescGZIP() []byte {
 	return file_library_proto_rawDescData
 }
 
-var file_library_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_library_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_library_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_library_proto_goTypes = []any{
-	(*WatchlistItem)(nil),         // 0: aniflow.library.v1.WatchlistItem
-	(*AddRequest)(nil),            // 1: aniflow.library.v1.AddRequest
-	(*AddResponse)(nil),           // 2: aniflow.library.v1.AddResponse
-	(*GetWatchlistRequest)(nil),   // 3: aniflow.library.v1.GetWatchlistRequest
-	(*GetWatchlistResponse)(nil),  // 4: aniflow.library.v1.GetWatchlistResponse
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(Status)(0),                         // 0: aniflow.library.v1.Status
+	(Visibility)(0),                     // 1: aniflow.library.v1.Visibility
+	(ImportFormat)(0),                   // 2: aniflow.library.v1.ImportFormat
+	(ExportFormat)(0),                   // 3: aniflow.library.v1.ExportFormat
+	(ImportJob_State)(0),                // 4: aniflow.library.v1.ImportJob.State
+	(*WatchlistItem)(nil),               // 5: aniflow.library.v1.WatchlistItem
+	(*AddRequest)(nil),                  // 6: aniflow.library.v1.AddRequest
+	(*AddResponse)(nil),                 // 7: aniflow.library.v1.AddResponse
+	(*GetWatchlistRequest)(nil),         // 8: aniflow.library.v1.GetWatchlistRequest
+	(*GetWatchlistResponse)(nil),        // 9: aniflow.library.v1.GetWatchlistResponse
+	(*ListWatchersRequest)(nil),         // 10: aniflow.library.v1.ListWatchersRequest
+	(*Watchers)(nil),                    // 11: aniflow.library.v1.Watchers
+	(*ListWatchersResponse)(nil),        // 12: aniflow.library.v1.ListWatchersResponse
+	(*UpdateItemRequest)(nil),           // 13: aniflow.library.v1.UpdateItemRequest
+	(*UpdateItemResponse)(nil),          // 14: aniflow.library.v1.UpdateItemResponse
+	(*GetSimilarRequest)(nil),           // 15: aniflow.library.v1.GetSimilarRequest
+	(*SimilarItem)(nil),                 // 16: aniflow.library.v1.SimilarItem
+	(*GetSimilarResponse)(nil),          // 17: aniflow.library.v1.GetSimilarResponse
+	(*GetTrendingRequest)(nil),          // 18: aniflow.library.v1.GetTrendingRequest
+	(*TrendingItem)(nil),                // 19: aniflow.library.v1.TrendingItem
+	(*GetTrendingResponse)(nil),         // 20: aniflow.library.v1.GetTrendingResponse
+	(*CollectionItem)(nil),              // 21: aniflow.library.v1.CollectionItem
+	(*Collection)(nil),                  // 22: aniflow.library.v1.Collection
+	(*CreateCollectionRequest)(nil),     // 23: aniflow.library.v1.CreateCollectionRequest
+	(*UpdateCollectionRequest)(nil),     // 24: aniflow.library.v1.UpdateCollectionRequest
+	(*DeleteCollectionRequest)(nil),     // 25: aniflow.library.v1.DeleteCollectionRequest
+	(*DeleteCollectionResponse)(nil),    // 26: aniflow.library.v1.DeleteCollectionResponse
+	(*GetCollectionRequest)(nil),        // 27: aniflow.library.v1.GetCollectionRequest
+	(*ListCollectionsRequest)(nil),      // 28: aniflow.library.v1.ListCollectionsRequest
+	(*ListCollectionsResponse)(nil),     // 29: aniflow.library.v1.ListCollectionsResponse
+	(*AddCollectionItemRequest)(nil),    // 30: aniflow.library.v1.AddCollectionItemRequest
+	(*RemoveCollectionItemRequest)(nil), // 31: aniflow.library.v1.RemoveCollectionItemRequest
+	(*ReorderCollectionRequest)(nil),    // 32: aniflow.library.v1.ReorderCollectionRequest
+	(*StartImportRequest)(nil),          // 33: aniflow.library.v1.StartImportRequest
+	(*UnmatchedEntry)(nil),              // 34: aniflow.library.v1.UnmatchedEntry
+	(*ImportJob)(nil),                   // 35: aniflow.library.v1.ImportJob
+	(*GetImportJobRequest)(nil),         // 36: aniflow.library.v1.GetImportJobRequest
+	(*ResolveImportEntryRequest)(nil),   // 37: aniflow.library.v1.ResolveImportEntryRequest
+	(*ExportLibraryRequest)(nil),        // 38: aniflow.library.v1.ExportLibraryRequest
+	(*ExportLibraryResponse)(nil),       // 39: aniflow.library.v1.ExportLibraryResponse
+	(*timestamppb.Timestamp)(nil),       // 40: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),         // 41: google.protobuf.Duration
 }
 var file_library_proto_depIdxs = []int32{
-	5, // 0: aniflow.library.v1.WatchlistItem.added_at:type_name -> google.protobuf.Timestamp
-	0, // 1: aniflow.library.v1.AddResponse.item:type_name -> aniflow.library.v1.WatchlistItem
-	0, // 2: aniflow.library.v1.GetWatchlistResponse.items:type_name -> aniflow.library.v1.WatchlistItem
-	1, // 3: aniflow.library.v1.Library.AddToWatchlist:input_type -> aniflow.library.v1.AddRequest
-	3, // 4: aniflow.library.v1.Library.GetWatchlist:input_type -> aniflow.library.v1.GetWatchlistRequest
-	2, // 5: aniflow.library.v1.Library.AddToWatchlist:output_type -> aniflow.library.v1.AddResponse
-	4, // 6: aniflow.library.v1.Library.GetWatchlist:output_type -> aniflow.library.v1.GetWatchlistResponse
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	40, // 0: aniflow.library.v1.WatchlistItem.added_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: aniflow.library.v1.WatchlistItem.status:type_name -> aniflow.library.v1.Status
+	40, // 2: aniflow.library.v1.WatchlistItem.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 3: aniflow.library.v1.AddResponse.item:type_name -> aniflow.library.v1.WatchlistItem
+	5,  // 4: aniflow.library.v1.GetWatchlistResponse.items:type_name -> aniflow.library.v1.WatchlistItem
+	11, // 5: aniflow.library.v1.ListWatchersResponse.watchers:type_name -> aniflow.library.v1.Watchers
+	0,  // 6: aniflow.library.v1.UpdateItemRequest.status:type_name -> aniflow.library.v1.Status
+	5,  // 7: aniflow.library.v1.UpdateItemResponse.item:type_name -> aniflow.library.v1.WatchlistItem
+	16, // 8: aniflow.library.v1.GetSimilarResponse.items:type_name -> aniflow.library.v1.SimilarItem
+	40, // 9: aniflow.library.v1.GetSimilarResponse.computed_at:type_name -> google.protobuf.Timestamp
+	41, // 10: aniflow.library.v1.GetTrendingRequest.window:type_name -> google.protobuf.Duration
+	19, // 11: aniflow.library.v1.GetTrendingResponse.items:type_name -> aniflow.library.v1.TrendingItem
+	40, // 12: aniflow.library.v1.CollectionItem.added_at:type_name -> google.protobuf.Timestamp
+	1,  // 13: aniflow.library.v1.Collection.visibility:type_name -> aniflow.library.v1.Visibility
+	21, // 14: aniflow.library.v1.Collection.items:type_name -> aniflow.library.v1.CollectionItem
+	40, // 15: aniflow.library.v1.Collection.created_at:type_name -> google.protobuf.Timestamp
+	40, // 16: aniflow.library.v1.Collection.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 17: aniflow.library.v1.CreateCollectionRequest.visibility:type_name -> aniflow.library.v1.Visibility
+	1,  // 18: aniflow.library.v1.UpdateCollectionRequest.visibility:type_name -> aniflow.library.v1.Visibility
+	22, // 19: aniflow.library.v1.ListCollectionsResponse.collections:type_name -> aniflow.library.v1.Collection
+	2,  // 20: aniflow.library.v1.StartImportRequest.format:type_name -> aniflow.library.v1.ImportFormat
+	0,  // 21: aniflow.library.v1.UnmatchedEntry.status:type_name -> aniflow.library.v1.Status
+	2,  // 22: aniflow.library.v1.ImportJob.format:type_name -> aniflow.library.v1.ImportFormat
+	4,  // 23: aniflow.library.v1.ImportJob.state:type_name -> aniflow.library.v1.ImportJob.State
+	34, // 24: aniflow.library.v1.ImportJob.unmatched:type_name -> aniflow.library.v1.UnmatchedEntry
+	40, // 25: aniflow.library.v1.ImportJob.created_at:type_name -> google.protobuf.Timestamp
+	40, // 26: aniflow.library.v1.ImportJob.finished_at:type_name -> google.protobuf.Timestamp
+	3,  // 27: aniflow.library.v1.ExportLibraryRequest.format:type_name -> aniflow.library.v1.ExportFormat
+	6,  // 28: aniflow.library.v1.Library.AddToWatchlist:input_type -> aniflow.library.v1.AddRequest
+	8,  // 29: aniflow.library.v1.Library.GetWatchlist:input_type -> aniflow.library.v1.GetWatchlistRequest
+	10, // 30: aniflow.library.v1.Library.ListWatchers:input_type -> aniflow.library.v1.ListWatchersRequest
+	13, // 31: aniflow.library.v1.Library.UpdateItem:input_type -> aniflow.library.v1.UpdateItemRequest
+	15, // 32: aniflow.library.v1.Library.GetSimilar:input_type -> aniflow.library.v1.GetSimilarRequest
+	18, // 33: aniflow.library.v1.Library.GetTrending:input_type -> aniflow.library.v1.GetTrendingRequest
+	23, // 34: aniflow.library.v1.Library.CreateCollection:input_type -> aniflow.library.v1.CreateCollectionRequest
+	24, // 35: aniflow.library.v1.Library.UpdateCollection:input_type -> aniflow.library.v1.UpdateCollectionRequest
+	25, // 36: aniflow.library.v1.Library.DeleteCollection:input_type -> aniflow.library.v1.DeleteCollectionRequest
+	27, // 37: aniflow.library.v1.Library.GetCollection:input_type -> aniflow.library.v1.GetCollectionRequest
+	28, // 38: aniflow.library.v1.Library.ListCollections:input_type -> aniflow.library.v1.ListCollectionsRequest
+	30, // 39: aniflow.library.v1.Library.AddCollectionItem:input_type -> aniflow.library.v1.AddCollectionItemRequest
+	31, // 40: aniflow.library.v1.Library.RemoveCollectionItem:input_type -> aniflow.library.v1.RemoveCollectionItemRequest
+	32, // 41: aniflow.library.v1.Library.ReorderCollection:input_type -> aniflow.library.v1.ReorderCollectionRequest
+	33, // 42: aniflow.library.v1.Library.StartImport:input_type -> aniflow.library.v1.StartImportRequest
+	36, // 43: aniflow.library.v1.Library.GetImportJob:input_type -> aniflow.library.v1.GetImportJobRequest
+	37, // 44: aniflow.library.v1.Library.ResolveImportEntry:input_type -> aniflow.library.v1.ResolveImportEntryRequest
+	38, // 45: aniflow.library.v1.Library.ExportLibrary:input_type -> aniflow.library.v1.ExportLibraryRequest
+	7,  // 46: aniflow.library.v1.Library.AddToWatchlist:output_type -> aniflow.library.v1.AddResponse
+	9,  // 47: aniflow.library.v1.Library.GetWatchlist:output_type -> aniflow.library.v1.GetWatchlistResponse
+	12, // 48: aniflow.library.v1.Library.ListWatchers:output_type -> aniflow.library.v1.ListWatchersResponse
+	14, // 49: aniflow.library.v1.Library.UpdateItem:output_type -> aniflow.library.v1.UpdateItemResponse
+	17, // 50: aniflow.library.v1.Library.GetSimilar:output_type -> aniflow.library.v1.GetSimilarResponse
+	20, // 51: aniflow.library.v1.Library.GetTrending:output_type -> aniflow.library.v1.GetTrendingResponse
+	22, // 52: aniflow.library.v1.Library.CreateCollection:output_type -> aniflow.library.v1.Collection
+	22, // 53: aniflow.library.v1.Library.UpdateCollection:output_type -> aniflow.library.v1.Collection
+	26, // 54: aniflow.library.v1.Library.DeleteCollection:output_type -> aniflow.library.v1.DeleteCollectionResponse
+	22, // 55: aniflow.library.v1.Library.GetCollection:output_type -> aniflow.library.v1.Collection
+	29, // 56: aniflow.library.v1.Library.ListCollections:output_type -> aniflow.library.v1.ListCollectionsResponse
+	22, // 57: aniflow.library.v1.Library.AddCollectionItem:output_type -> aniflow.library.v1.Collection
+	22, // 58: aniflow.library.v1.Library.RemoveCollectionItem:output_type -> aniflow.library.v1.Collection
+	22, // 59: aniflow.library.v1.Library.ReorderCollection:output_type -> aniflow.library.v1.Collection
+	35, // 60: aniflow.library.v1.Library.StartImport:output_type -> aniflow.library.v1.ImportJob
+	35, // 61: aniflow.library.v1.Library.GetImportJob:output_type -> aniflow.library.v1.ImportJob
+	35, // 62: aniflow.library.v1.Library.ResolveImportEntry:output_type -> aniflow.library.v1.ImportJob
+	39, // 63: aniflow.library.v1.Library.ExportLibrary:output_type -> aniflow.library.v1.ExportLibraryResponse
+	46, // [46:64] is the sub-list for method output_type
+	28, // [28:46] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_library_proto_init() }
@@ -339,18 +2893,22 @@ func file_library_proto_init() {
 	if File_library_proto != nil {
 		return
 	}
+	file_library_proto_msgTypes[8].OneofWrappers = []any{}
+	file_library_proto_msgTypes[19].OneofWrappers = []any{}
+	file_library_proto_msgTypes[25].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_library_proto_rawDesc), len(file_library_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      5,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_library_proto_goTypes,
 		DependencyIndexes: file_library_proto_depIdxs,
+		EnumInfos:         file_library_proto_enumTypes,
 		MessageInfos:      file_library_proto_msgTypes,
 	}.Build()
 	File_library_proto = out.File
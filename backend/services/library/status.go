@@ -0,0 +1,26 @@
+package librarypb
+
+// statusNames are the names statuses go by outside gRPC: in the gateway
+// API, in library storage and in recommendation profiles.
+var statusNames = map[Status]string{
+	Status_STATUS_PLANNED:   "planned",
+	Status_STATUS_WATCHING:  "watching",
+	Status_STATUS_COMPLETED: "completed",
+	Status_STATUS_ON_HOLD:   "on_hold",
+	Status_STATUS_DROPPED:   "dropped",
+}
+
+// Name returns the name of s, e.g. "on_hold", or "" if s is unspecified.
+func (s Status) Name() string {
+	return statusNames[s]
+}
+
+// ParseStatus returns the status called name.
+func ParseStatus(name string) (Status, bool) {
+	for st, n := range statusNames {
+		if n == name {
+			return st, true
+		}
+	}
+	return Status_STATUS_UNSPECIFIED, false
+}